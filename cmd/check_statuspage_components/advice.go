@@ -19,8 +19,9 @@ import (
 
 // filterErrAdvice is a small helper function used to evaluate the specific
 // filter error that occurred and offer the user some feedback or advice for
-// resolving it.
-func filterErrAdvice(err error, cs *components.Set, filter components.Filter, feedSrc string) string {
+// resolving it. If the feed URL was derived from a user-specified base status
+// page URL or page ID value, that value is noted along with the derived URL.
+func filterErrAdvice(err error, cs *components.Set, filter components.Filter, feedSrc string, feedSrcProvided string) string {
 
 	var tryAgainMsg strings.Builder
 
@@ -46,12 +47,30 @@ func filterErrAdvice(err error, cs *components.Set, filter components.Filter, fe
 			nagios.CheckOutputEOL,
 		)
 
+	case errors.Is(err, components.ErrComponentSetFilterInvalidMatchMode):
+		_, _ = fmt.Fprintf(
+			&tryAgainMsg,
+			"Double-check provided match mode (supported modes: %s).%s",
+			strings.Join(components.SupportedMatchModes(), ", "),
+			nagios.CheckOutputEOL,
+		)
+
+	case errors.Is(err, components.ErrComponentSetFilterInvalidPattern):
+		_, _ = fmt.Fprintf(
+			&tryAgainMsg,
+			"Double-check provided component group or component values (not a valid pattern for the %q match mode).%s",
+			filterMatchMode(filter),
+			nagios.CheckOutputEOL,
+		)
+
 	case errors.Is(err, components.ErrComponentGroupNotFound):
 		_, _ = fmt.Fprintf(
 			&tryAgainMsg,
 			"Double-check provided component group name or ID values (provided value not found).%s",
 			nagios.CheckOutputEOL,
 		)
+		matchModeAdvice(&tryAgainMsg, filter)
+		suggestionsAdvice(&tryAgainMsg, cs, filter, true)
 
 	case errors.Is(err, components.ErrComponentIsNotValidSubcomponent):
 		_, _ = fmt.Fprintf(
@@ -60,6 +79,7 @@ func filterErrAdvice(err error, cs *components.Set, filter components.Filter, fe
 				"(mismatch between group/subcomponent values).%s",
 			nagios.CheckOutputEOL,
 		)
+		matchModeAdvice(&tryAgainMsg, filter)
 
 	case errors.Is(err, components.ErrComponentNotFound):
 		_, _ = fmt.Fprintf(
@@ -67,6 +87,18 @@ func filterErrAdvice(err error, cs *components.Set, filter components.Filter, fe
 			"Double-check provided component name or ID values (provided value not found).%s",
 			nagios.CheckOutputEOL,
 		)
+		matchModeAdvice(&tryAgainMsg, filter)
+		suggestionsAdvice(&tryAgainMsg, cs, filter, false)
+
+	case errors.Is(err, components.ErrComponentIsNotComponentGroup):
+		_, _ = fmt.Fprintf(
+			&tryAgainMsg,
+			"Double-check provided component group or excluded component group values "+
+				"(provided value is not a component group; use the %s flag to exclude individual components).%s",
+			config.ExcludeComponentsFlagLong,
+			nagios.CheckOutputEOL,
+		)
+		matchModeAdvice(&tryAgainMsg, filter)
 
 	// NOTE: While this plugin supports evaluating all components (and
 	// therefore results in an empty filter), this error is only
@@ -108,6 +140,122 @@ func filterErrAdvice(err error, cs *components.Set, filter components.Filter, fe
 		nagios.CheckOutputEOL,
 	)
 
+	if feedSrcProvided != "" && feedSrcProvided != feedSrc {
+		_, _ = fmt.Fprintf(
+			&tryAgainMsg,
+			"%sNOTE: The feed URL %q was derived from the provided value %q.%s",
+			nagios.CheckOutputEOL,
+			feedSrc,
+			feedSrcProvided,
+			nagios.CheckOutputEOL,
+		)
+	}
+
 	return tryAgainMsg.String()
 
 }
+
+// filterMatchMode returns the match mode used by the given filter. The
+// default match mode is returned if one was not specified.
+func filterMatchMode(filter components.Filter) string {
+	if filter.MatchMode == "" {
+		return components.MatchModeExact
+	}
+
+	return strings.ToLower(filter.MatchMode)
+}
+
+// matchModeAdvice is a helper function used to explain how component and
+// component group names were matched for the given filter. If names were
+// matched exactly, the user is pointed to the less strict match modes.
+func matchModeAdvice(tryAgainMsg *strings.Builder, filter components.Filter) {
+
+	matchMode := filterMatchMode(filter)
+
+	_, _ = fmt.Fprintf(
+		tryAgainMsg,
+		"Component and component group names were matched using the %q match mode.%s",
+		matchMode,
+		nagios.CheckOutputEOL,
+	)
+
+	if matchMode == components.MatchModeExact {
+		_, _ = fmt.Fprintf(
+			tryAgainMsg,
+			"Names must match exactly (including case); see the %s flag for the %q, %q and %q match modes.%s",
+			config.MatchModeFlagLong,
+			components.MatchModeCaseInsensitive,
+			components.MatchModeGlob,
+			components.MatchModeRegex,
+			nagios.CheckOutputEOL,
+		)
+	}
+}
+
+// maxSuggestions is the maximum number of similar components or component
+// groups suggested for each filter value which could not be matched.
+const maxSuggestions int = 3
+
+// suggestionsAdvice is a helper function used to suggest similar component
+// groups (if groups is true) or components for each group or component
+// value in the given filter which does not match any component name or ID
+// value in the set.
+func suggestionsAdvice(tryAgainMsg *strings.Builder, cs *components.Set, filter components.Filter, groups bool) {
+
+	var searchVals []string
+	switch {
+	case groups:
+		for _, group := range filter.Groups {
+			searchVals = append(searchVals, group.Group)
+		}
+		searchVals = append(searchVals, filter.ExcludeGroups...)
+
+	default:
+		for _, group := range filter.Groups {
+			searchVals = append(searchVals, group.Components...)
+		}
+		searchVals = append(searchVals, filter.Components...)
+		searchVals = append(searchVals, filter.ExcludeComponents...)
+	}
+
+	for _, searchVal := range searchVals {
+		if _, err := cs.GetComponentsByNameMatch(searchVal, filter.MatchMode); err == nil {
+			continue
+		}
+
+		if _, err := cs.GetComponentByID(searchVal); err == nil {
+			continue
+		}
+
+		suggestions := cs.Suggest(searchVal, groups, maxSuggestions)
+		if len(suggestions) == 0 {
+			_, _ = fmt.Fprintf(
+				tryAgainMsg,
+				"%sNo similar names or ID values found for %q.%s",
+				nagios.CheckOutputEOL,
+				searchVal,
+				nagios.CheckOutputEOL,
+			)
+
+			continue
+		}
+
+		_, _ = fmt.Fprintf(
+			tryAgainMsg,
+			"%sNo match found for %q. Did you mean:%s",
+			nagios.CheckOutputEOL,
+			searchVal,
+			nagios.CheckOutputEOL,
+		)
+
+		for _, suggestion := range suggestions {
+			_, _ = fmt.Fprintf(
+				tryAgainMsg,
+				"* %q (ID: %s)%s",
+				suggestion.Component.Name,
+				suggestion.Component.ID,
+				nagios.CheckOutputEOL,
+			)
+		}
+	}
+}
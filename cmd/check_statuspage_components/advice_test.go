@@ -0,0 +1,112 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/atc0005/check-statuspage/internal/statuspage/components"
+)
+
+// TestFilterErrAdviceSuggestions asserts that similar component or component
+// group names (along with their ID values) are suggested for filter values
+// which could not be matched.
+func TestFilterErrAdviceSuggestions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		filename         string
+		filter           components.Filter
+		expectedAdvice   []string
+		unexpectedAdvice []string
+	}{
+		{
+			name:     "Component name with different case and extra whitespace",
+			filename: "testdata/components/github-components.json",
+			filter: components.Filter{
+				Components: []string{"api  requests", "Issues"},
+			},
+			expectedAdvice: []string{
+				`No match found for "api  requests". Did you mean:`,
+				`* "API Requests" (ID: brv1bkgrwx7q)`,
+			},
+			unexpectedAdvice: []string{
+				`No match found for "Issues"`,
+			},
+		},
+		{
+			name:     "Misspelled component name",
+			filename: "testdata/components/github-components.json",
+			filter: components.Filter{
+				Components: []string{"Git Operatoins"},
+			},
+			expectedAdvice: []string{
+				`* "Git Operations" (ID: 8l4ygp009s5s)`,
+			},
+		},
+		{
+			name:     "Component group name with extra whitespace",
+			filename: "testdata/components/instructure-components.json",
+			filter: components.Filter{
+				Groups: []components.GroupFilter{{Group: "Mastery Connect"}},
+			},
+			expectedAdvice: []string{
+				`No match found for "Mastery Connect". Did you mean:`,
+				`* "MasteryConnect" (ID: qw5j90r2w7k1)`,
+			},
+		},
+		{
+			name:     "Component name without similar names",
+			filename: "testdata/components/github-components.json",
+			filter: components.Filter{
+				Components: []string{"Quantum Entanglement"},
+			},
+			expectedAdvice: []string{
+				`No similar names or ID values found for "Quantum Entanglement".`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filename := filepath.Join("../../", tt.filename)
+			componentsSet, err := components.NewFromFile(filename, 1048576, false)
+			if err != nil {
+				t.Fatalf("ERROR: failed to initialize components set: %v", err)
+			}
+
+			filterErr := componentsSet.Filter(tt.filter)
+			if filterErr == nil {
+				t.Fatalf("ERROR: filter %s successfully applied, but expected to fail", tt.filter)
+			}
+
+			advice := filterErrAdvice(filterErr, componentsSet, tt.filter, filename, "")
+
+			for _, want := range tt.expectedAdvice {
+				if !strings.Contains(advice, want) {
+					t.Errorf("ERROR: want advice to contain %q, got:\n%s", want, advice)
+				}
+			}
+
+			for _, unwanted := range tt.unexpectedAdvice {
+				if strings.Contains(advice, unwanted) {
+					t.Errorf("ERROR: want advice to not contain %q, got:\n%s", unwanted, advice)
+				}
+			}
+
+			if !t.Failed() {
+				t.Logf("OK: advice contains expected suggestions:\n%s", advice)
+			}
+		})
+	}
+}
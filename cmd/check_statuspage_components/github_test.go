@@ -8,7 +8,10 @@
 //nolint:dupl
 package main
 
-import "github.com/atc0005/go-nagios"
+import (
+	"github.com/atc0005/check-statuspage/internal/config"
+	"github.com/atc0005/go-nagios"
+)
 
 // As of this writing, GitHub doesn't use component groups, just "flat" or
 // top-level components to describe their infrastructure.
@@ -200,6 +203,131 @@ var githubEvalComponentsTestEntries = []evalComponentsTestdataFile{
 		filterErrorExpected:           false,
 		filterResultsMismatchExpected: false,
 	},
+	{
+		name:                       "(OK) GitHub, by one component name with different case, case-insensitive match mode, complete expected excluded list",
+		filenameFlagValue:          "testdata/components/github-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              defaultComponentFlag,
+		componentFlagValue:         "api requests",
+		evalAllComponentsFlagValue: defaultEvalAllFlagValue,
+		matchModeFlag:              defaultMatchModeFlag,
+		matchModeFlagValue:         config.MatchModeCaseInsensitive,
+		expectedExcludedComponentIDs: []string{
+			"8l4ygp009s5s", // Git Operations
+			// "brv1bkgrwx7q", // API Requests
+			"4230lsnqdsld", // Webhooks
+			"0l2p9nhqnxpd", // 'Visit www.githubstatus.com for more information'
+			"kr09ddfgbfsf", // Issues
+			"hhtssxt0f5v2", // Pull Requests
+			"br0l2tvcx85d", // GitHub Actions
+			"st3j38cctv9l", // GitHub Packages
+			"vg70hn9s2tyj", // GitHub Pages
+			"h2ftsgbw7kmk", // Codespaces
+		},
+		filterErrorExpected:           false,
+		filterResultsMismatchExpected: false,
+	},
+	{
+		name:                         "(FAIL) GitHub, by one component name with different case, exact match mode, empty expected excluded list",
+		filenameFlagValue:            "testdata/components/github-components.json",
+		groupFlag:                    "",
+		groupFlagValue:               "",
+		componentFlag:                defaultComponentFlag,
+		componentFlagValue:           "api requests",
+		evalAllComponentsFlagValue:   defaultEvalAllFlagValue,
+		matchModeFlag:                defaultMatchModeFlag,
+		matchModeFlagValue:           config.MatchModeExact,
+		expectedExcludedComponentIDs: []string{
+			// "8l4ygp009s5s", // Git Operations
+			// "brv1bkgrwx7q", // API Requests
+			// "4230lsnqdsld", // Webhooks
+			// "0l2p9nhqnxpd", // 'Visit www.githubstatus.com for more information'
+			// "kr09ddfgbfsf", // Issues
+			// "hhtssxt0f5v2", // Pull Requests
+			// "br0l2tvcx85d", // GitHub Actions
+			// "st3j38cctv9l", // GitHub Packages
+			// "vg70hn9s2tyj", // GitHub Pages
+			// "h2ftsgbw7kmk", // Codespaces
+		},
+		filterErrorExpected:           true,
+		filterResultsMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) GitHub, by one component glob pattern, glob match mode, complete expected excluded list",
+		filenameFlagValue:          "testdata/components/github-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              defaultComponentFlag,
+		componentFlagValue:         "GitHub Pa*",
+		evalAllComponentsFlagValue: defaultEvalAllFlagValue,
+		matchModeFlag:              defaultMatchModeFlag,
+		matchModeFlagValue:         config.MatchModeGlob,
+		expectedExcludedComponentIDs: []string{
+			"8l4ygp009s5s", // Git Operations
+			"brv1bkgrwx7q", // API Requests
+			"4230lsnqdsld", // Webhooks
+			"0l2p9nhqnxpd", // 'Visit www.githubstatus.com for more information'
+			"kr09ddfgbfsf", // Issues
+			"hhtssxt0f5v2", // Pull Requests
+			"br0l2tvcx85d", // GitHub Actions
+			// "st3j38cctv9l", // GitHub Packages
+			// "vg70hn9s2tyj", // GitHub Pages
+			"h2ftsgbw7kmk", // Codespaces
+		},
+		filterErrorExpected:           false,
+		filterResultsMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) GitHub, by one component regular expression, regex match mode, complete expected excluded list",
+		filenameFlagValue:          "testdata/components/github-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              defaultComponentFlag,
+		componentFlagValue:         "^(Issues|Pull Requests)$",
+		evalAllComponentsFlagValue: defaultEvalAllFlagValue,
+		matchModeFlag:              defaultMatchModeFlag,
+		matchModeFlagValue:         config.MatchModeRegex,
+		expectedExcludedComponentIDs: []string{
+			"8l4ygp009s5s", // Git Operations
+			"brv1bkgrwx7q", // API Requests
+			"4230lsnqdsld", // Webhooks
+			"0l2p9nhqnxpd", // 'Visit www.githubstatus.com for more information'
+			// "kr09ddfgbfsf", // Issues
+			// "hhtssxt0f5v2", // Pull Requests
+			"br0l2tvcx85d", // GitHub Actions
+			"st3j38cctv9l", // GitHub Packages
+			"vg70hn9s2tyj", // GitHub Pages
+			"h2ftsgbw7kmk", // Codespaces
+		},
+		filterErrorExpected:           false,
+		filterResultsMismatchExpected: false,
+	},
+	{
+		name:                         "(FAIL) GitHub, by one invalid component regular expression, regex match mode, empty expected excluded list",
+		filenameFlagValue:            "testdata/components/github-components.json",
+		groupFlag:                    "",
+		groupFlagValue:               "",
+		componentFlag:                defaultComponentFlag,
+		componentFlagValue:           "GitHub (Actions",
+		evalAllComponentsFlagValue:   defaultEvalAllFlagValue,
+		matchModeFlag:                defaultMatchModeFlag,
+		matchModeFlagValue:           config.MatchModeRegex,
+		expectedExcludedComponentIDs: []string{
+			// "8l4ygp009s5s", // Git Operations
+			// "brv1bkgrwx7q", // API Requests
+			// "4230lsnqdsld", // Webhooks
+			// "0l2p9nhqnxpd", // 'Visit www.githubstatus.com for more information'
+			// "kr09ddfgbfsf", // Issues
+			// "hhtssxt0f5v2", // Pull Requests
+			// "br0l2tvcx85d", // GitHub Actions
+			// "st3j38cctv9l", // GitHub Packages
+			// "vg70hn9s2tyj", // GitHub Pages
+			// "h2ftsgbw7kmk", // Codespaces
+		},
+		filterErrorExpected:           true,
+		filterResultsMismatchExpected: false,
+	},
 	{
 		name:                          "(OK) GitHub, eval all, empty expected excluded list",
 		filenameFlagValue:             "testdata/components/github-components.json",
@@ -215,6 +343,58 @@ var githubEvalComponentsTestEntries = []evalComponentsTestdataFile{
 }
 
 var githubEvalPluginStatusTestEntries = []evalPluginStatusTestdataFile{
+	{
+		name:                       "(OK) GitHub, eval all, component severity override raises state, valid plugin status",
+		filenameFlagValue:          "testdata/components/github-components-with-problem.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		severityFlagValues: []string{
+			"GitHub Actions:partial_outage=CRITICAL",
+		},
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) GitHub, eval all, component severity override by ID limits state, valid plugin status",
+		filenameFlagValue:          "testdata/components/github-components-with-problem.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		severityFlagValues: []string{
+			"br0l2tvcx85d:max=OK",
+		},
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) GitHub, eval all, component severity override for other component, valid plugin status",
+		filenameFlagValue:          "testdata/components/github-components-with-problem.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		severityFlagValues: []string{
+			"Git Operations:partial_outage=CRITICAL",
+			"Codespaces:max=OK",
+		},
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
 	{
 		name:                       "(FAIL) GitHub, eval all, invalid plugin status",
 		filenameFlagValue:          "testdata/components/github-components-with-problem.json",
@@ -243,6 +243,167 @@ var instructureEvalComponentsTestEntries = []evalComponentsTestdataFile{
 		filterErrorExpected:           false,
 		filterResultsMismatchExpected: false,
 	},
+	{
+		name:                       "(OK) Instructure, eval all, exclude group by name, valid expected excluded list",
+		filenameFlagValue:          "testdata/components/instructure-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		excludeGroupFlag:           defaultExcludeGroupFlag,
+		excludeGroupFlagValue:      "Portfolium",
+		excludeComponentFlag:       "",
+		excludeComponentFlagValue:  "",
+		expectedExcludedComponentIDs: []string{
+			"j7jp6sq831c2", "9c01dg04bfg5", "100xy482gkyf",
+			"c8zkn4rlhvw6",
+		},
+		filterErrorExpected:           false,
+		filterResultsMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Instructure, eval all, exclude groups by name and component by ID, valid expected excluded list",
+		filenameFlagValue:          "testdata/components/instructure-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		excludeGroupFlag:           defaultExcludeGroupFlag,
+		excludeGroupFlagValue:      "Portfolium,MasteryConnect",
+		excludeComponentFlag:       defaultExcludeComponentFlag,
+		excludeComponentFlagValue:  "57p1tjtk1yq0",
+		expectedExcludedComponentIDs: []string{
+			"j7jp6sq831c2", "9c01dg04bfg5", "100xy482gkyf",
+			"c8zkn4rlhvw6", "qw5j90r2w7k1", "v6m5nhwgtshj",
+			"jt1kl5fj472f", "142661pcf7h1", "xwqppk51m3mm",
+			"57p1tjtk1yq0",
+		},
+		filterErrorExpected:           false,
+		filterResultsMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Instructure, by group name, exclude component by name, valid expected excluded list",
+		filenameFlagValue:          "testdata/components/instructure-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "Canvas",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		excludeGroupFlag:           "",
+		excludeGroupFlagValue:      "",
+		excludeComponentFlag:       defaultExcludeComponentFlag,
+		excludeComponentFlagValue:  "— Chat",
+		expectedExcludedComponentIDs: []string{
+			"j7jp6sq831c2", "41wg86q5vc14", "v6m5nhwgtshj",
+			"9c01dg04bfg5", "jt1kl5fj472f", "100xy482gkyf",
+			"qw5j90r2w7k1", "c8zkn4rlhvw6", "142661pcf7h1",
+			"xwqppk51m3mm",
+			"57p1tjtk1yq0",
+		},
+		filterErrorExpected:           false,
+		filterResultsMismatchExpected: false,
+	},
+	{
+		name:                          "(FAIL) Instructure, eval all, exclude invalid group name, empty expected excluded list",
+		filenameFlagValue:             "testdata/components/instructure-components.json",
+		groupFlag:                     "",
+		groupFlagValue:                "",
+		componentFlag:                 "",
+		componentFlagValue:            "",
+		evalAllComponentsFlagValue:    "true",
+		excludeGroupFlag:              defaultExcludeGroupFlag,
+		excludeGroupFlagValue:         "Not a Group",
+		excludeComponentFlag:          "",
+		excludeComponentFlagValue:     "",
+		expectedExcludedComponentIDs:  []string{},
+		filterErrorExpected:           true,
+		filterResultsMismatchExpected: false,
+	},
+	{
+		name:                          "(FAIL) Instructure, eval all, exclude component name as group, empty expected excluded list",
+		filenameFlagValue:             "testdata/components/instructure-components.json",
+		groupFlag:                     "",
+		groupFlagValue:                "",
+		componentFlag:                 "",
+		componentFlagValue:            "",
+		evalAllComponentsFlagValue:    "true",
+		excludeGroupFlag:              defaultExcludeGroupFlag,
+		excludeGroupFlagValue:         "— Chat",
+		excludeComponentFlag:          "",
+		excludeComponentFlagValue:     "",
+		expectedExcludedComponentIDs:  []string{},
+		filterErrorExpected:           true,
+		filterResultsMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Instructure, by multiple group names, valid expected excluded list",
+		filenameFlagValue:          "testdata/components/instructure-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		additionalFilterFlags: []string{
+			defaultGroupFlag, "Portfolium",
+			defaultGroupFlag, "MasteryConnect",
+		},
+		expectedExcludedComponentIDs: []string{
+			"41wg86q5vc14", "9dlvqx1drp3d", "9c01dg04bfg5",
+			"jw0fn0dnpcgn", "57p1tjtk1yq0", "qw5j90r2w7k1",
+			"zxq967k6np07", "z5p8qvl1hj1y", "mtytktcmbk6p",
+			"ch8dsykb6hln", "qt6q9hfpbljc", "knh34j1129ft",
+			"6pnn3zwfyzxz", "d7cxm3fbff4h", "zlyh32dxbwjj",
+			"tlhdyd68vb55", "3q12z77wvfjp",
+		},
+		filterErrorExpected:           false,
+		filterResultsMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Instructure, by multiple group names each paired with a component name, valid expected excluded list",
+		filenameFlagValue:          "testdata/components/instructure-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		additionalFilterFlags: []string{
+			defaultGroupFlag, "Canvas",
+			defaultComponentFlag, "— Chat",
+			defaultGroupFlag, "MasteryConnect",
+			defaultComponentFlag, "Reporting",
+		},
+		expectedExcludedComponentIDs: []string{
+			"j7jp6sq831c2", "41wg86q5vc14", "9dlvqx1drp3d",
+			"v6m5nhwgtshj", "9c01dg04bfg5", "jt1kl5fj472f",
+			"100xy482gkyf", "jw0fn0dnpcgn", "qw5j90r2w7k1",
+			"c8zkn4rlhvw6", "142661pcf7h1", "zxq967k6np07",
+			"z5p8qvl1hj1y", "mtytktcmbk6p", "ch8dsykb6hln",
+			"qt6q9hfpbljc", "knh34j1129ft", "6pnn3zwfyzxz",
+			"d7cxm3fbff4h", "zlyh32dxbwjj", "tlhdyd68vb55",
+			"3q12z77wvfjp",
+		},
+		filterErrorExpected:           false,
+		filterResultsMismatchExpected: false,
+	},
+	{
+		name:                       "(FAIL) Instructure, by multiple group names, component paired with wrong group, empty expected excluded list",
+		filenameFlagValue:          "testdata/components/instructure-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		additionalFilterFlags: []string{
+			defaultGroupFlag, "Canvas",
+			defaultComponentFlag, "Reporting",
+			defaultGroupFlag, "MasteryConnect",
+		},
+		expectedExcludedComponentIDs:  []string{},
+		filterErrorExpected:           true,
+		filterResultsMismatchExpected: false,
+	},
 }
 
 var instructureEvalPluginStatusTestEntries = []evalPluginStatusTestdataFile{
@@ -13,11 +13,13 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/atc0005/go-nagios"
 
 	"github.com/atc0005/check-statuspage/internal/config"
 	"github.com/atc0005/check-statuspage/internal/reports"
+	"github.com/atc0005/check-statuspage/internal/statuspage"
 	"github.com/atc0005/check-statuspage/internal/statuspage/components"
 
 	"github.com/rs/zerolog"
@@ -78,17 +80,34 @@ func main() {
 	// Record thresholds for use as Nagios "Long Service Output" content. This
 	// content is shown in the detailed web UI and in notifications generated
 	// by Nagios.
+	//
+	// The user-specified status map (if any) overrides the default mapping of
+	// component statuses to service states.
+	//
+	// User-specified thresholds for evaluated components with a
+	// non-operational status (if any) determine the plugin state in place of
+	// the most severe component state.
+	statusMap := components.StatusMap(cfg.StatusMap())
+	groupRollup := components.GroupRollup(cfg.GroupRollup())
+	warningThreshold := components.ProblemThreshold(cfg.WarningThreshold())
+	criticalThreshold := components.ProblemThreshold(cfg.CriticalThreshold())
 	if cfg.ShowVerbose {
-		criticalComponentStatuses := components.ServiceStateToComponentStatuses(
-			nagios.ServiceState{ExitCode: nagios.StateCRITICALExitCode},
-		)
-		plugin.CriticalThreshold = strings.Join(criticalComponentStatuses, ", ")
-
-		warningComponentStatuses := components.ServiceStateToComponentStatuses(
-			nagios.ServiceState{ExitCode: nagios.StateWARNINGExitCode},
-		)
+		switch {
+		case cfg.HasProblemThresholds():
+			plugin.CriticalThreshold = problemThresholdText(criticalThreshold)
+			plugin.WarningThreshold = problemThresholdText(warningThreshold)
+
+		default:
+			plugin.CriticalThreshold = componentStatusesThreshold(
+				statusMap,
+				nagios.ServiceState{ExitCode: nagios.StateCRITICALExitCode},
+			)
 
-		plugin.WarningThreshold = strings.Join(warningComponentStatuses, ", ")
+			plugin.WarningThreshold = componentStatusesThreshold(
+				statusMap,
+				nagios.ServiceState{ExitCode: nagios.StateWARNINGExitCode},
+			)
+		}
 	}
 
 	if cfg.EmitBranding {
@@ -96,156 +115,263 @@ func main() {
 		plugin.BrandingCallback = config.Branding("Notification generated by ")
 	}
 
+	feeds := cfg.Feeds()
+
 	log := cfg.Log.With().
 		Str("filename", cfg.Filename).
 		Str("url", cfg.URL).
+		Str("provider", cfg.Provider).
+		Str("cache_dir", cfg.CacheDir).
+		Int("retries", cfg.Retries).
+		Dur("retry_delay", cfg.RetryDelay).
+		Int("feeds", len(feeds)).
 		Int64("read_limit", cfg.ReadLimit).
 		Bool("allow_unknown_fields", cfg.AllowUnknownJSONFields).
 		Logger()
 
-	// Process one of local file or remote URL. Rely on config package
-	// validation to prevent the user from specifying both.
-	var componentsSet *components.Set
-	var feedSource string
-	switch {
+	sources := make([]statuspage.FeedSource, 0, len(feeds))
+	for _, feed := range feeds {
+		sources = append(sources, statuspage.FeedSource{
+			URL:      feed.URL,
+			Filename: feed.Filename,
+		})
+	}
 
-	case cfg.Filename != "":
+	// Retrieve all feeds concurrently (up to the specified limit) within the
+	// same context deadline. Errors are collected for each feed instead of
+	// aborting at the first failure.
+	log.Debug().
+		Int("concurrency", cfg.Concurrency).
+		Msg("Processing JSON feeds")
+
+	fetchedSets, fetchErrs := components.NewFromSources(
+		ctx,
+		sources,
+		cfg.Concurrency,
+		cfg.ReadLimit,
+		cfg.AllowUnknownJSONFields,
+		cfg.UserAgent(),
+		cfg.FetchOptions(),
+	)
+
+	// Annotate results evaluated using the last good copy of a feed which
+	// could not be retrieved.
+	var staleErrs []*components.StaleFeedError
+	defer func() {
+		reports.AnnotateStaleFeedResults(plugin, cfg.FallbackServiceState(), staleErrs)
+	}()
+
+	var numFetchErrs int
+	for i, err := range fetchErrs {
+		var staleErr *components.StaleFeedError
+		switch {
+		case err == nil:
+			continue
 
-		feedSource = cfg.Filename
+		case errors.As(err, &staleErr):
+			log.Warn().
+				Err(err).
+				Str("feed", sources[i].String()).
+				Msg("Using last good copy of JSON feed")
 
-		log.Debug().Msg("Processing JSON file")
+			staleErrs = append(staleErrs, staleErr)
 
-		var err error
-		componentsSet, err = components.NewFromFile(cfg.Filename, cfg.ReadLimit, cfg.AllowUnknownJSONFields)
-		if err != nil {
-			log.Error().Err(err).Msg("Error occurred processing input file")
+			continue
+		}
+
+		numFetchErrs++
+
+		log.Error().
+			Err(err).
+			Str("feed", sources[i].String()).
+			Msg("Error processing JSON feed")
+
+		plugin.AddError(err)
+	}
+
+	if numFetchErrs > 0 {
+		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+		switch {
+		case len(sources) == 1:
+			feedType := "URL"
+			if sources[0].Filename != "" {
+				feedType = "file"
+			}
 
-			plugin.AddError(err)
 			plugin.ServiceOutput = fmt.Sprintf(
-				"%s: Failed to process JSON feed from file",
+				"%s: Failed to process JSON feed from %s %q",
 				nagios.StateUNKNOWNLabel,
+				feedType,
+				sources[0].String(),
 			)
-			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
 
 			var prepErr *components.PrepError
-			if errors.As(err, &prepErr) {
+			if errors.As(fetchErrs[0], &prepErr) {
 				plugin.ServiceOutput += ": " + prepErr.Message
 			}
 
-			return
+		default:
+			plugin.ServiceOutput = fmt.Sprintf(
+				"%s: Failed to process %d of %d JSON feeds",
+				nagios.StateUNKNOWNLabel,
+				numFetchErrs,
+				len(sources),
+			)
 		}
-		log.Debug().Msg("Successfully processed JSON file")
 
-	case cfg.URL != "":
+		return
+	}
+	log.Debug().Msg("Successfully processed JSON feeds")
 
-		feedSource = cfg.URL
+	componentsSets := make(components.Sets, 0, len(feeds))
+	csFilters := make([]components.Filter, 0, len(feeds))
 
-		log.Debug().Msg("Processing JSON feed")
+	for i, feed := range feeds {
 
-		var err error
-		componentsSet, err = components.NewFromURL(
-			ctx,
-			cfg.URL,
-			cfg.ReadLimit,
-			cfg.AllowUnknownJSONFields,
-			cfg.UserAgent(),
-		)
-		if err != nil {
-			log.Error().Err(err).Msg("Error processing JSON feed")
+		feedSource := feed.Source()
+		componentsSet := fetchedSets[i]
+
+		log := log.With().
+			Str("feed", feedSource).
+			Logger()
+
+		if err := componentsSet.Validate(); err != nil {
+
+			log.Error().Msg("Failed to validate JSON feed")
 
 			plugin.AddError(err)
 			plugin.ServiceOutput = fmt.Sprintf(
-				"%s: Failed to process JSON feed from URL",
+				"%s: Error validating JSON feed from %q",
 				nagios.StateUNKNOWNLabel,
+				feedSource,
 			)
 			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
 
-			var prepErr *components.PrepError
-			if errors.As(err, &prepErr) {
-				plugin.ServiceOutput += ": " + prepErr.Message
-			}
-
 			return
+
 		}
-		log.Debug().Msg("Successfully processed JSON feed")
 
-	}
+		if err := componentsSet.Page.ValidateID(cfg.ExpectedPageID); err != nil {
 
-	if err := componentsSet.Validate(); err != nil {
+			log.Error().Err(err).Msg("JSON feed does not belong to specified provider")
 
-		log.Error().Msg("Failed to validate JSON feed")
+			plugin.AddError(err)
+			plugin.ServiceOutput = fmt.Sprintf(
+				"%s: JSON feed from %q does not belong to provider %q",
+				nagios.StateUNKNOWNLabel,
+				feedSource,
+				cfg.ProviderName,
+			)
+			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
 
-		plugin.AddError(err)
-		plugin.ServiceOutput = fmt.Sprintf(
-			"%s: Error validating JSON feed from %q",
-			nagios.StateUNKNOWNLabel,
-			feedSource,
-		)
-		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+			return
 
-		return
+		}
 
-	}
+		componentsSet.StatusMap = statusMap
+		componentsSet.GroupRollup = groupRollup
 
-	csFilter := components.Filter(cfg.ComponentFilter())
+		csFilter := components.Filter(feed.ComponentFilter())
 
-	switch {
-	case cfg.EvalAllComponents:
+		switch {
+		case cfg.EvalAllComponents && !csFilter.HasExclusions():
+
+			log.Debug().Msg("Option to evaluate all components chosen")
+			componentsSet.EvalAllComponents = true
+
+		default:
+
+			// Exclusions are applied as a filter, even if the user opted to
+			// evaluate all components.
+			log.Debug().
+				Bool("eval_all", cfg.EvalAllComponents).
+				Msg("Applying user specified filter")
+			log.Debug().
+				Stringer("filter", csFilter).
+				Msg("Applying user specified components filter to components set")
+
+			if err := componentsSet.Filter(csFilter); err != nil {
+				log.Error().
+					Err(err).
+					Msg("Error applying search terms as filter to components set")
+
+				plugin.AddError(err)
+				plugin.ServiceOutput = fmt.Sprintf(
+					"%s: Error filtering components set from %q using specified search terms",
+					nagios.StateUNKNOWNLabel,
+					feedSource,
+				)
+
+				plugin.LongServiceOutput = filterErrAdvice(
+					err,
+					componentsSet,
+					csFilter,
+					feedSource,
+					feed.URLProvided,
+				)
+
+				plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+				return
+			}
 
-		log.Debug().Msg("Option to evaluate all components chosen")
-		componentsSet.EvalAllComponents = true
+		}
 
-	default:
+		componentsSets = append(componentsSets, componentsSet)
+		csFilters = append(csFilters, csFilter)
+	}
 
-		log.Debug().Msg("Option to evaluate all components not chosen")
-		log.Debug().
-			Str("group", csFilter.Group).
-			Str("components", strings.Join(csFilter.Components, ", ")).
-			Msg("Applying user specified components filter to components set")
+	// Severity overrides apply to matching components of any feed.
+	severityOverrides := cfg.SeverityOverrides()
+	if len(severityOverrides) > 0 {
+		csOverrides := make([]components.SeverityOverride, 0, len(severityOverrides))
+		for _, override := range severityOverrides {
+			csOverrides = append(csOverrides, components.SeverityOverride(override))
+		}
 
-		if err := componentsSet.Filter(csFilter); err != nil {
+		if err := componentsSets.ApplySeverityOverrides(csOverrides, cfg.MatchMode); err != nil {
 			log.Error().
 				Err(err).
-				Msg("Error applying search terms as filter to components set")
+				Msg("Error applying severity overrides to components sets")
 
 			plugin.AddError(err)
 			plugin.ServiceOutput = fmt.Sprintf(
-				"%s: Error filtering components set using specified search terms",
+				"%s: Error applying specified component severity overrides",
 				nagios.StateUNKNOWNLabel,
 			)
-
-			plugin.LongServiceOutput = filterErrAdvice(
-				err,
-				componentsSet,
-				csFilter,
-				feedSource,
-			)
-
 			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
 
 			return
 		}
-
 	}
 
+	// Components with a non-operational status which has not yet persisted
+	// for the user-specified duration are not evaluated as problems.
+	componentsSets.ApplyMinProblemDuration(cfg.MinProblemDuration, time.Now())
+
 	// Global stats
-	numTotalComponents := componentsSet.NumComponents()
-	numTotalComponentGroups := componentsSet.NumGroups()
-	numComponentsCritical := componentsSet.NumCriticalState(true)
-	numComponentsWarning := componentsSet.NumWarningState(true)
-	numComponentsUnknown := componentsSet.NumUnknownState(true)
-	numComponentsOK := componentsSet.NumOKState(true)
+	numTotalComponents := componentsSets.NumComponents()
+	numTotalComponentGroups := componentsSets.NumGroups()
+	numComponentsCritical := componentsSets.NumCriticalState(true)
+	numComponentsWarning := componentsSets.NumWarningState(true)
+	numComponentsUnknown := componentsSets.NumUnknownState(true)
+	numComponentsOK := componentsSets.NumOKState(true)
 
 	// Stats specific to the components being monitored
-	numComponentsRemainingCritical := componentsSet.NumCriticalState(false)
-	numComponentsRemainingWarning := componentsSet.NumWarningState(false)
-	numComponentsRemainingUnknown := componentsSet.NumUnknownState(false)
-	numComponentsRemainingOK := componentsSet.NumOKState(false)
-
-	numProblemComponents := componentsSet.NumProblemComponents(true)
-	numExcludedComponents := componentsSet.NumExcluded()
-	numRemainingProblemComponents := componentsSet.NumProblemComponents(false)
+	numComponentsRemainingCritical := componentsSets.NumCriticalState(false)
+	numComponentsRemainingWarning := componentsSets.NumWarningState(false)
+	numComponentsRemainingUnknown := componentsSets.NumUnknownState(false)
+	numComponentsRemainingOK := componentsSets.NumOKState(false)
+
+	numProblemComponents := componentsSets.NumProblemComponents(true)
+	numExcludedComponents := componentsSets.NumExcluded()
+	numRemainingProblemComponents := componentsSets.NumProblemComponents(false)
 	numExcludedProblemComponents := numProblemComponents - numRemainingProblemComponents
+	pctRemainingProblemComponents := componentsSets.PercentProblemComponents()
+
+	// Age of the least recently updated page
+	feedAge := componentsSets.OldestPageAge(time.Now())
 
 	pd := []nagios.PerformanceData{
 		// The `time` (runtime) metric is appended at plugin exit, so do not
@@ -269,6 +395,15 @@ func main() {
 		{
 			Label: "remaining_problem_components",
 			Value: fmt.Sprintf("%d", numRemainingProblemComponents),
+			Warn:  perfDataThreshold(warningThreshold, false),
+			Crit:  perfDataThreshold(criticalThreshold, false),
+		},
+		{
+			Label:             "remaining_problem_components_percent",
+			Value:             fmt.Sprintf("%.2f", pctRemainingProblemComponents),
+			UnitOfMeasurement: "%",
+			Warn:              perfDataThreshold(warningThreshold, true),
+			Crit:              perfDataThreshold(criticalThreshold, true),
 		},
 		{
 			Label: "all_components_critical",
@@ -302,6 +437,13 @@ func main() {
 			Label: "remaining_components_ok",
 			Value: fmt.Sprintf("%d", numComponentsRemainingOK),
 		},
+		{
+			Label:             "feed_age",
+			Value:             fmt.Sprintf("%d", int64(feedAge.Seconds())),
+			UnitOfMeasurement: "s",
+			Warn:              durationThreshold(cfg.StaleWarning),
+			Crit:              durationThreshold(cfg.StaleCritical),
+		},
 	}
 
 	// Update logger with new performance data related fields
@@ -310,6 +452,8 @@ func main() {
 		Int("excluded_components", numExcludedComponents).
 		Int("excluded_problem_components", numExcludedProblemComponents).
 		Int("remaining_problem_components", numRemainingProblemComponents).
+		Float64("remaining_problem_components_percent", pctRemainingProblemComponents).
+		Dur("feed_age", feedAge).
 		Logger()
 
 	if err := plugin.AddPerfData(false, pd...); err != nil {
@@ -330,7 +474,58 @@ func main() {
 	}
 
 	switch {
-	case !componentsSet.IsOKState(false):
+	case cfg.HasProblemThresholds():
+
+		thresholdsState, err := componentsSets.ProblemThresholdsServiceState(
+			warningThreshold,
+			criticalThreshold,
+		)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Msg("Error evaluating specified thresholds")
+
+			plugin.AddError(err)
+			plugin.ServiceOutput = fmt.Sprintf(
+				"%s: Error evaluating specified thresholds",
+				nagios.StateUNKNOWNLabel,
+			)
+			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+			return
+		}
+
+		if thresholdsState.ExitCode != nagios.StateOKExitCode {
+			log.Error().
+				Str("state", thresholdsState.Label).
+				Msg("Non-excluded, non-operational components exceed specified thresholds")
+
+			plugin.AddError(components.ErrProblemComponentsThresholdExceeded)
+		}
+
+		plugin.ExitStatusCode = thresholdsState.ExitCode
+
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s (%.2f%%; %s)",
+			strings.TrimSpace(reports.ComponentsSetsOneLineCheckSummary(
+				thresholdsState.Label,
+				componentsSets,
+				false,
+			)),
+			pctRemainingProblemComponents,
+			problemThresholdsSummary(warningThreshold, criticalThreshold),
+		)
+
+		plugin.LongServiceOutput = reports.ComponentsSetsReport(
+			thresholdsState.Label,
+			csFilters,
+			componentsSets,
+			cfg.OmitOKComponents,
+			cfg.OmitSummaryResults,
+			cfg.ShowVerbose,
+		)
+
+	case !componentsSets.IsOKState(false):
 
 		log.Error().
 			Msg("Non-excluded, non-operational status of statuspage components detected")
@@ -339,39 +534,37 @@ func main() {
 		// status found in the (filtered) collection.
 		var stateLabel string
 		switch {
-		case componentsSet.HasCriticalState(false):
+		case componentsSets.HasCriticalState(false):
 			stateLabel = nagios.StateCRITICALLabel
 			plugin.ExitStatusCode = nagios.StateCRITICALExitCode
 			plugin.AddError(components.ErrComponentWithProblemStatusNotExcluded)
 
-		case componentsSet.HasWarningState(false):
+		case componentsSets.HasWarningState(false):
 			stateLabel = nagios.StateWARNINGLabel
 			plugin.ExitStatusCode = nagios.StateWARNINGExitCode
 			plugin.AddError(components.ErrComponentWithProblemStatusNotExcluded)
 
-		case componentsSet.HasUnknownState(false):
+		case componentsSets.HasUnknownState(false):
 			stateLabel = nagios.StateUNKNOWNLabel
 			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
 			plugin.AddError(components.ErrComponentWithProblemStatusNotExcluded)
 		}
 
-		plugin.ServiceOutput = reports.ComponentsOneLineCheckSummary(
+		plugin.ServiceOutput = reports.ComponentsSetsOneLineCheckSummary(
 			stateLabel,
-			componentsSet,
+			componentsSets,
 			false,
 		)
 
-		plugin.LongServiceOutput = reports.ComponentsReport(
+		plugin.LongServiceOutput = reports.ComponentsSetsReport(
 			stateLabel,
-			csFilter,
-			componentsSet,
+			csFilters,
+			componentsSets,
 			cfg.OmitOKComponents,
 			cfg.OmitSummaryResults,
 			cfg.ShowVerbose,
 		)
 
-		return
-
 	default:
 
 		// success path
@@ -380,23 +573,137 @@ func main() {
 
 		plugin.ExitStatusCode = nagios.StateOKExitCode
 
-		plugin.ServiceOutput = reports.ComponentsOneLineCheckSummary(
+		plugin.ServiceOutput = reports.ComponentsSetsOneLineCheckSummary(
 			nagios.StateOKLabel,
-			componentsSet,
+			componentsSets,
 			false,
 		)
 
-		plugin.LongServiceOutput = reports.ComponentsReport(
+		plugin.LongServiceOutput = reports.ComponentsSetsReport(
 			nagios.StateOKLabel,
-			csFilter,
-			componentsSet,
+			csFilters,
+			componentsSets,
 			cfg.OmitOKComponents,
 			cfg.OmitSummaryResults,
 			cfg.ShowVerbose,
 		)
 
+	}
+
+	// A status page which has stopped updating is itself a problem; raise
+	// the plugin state if the least recently updated page exceeds the
+	// user-specified limits.
+	freshnessState := components.FreshnessServiceState(
+		feedAge,
+		cfg.StaleWarning,
+		cfg.StaleCritical,
+	)
+
+	if freshnessState.ExitCode != nagios.StateOKExitCode {
+		log.Error().
+			Str("state", freshnessState.Label).
+			Msg("Status page not updated within expected duration")
+
+		plugin.AddError(components.ErrPageNotUpdated)
+
+		raiseServiceState(plugin, freshnessState)
+
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s (page not updated for %s)",
+			strings.TrimSpace(plugin.ServiceOutput),
+			feedAge.Round(time.Second),
+		)
+	}
+
+}
+
+// raiseServiceState sets the plugin state to the given service state if it
+// is more severe than the current plugin state, replacing the state label
+// prefix of the one-line summary to match. States are ranked OK, WARNING and
+// then CRITICAL; an UNKNOWN plugin state is never replaced.
+func raiseServiceState(plugin *nagios.Plugin, serviceState nagios.ServiceState) {
+	rank := func(exitCode int) int {
+		switch exitCode {
+		case nagios.StateCRITICALExitCode:
+			return 2
+		case nagios.StateWARNINGExitCode:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	if plugin.ExitStatusCode == nagios.StateUNKNOWNExitCode ||
+		rank(serviceState.ExitCode) <= rank(plugin.ExitStatusCode) {
 		return
+	}
+
+	currentLabel := nagios.ExitCodeToStateLabel(plugin.ExitStatusCode)
+	plugin.ServiceOutput = serviceState.Label +
+		strings.TrimPrefix(plugin.ServiceOutput, currentLabel)
+	plugin.ExitStatusCode = serviceState.ExitCode
+}
+
+// componentStatusesThreshold returns the component statuses which map to the
+// given service state as threshold text. ThresholdNotUsed is returned if no
+// component statuses map to the service state.
+func componentStatusesThreshold(statusMap components.StatusMap, serviceState nagios.ServiceState) string {
+	statuses := statusMap.ComponentStatuses(serviceState)
+	if len(statuses) == 0 {
+		return config.ThresholdNotUsed
+	}
+
+	return strings.Join(statuses, ", ")
+}
+
+// durationThreshold converts the given duration to a performance data
+// threshold value in seconds. An empty value (no threshold) is returned for a
+// zero duration.
+func durationThreshold(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%d", int64(d.Seconds()))
+}
+
+// problemThresholdText returns the given threshold for evaluated components
+// with a non-operational status as threshold text. ThresholdNotUsed is
+// returned if the threshold was not specified.
+func problemThresholdText(threshold components.ProblemThreshold) string {
+	if !threshold.IsSet() {
+		return config.ThresholdNotUsed
+	}
+
+	return threshold.String() + " (evaluated components with a non-operational status)"
+}
+
+// problemThresholdsSummary returns the specified warning and critical
+// thresholds for evaluated components with a non-operational status as a
+// short summary for use in the one-line plugin output.
+func problemThresholdsSummary(warning components.ProblemThreshold, critical components.ProblemThreshold) string {
+	thresholds := make([]string, 0, 2)
+
+	if warning.IsSet() {
+		thresholds = append(thresholds, "warning threshold "+warning.String())
+	}
+
+	if critical.IsSet() {
+		thresholds = append(thresholds, "critical threshold "+critical.String())
+	}
+
+	return strings.Join(thresholds, ", ")
+}
 
+// perfDataThreshold returns the range of the given threshold for evaluated
+// components with a non-operational status as a performance data threshold
+// value if the threshold applies to the percentage (if percent is true) or
+// number of those components. An empty value (no threshold) is returned
+// otherwise.
+func perfDataThreshold(threshold components.ProblemThreshold, percent bool) string {
+	if threshold.Percent != percent {
+		return ""
 	}
 
+	return threshold.Range
 }
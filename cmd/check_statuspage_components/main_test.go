@@ -14,6 +14,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/atc0005/check-statuspage/internal/config"
 	"github.com/atc0005/check-statuspage/internal/statuspage/components"
@@ -42,6 +43,16 @@ const (
 	defaultReadLimitFlag        = "--" + config.ReadLimitFlagLong
 	defaultReadLimitFlagValue   = "1048576" // 1 MB
 	defaultAllowUnknownJSONFlag = "--" + config.AllowUnknownJSONFieldsFlagLong
+	defaultMatchModeFlag        = "--" + config.MatchModeFlagLong
+	defaultExcludeGroupFlag     = "--" + config.ExcludeGroupsFlagLong
+	defaultExcludeComponentFlag = "--" + config.ExcludeComponentsFlagLong
+	defaultStatusMapFlag        = "--" + config.StatusMapFlagLong
+	defaultSeverityFlag         = "--" + config.ComponentSeverityFlagLong
+	defaultWarningFlag          = "--" + config.WarningThresholdFlagLong
+	defaultCriticalFlag         = "--" + config.CriticalThresholdFlagLong
+	defaultGroupRollupFlag      = "--" + config.GroupRollupFlagLong
+	defaultGroupQuorumFlag      = "--" + config.GroupQuorumFlagLong
+	defaultMinProblemDurFlag    = "--" + config.MinProblemDurationFlagLong
 )
 
 const (
@@ -59,6 +70,13 @@ type evalComponentsTestdataFile struct {
 	componentFlag                 string
 	componentFlagValue            string
 	evalAllComponentsFlagValue    string
+	matchModeFlag                 string
+	matchModeFlagValue            string
+	excludeGroupFlag              string
+	excludeGroupFlagValue         string
+	excludeComponentFlag          string
+	excludeComponentFlagValue     string
+	additionalFilterFlags         []string
 	expectedExcludedComponentIDs  []string
 	filterErrorExpected           bool
 	filterResultsMismatchExpected bool
@@ -74,6 +92,14 @@ type evalPluginStatusTestdataFile struct {
 	componentFlag                string
 	componentFlagValue           string
 	evalAllComponentsFlagValue   string
+	statusMapFlag                string
+	statusMapFlagValue           string
+	severityFlagValues           []string
+	warningFlagValue             string
+	criticalFlagValue            string
+	groupRollupFlagValue         string
+	groupQuorumFlagValue         string
+	minProblemDurFlagValue       string
 	expectedPluginStatus         nagios.ServiceState
 	pluginStatusMismatchExpected bool
 }
@@ -748,6 +774,40 @@ func TestLoadAndDecodeAndValidateTestdataFiles(t *testing.T) {
 			errorLoadingFileExpected:  true,
 			errorDecodingFileExpected: true, // the file does not exist, so decoding is not possible
 		},
+		{
+			name:                      "instructure-summary.json, unknown JSON fields not allowed",
+			filenameFlag:              defaultFilenameFlag,
+			filenameFlagValue:         "testdata/summary/instructure-summary.json",
+			logLevelFlag:              defaultLogLevelFlag,
+			logLevelFlagValue:         defaultLogLevelFlagValue,
+			componentFlag:             defaultComponentFlag,
+			componentFlagValue:        defaultComponentFlagValue,
+			readLimitFlag:             defaultReadLimitFlag,
+			readLimitFlagValue:        defaultReadLimitFlagValue,
+			timeoutFlag:               defaultTimeoutFlag,
+			timeoutFlagValue:          defaultTimeoutFlagValue,
+			allowUnknownJSONFlag:      defaultAllowUnknownJSONFlag,
+			allowUnknownJSONFlagValue: "false",
+			errorLoadingFileExpected:  true,
+			errorDecodingFileExpected: true, // the summary fields are not known to the components decoder
+		},
+		{
+			name:                      "instructure-summary.json, unknown JSON fields allowed",
+			filenameFlag:              defaultFilenameFlag,
+			filenameFlagValue:         "testdata/summary/instructure-summary.json",
+			logLevelFlag:              defaultLogLevelFlag,
+			logLevelFlagValue:         defaultLogLevelFlagValue,
+			componentFlag:             defaultComponentFlag,
+			componentFlagValue:        defaultComponentFlagValue,
+			readLimitFlag:             defaultReadLimitFlag,
+			readLimitFlagValue:        defaultReadLimitFlagValue,
+			timeoutFlag:               defaultTimeoutFlag,
+			timeoutFlagValue:          defaultTimeoutFlagValue,
+			allowUnknownJSONFlag:      defaultAllowUnknownJSONFlag,
+			allowUnknownJSONFlagValue: "true",
+			errorLoadingFileExpected:  false,
+			errorDecodingFileExpected: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -899,9 +959,13 @@ func TestEvalComponentsFromTestdataFiles(t *testing.T) {
 				defaultTimeoutFlag, defaultTimeoutFlagValue,
 				test.componentFlag, test.componentFlagValue,
 				test.groupFlag, test.groupFlagValue,
+				test.matchModeFlag, test.matchModeFlagValue,
+				test.excludeGroupFlag, test.excludeGroupFlagValue,
+				test.excludeComponentFlag, test.excludeComponentFlagValue,
 				defaultAllowUnknownJSONFlag + "=" + "false",
 				defaultEvalAllFlag + "=" + test.evalAllComponentsFlagValue,
 			}
+			flagsAndValuesInOrder = append(flagsAndValuesInOrder, test.additionalFilterFlags...)
 
 			for i, item := range flagsAndValuesInOrder {
 				if strings.TrimSpace(item) != "" {
@@ -932,16 +996,17 @@ func TestEvalComponentsFromTestdataFiles(t *testing.T) {
 				t.Fatalf("Failed to validate components set: %v", err)
 			}
 
+			csFilter := components.Filter(cfg.ComponentFilter())
+
 			switch {
-			case cfg.EvalAllComponents:
+			case cfg.EvalAllComponents && !csFilter.HasExclusions():
 				t.Log("Option to evaluate all components chosen")
 				componentsSet.EvalAllComponents = true
 
 			default:
-				t.Log("Option to evaluate all components not chosen")
+				t.Log("Option to evaluate all components not chosen or exclusions specified")
 
 				// Apply filter (success depdendent on specific test case)
-				csFilter := components.Filter(cfg.ComponentFilter())
 				err := componentsSet.Filter(csFilter)
 				switch shouldIgnoreError(t, err, test.filterErrorExpected) {
 				case true:
@@ -1161,10 +1226,35 @@ func TestPluginStatusFromTestdataFiles(t *testing.T) {
 				defaultTimeoutFlag, defaultTimeoutFlagValue,
 				test.componentFlag, test.componentFlagValue,
 				test.groupFlag, test.groupFlagValue,
+				test.statusMapFlag, test.statusMapFlagValue,
 				defaultAllowUnknownJSONFlag + "=" + "false",
 				defaultEvalAllFlag + "=" + test.evalAllComponentsFlagValue,
 			}
 
+			for _, value := range test.severityFlagValues {
+				flagsAndValuesInOrder = append(flagsAndValuesInOrder, defaultSeverityFlag, value)
+			}
+
+			if test.warningFlagValue != "" {
+				flagsAndValuesInOrder = append(flagsAndValuesInOrder, defaultWarningFlag, test.warningFlagValue)
+			}
+
+			if test.criticalFlagValue != "" {
+				flagsAndValuesInOrder = append(flagsAndValuesInOrder, defaultCriticalFlag, test.criticalFlagValue)
+			}
+
+			if test.groupRollupFlagValue != "" {
+				flagsAndValuesInOrder = append(flagsAndValuesInOrder, defaultGroupRollupFlag, test.groupRollupFlagValue)
+			}
+
+			if test.groupQuorumFlagValue != "" {
+				flagsAndValuesInOrder = append(flagsAndValuesInOrder, defaultGroupQuorumFlag, test.groupQuorumFlagValue)
+			}
+
+			if test.minProblemDurFlagValue != "" {
+				flagsAndValuesInOrder = append(flagsAndValuesInOrder, defaultMinProblemDurFlag, test.minProblemDurFlagValue)
+			}
+
 			for i, item := range flagsAndValuesInOrder {
 				if strings.TrimSpace(item) != "" {
 					os.Args = append(os.Args, item)
@@ -1187,6 +1277,8 @@ func TestPluginStatusFromTestdataFiles(t *testing.T) {
 			if err != nil {
 				t.Fatalf("Failed to initialize components set: %v", err)
 			}
+			componentsSet.StatusMap = cfg.StatusMap()
+			componentsSet.GroupRollup = components.GroupRollup(cfg.GroupRollup())
 
 			// Expected to succeed (no potential failure allowance)
 			err = componentsSet.Validate()
@@ -1210,9 +1302,35 @@ func TestPluginStatusFromTestdataFiles(t *testing.T) {
 				}
 			}
 
+			// Expected to succeed (no potential failure allowance)
+			severityOverrides := make([]components.SeverityOverride, 0, len(cfg.SeverityOverrides()))
+			for _, override := range cfg.SeverityOverrides() {
+				severityOverrides = append(severityOverrides, components.SeverityOverride(override))
+			}
+
+			err = components.Sets{componentsSet}.ApplySeverityOverrides(severityOverrides, cfg.MatchMode)
+			if err != nil {
+				t.Fatalf("Failed to apply severity overrides to components set: %v", err)
+			}
+
+			// Measure the duration of component statuses from the time the
+			// page was last updated so that results do not depend on the age
+			// of the testdata file.
+			componentsSet.ApplyMinProblemDuration(cfg.MinProblemDuration, componentsSet.Page.UpdatedAt)
+
 			// Test plugin status. Don't evaluate excluded components per
-			// earlier filtering.
+			// earlier filtering. If specified, thresholds determine the
+			// plugin status in place of the most severe component state.
 			serviceState := componentsSet.ServiceState(false)
+			if cfg.HasProblemThresholds() {
+				serviceState, err = components.Sets{componentsSet}.ProblemThresholdsServiceState(
+					components.ProblemThreshold(cfg.WarningThreshold()),
+					components.ProblemThreshold(cfg.CriticalThreshold()),
+				)
+				if err != nil {
+					t.Fatalf("Failed to evaluate thresholds: %v", err)
+				}
+			}
 			// t.Logf("serviceState: %#v", serviceState)
 			// t.Logf("test.expectedPluginStatus: %#v", test.expectedPluginStatus)
 			serviceStateEqual := cmp.Equal(serviceState, test.expectedPluginStatus)
@@ -1272,6 +1390,465 @@ func TestPluginStatusFromTestdataFiles(t *testing.T) {
 	}
 }
 
+// TestPluginStatusFromMultipleTestdataFiles asserts that the plugin status
+// for multiple feeds (each with their own filter) reflects the most severe
+// state across all component Sets.
+func TestPluginStatusFromMultipleTestdataFiles(t *testing.T) {
+
+	tests := []struct {
+		name                 string
+		flagsAndValues       []string
+		expectedPluginStatus nagios.ServiceState
+	}{
+		{
+			name: "Box and GitHub, eval all, all OK",
+			flagsAndValues: []string{
+				defaultEvalAllFlag + "=true",
+				defaultFilenameFlag, "testdata/components/box-components.json",
+				defaultFilenameFlag, "testdata/components/github-components.json",
+			},
+			expectedPluginStatus: nagios.ServiceState{
+				Label:    nagios.StateOKLabel,
+				ExitCode: nagios.StateOKExitCode,
+			},
+		},
+		{
+			name: "Box and GitHub with problem, eval all, WARNING",
+			flagsAndValues: []string{
+				defaultEvalAllFlag + "=true",
+				defaultFilenameFlag, "testdata/components/box-components.json",
+				defaultFilenameFlag, "testdata/components/github-components-with-problem.json",
+			},
+			expectedPluginStatus: nagios.ServiceState{
+				Label:    nagios.StateWARNINGLabel,
+				ExitCode: nagios.StateWARNINGExitCode,
+			},
+		},
+		{
+			name: "GitHub with problem and Qualys, eval all, CRITICAL",
+			flagsAndValues: []string{
+				defaultEvalAllFlag + "=true",
+				defaultFilenameFlag, "testdata/components/github-components-with-problem.json",
+				defaultFilenameFlag, "testdata/components/qualys-components.json",
+			},
+			expectedPluginStatus: nagios.ServiceState{
+				Label:    nagios.StateCRITICALLabel,
+				ExitCode: nagios.StateCRITICALExitCode,
+			},
+		},
+		{
+			name: "Box by group and component, GitHub with problem by component, WARNING",
+			flagsAndValues: []string{
+				defaultFilenameFlag, "testdata/components/box-components.json",
+				defaultGroupFlag, "vggytbdllrjt",
+				defaultComponentFlag, "k9qs0mx2d5cw",
+				defaultFilenameFlag, "testdata/components/github-components-with-problem.json",
+				defaultComponentFlag, "GitHub Actions",
+			},
+			expectedPluginStatus: nagios.ServiceState{
+				Label:    nagios.StateWARNINGLabel,
+				ExitCode: nagios.StateWARNINGExitCode,
+			},
+		},
+		{
+			name: "Box by group and component, GitHub with problem by other component, OK",
+			flagsAndValues: []string{
+				defaultFilenameFlag, "testdata/components/box-components.json",
+				defaultGroupFlag, "vggytbdllrjt",
+				defaultComponentFlag, "k9qs0mx2d5cw",
+				defaultFilenameFlag, "testdata/components/github-components-with-problem.json",
+				defaultComponentFlag, "Git Operations",
+			},
+			expectedPluginStatus: nagios.ServiceState{
+				Label:    nagios.StateOKLabel,
+				ExitCode: nagios.StateOKExitCode,
+			},
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.name, func(t *testing.T) {
+
+			// Save old command-line arguments so that we can restore them later
+			// https://stackoverflow.com/questions/33723300/how-to-test-the-passing-of-arguments-in-golang
+			oldArgs := os.Args
+
+			// Defer restoring original command-line arguments
+			defer func() { os.Args = oldArgs }()
+
+			os.Args = []string{
+				config.PluginComponentsAppName,
+				defaultLogLevelFlag, defaultLogLevelFlagValue,
+				defaultReadLimitFlag, defaultReadLimitFlagValue,
+				defaultTimeoutFlag, defaultTimeoutFlagValue,
+			}
+
+			for i, item := range test.flagsAndValues {
+				// The testdata directory is two levels up
+				if i > 0 && test.flagsAndValues[i-1] == defaultFilenameFlag {
+					item = filepath.Join("../../", item)
+				}
+				os.Args = append(os.Args, item)
+			}
+
+			t.Log("INFO: New os.Args before init config:\n", os.Args)
+
+			// Expected to succeed (no potential failure allowance)
+			cfg, err := config.New(config.AppType{PluginComponents: true})
+			if err != nil {
+				t.Fatalf("Failed to instantiate configuration: %v", err)
+			}
+
+			var componentsSets components.Sets
+			for _, feed := range cfg.Feeds() {
+
+				// Expected to succeed (no potential failure allowance)
+				componentsSet, err := components.NewFromFile(feed.Filename, cfg.ReadLimit, cfg.AllowUnknownJSONFields)
+				if err != nil {
+					t.Fatalf("Failed to initialize components set: %v", err)
+				}
+
+				// Expected to succeed (no potential failure allowance)
+				if err := componentsSet.Validate(); err != nil {
+					t.Fatalf("Failed to validate components set: %v", err)
+				}
+
+				switch {
+				case cfg.EvalAllComponents:
+					componentsSet.EvalAllComponents = true
+
+				default:
+					// Expected to succeed (no potential failure allowance)
+					csFilter := components.Filter(feed.ComponentFilter())
+					if err := componentsSet.Filter(csFilter); err != nil {
+						t.Fatalf("Failed to apply filter to components set: %v", err)
+					}
+				}
+
+				componentsSets = append(componentsSets, componentsSet)
+			}
+
+			serviceState := componentsSets.ServiceState(false)
+			if d := cmp.Diff(test.expectedPluginStatus, serviceState); d != "" {
+				t.Errorf("(-want, +got)\n:%s", d)
+			} else {
+				t.Logf("OK: got expected plugin status %#v", serviceState)
+			}
+		})
+	}
+}
+
+// TestFeedFreshnessState asserts that the plugin state is raised for a
+// status page which has not been updated within the specified durations.
+func TestFeedFreshnessState(t *testing.T) {
+	t.Parallel()
+
+	componentsSet, err := components.NewFromFile(
+		filepath.Join("../../", "testdata/components/github-components.json"),
+		config.MB,
+		false,
+	)
+	if err != nil {
+		t.Fatalf("ERROR: failed to decode testdata file: %v", err)
+	}
+
+	now := componentsSet.Page.UpdatedAt.Add(12 * time.Hour)
+	feedAge := components.Sets{componentsSet}.OldestPageAge(now)
+
+	if feedAge != 12*time.Hour {
+		t.Fatalf("ERROR: want feed age %v, got %v", 12*time.Hour, feedAge)
+	}
+
+	tests := []struct {
+		name          string
+		warning       time.Duration
+		critical      time.Duration
+		expectedState string
+	}{
+		{
+			name:          "Not evaluated",
+			expectedState: nagios.StateOKLabel,
+		},
+		{
+			name:          "Within limits",
+			warning:       24 * time.Hour,
+			critical:      48 * time.Hour,
+			expectedState: nagios.StateOKLabel,
+		},
+		{
+			name:          "Exceeds warning",
+			warning:       6 * time.Hour,
+			critical:      24 * time.Hour,
+			expectedState: nagios.StateWARNINGLabel,
+		},
+		{
+			name:          "Exceeds critical",
+			warning:       1 * time.Hour,
+			critical:      6 * time.Hour,
+			expectedState: nagios.StateCRITICALLabel,
+		},
+		{
+			name:          "Exceeds critical, warning not evaluated",
+			critical:      6 * time.Hour,
+			expectedState: nagios.StateCRITICALLabel,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := components.FreshnessServiceState(feedAge, test.warning, test.critical)
+			if got.Label != test.expectedState {
+				t.Errorf("ERROR: want state %s, got %s", test.expectedState, got.Label)
+			} else {
+				t.Logf("OK: got expected state %s", got.Label)
+			}
+		})
+	}
+}
+
+func TestRaiseServiceState(t *testing.T) {
+	t.Parallel()
+
+	warningState := nagios.ServiceState{
+		Label:    nagios.StateWARNINGLabel,
+		ExitCode: nagios.StateWARNINGExitCode,
+	}
+
+	criticalState := nagios.ServiceState{
+		Label:    nagios.StateCRITICALLabel,
+		ExitCode: nagios.StateCRITICALExitCode,
+	}
+
+	tests := []struct {
+		name           string
+		currentState   int
+		freshnessState nagios.ServiceState
+		expectedState  int
+	}{
+		{
+			name:           "OK raised to stale WARNING",
+			currentState:   nagios.StateOKExitCode,
+			freshnessState: warningState,
+			expectedState:  nagios.StateWARNINGExitCode,
+		},
+		{
+			name:           "WARNING raised to stale CRITICAL",
+			currentState:   nagios.StateWARNINGExitCode,
+			freshnessState: criticalState,
+			expectedState:  nagios.StateCRITICALExitCode,
+		},
+		{
+			name:           "WARNING kept for stale WARNING",
+			currentState:   nagios.StateWARNINGExitCode,
+			freshnessState: warningState,
+			expectedState:  nagios.StateWARNINGExitCode,
+		},
+		{
+			name:           "CRITICAL kept for stale WARNING",
+			currentState:   nagios.StateCRITICALExitCode,
+			freshnessState: warningState,
+			expectedState:  nagios.StateCRITICALExitCode,
+		},
+		{
+			name:           "UNKNOWN kept for stale WARNING",
+			currentState:   nagios.StateUNKNOWNExitCode,
+			freshnessState: warningState,
+			expectedState:  nagios.StateUNKNOWNExitCode,
+		},
+		{
+			name:           "UNKNOWN kept for stale CRITICAL",
+			currentState:   nagios.StateUNKNOWNExitCode,
+			freshnessState: criticalState,
+			expectedState:  nagios.StateUNKNOWNExitCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			currentLabel := nagios.ExitCodeToStateLabel(test.currentState)
+			expectedLabel := nagios.ExitCodeToStateLabel(test.expectedState)
+
+			plugin := nagios.NewPlugin()
+			plugin.ExitStatusCode = test.currentState
+			plugin.ServiceOutput = currentLabel + ": summary"
+
+			raiseServiceState(plugin, test.freshnessState)
+
+			if plugin.ExitStatusCode != test.expectedState {
+				t.Errorf(
+					"ERROR: want state %s, got %s",
+					expectedLabel,
+					nagios.ExitCodeToStateLabel(plugin.ExitStatusCode),
+				)
+			} else {
+				t.Logf("OK: got expected state %s", expectedLabel)
+			}
+
+			wantOutput := expectedLabel + ": summary"
+			if plugin.ServiceOutput != wantOutput {
+				t.Errorf("ERROR: want output %q, got %q", wantOutput, plugin.ServiceOutput)
+			} else {
+				t.Logf("OK: got expected output %q", plugin.ServiceOutput)
+			}
+		})
+	}
+}
+
+// problemComponentIDs is a helper function to collect the ID values of the
+// problem components in the given set.
+func problemComponentIDs(t *testing.T, componentsSet *components.Set) []string {
+	t.Helper()
+
+	problemComponents := componentsSet.ProblemComponents(false)
+
+	ids := make([]string, 0, len(problemComponents))
+	for _, component := range problemComponents {
+		ids = append(ids, component.ID)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// TestProblemComponentsWithStatusMap asserts that component statuses mapped
+// to an OK state are not counted or listed as problem components and that
+// the number of OK and problem components add up to the number of evaluated
+// components.
+func TestProblemComponentsWithStatusMap(t *testing.T) {
+	t.Parallel()
+
+	underMaintenanceOK := components.StatusMap{
+		components.ComponentStatusUnderMaintenance: nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		statusMap            components.StatusMap
+		expectedNumProblem   int
+		expectedProblemIDs   []string
+		expectedServiceState string
+	}{
+		{
+			name:               "Default status mapping",
+			expectedNumProblem: 5,
+			expectedProblemIDs: []string{
+				"18y3lk2rrbxq",
+				"2pz4b2l3ptdz",
+				"6t9q2x7c000d",
+				"7xmg9bzxnp6f",
+				"9fxsjz7b7v0d",
+			},
+			expectedServiceState: nagios.StateCRITICALLabel,
+		},
+		{
+			name:                 "Under maintenance mapped to OK",
+			statusMap:            underMaintenanceOK,
+			expectedNumProblem:   1,
+			expectedProblemIDs:   []string{"2pz4b2l3ptdz"},
+			expectedServiceState: nagios.StateCRITICALLabel,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			componentsSet, err := components.NewFromFile(
+				filepath.Join("../../", "testdata/components/qualys-components.json"),
+				config.MB,
+				false,
+			)
+			if err != nil {
+				t.Fatalf("ERROR: failed to decode testdata file: %v", err)
+			}
+			componentsSet.EvalAllComponents = true
+			componentsSet.StatusMap = test.statusMap
+
+			numProblem := componentsSet.NumProblemComponents(false)
+			if numProblem != test.expectedNumProblem {
+				t.Errorf("ERROR: want %d problem components, got %d", test.expectedNumProblem, numProblem)
+			}
+
+			numOK := componentsSet.NumOKState(false)
+			numEvaluated := componentsSet.NumComponents() - componentsSet.NumGroups()
+			if numOK+numProblem != numEvaluated {
+				t.Errorf(
+					"ERROR: want %d OK and problem components combined, got %d OK and %d problem",
+					numEvaluated,
+					numOK,
+					numProblem,
+				)
+			}
+
+			if d := cmp.Diff(test.expectedProblemIDs, problemComponentIDs(t, componentsSet)); d != "" {
+				t.Errorf("ERROR: problem components mismatch (-want, +got)\n:%s", d)
+			}
+
+			if got := componentsSet.ServiceState(false).Label; got != test.expectedServiceState {
+				t.Errorf("ERROR: want state %s, got %s", test.expectedServiceState, got)
+			}
+		})
+	}
+}
+
+// TestProblemComponentsWithSeverityOverride asserts that a component with a
+// state lowered to OK by a severity override is not counted or listed as a
+// problem component.
+func TestProblemComponentsWithSeverityOverride(t *testing.T) {
+	t.Parallel()
+
+	componentsSet, err := components.NewFromFile(
+		filepath.Join("../../", "testdata/components/qualys-components.json"),
+		config.MB,
+		false,
+	)
+	if err != nil {
+		t.Fatalf("ERROR: failed to decode testdata file: %v", err)
+	}
+	componentsSet.EvalAllComponents = true
+
+	// Lower the only major_outage subcomponent to an OK state.
+	overrides := []components.SeverityOverride{
+		{
+			Target: "2pz4b2l3ptdz",
+			MaxState: nagios.ServiceState{
+				Label:    nagios.StateOKLabel,
+				ExitCode: nagios.StateOKExitCode,
+			},
+		},
+	}
+
+	err = components.Sets{componentsSet}.ApplySeverityOverrides(overrides, components.MatchModeExact)
+	if err != nil {
+		t.Fatalf("ERROR: failed to apply severity overrides: %v", err)
+	}
+
+	if numProblem := componentsSet.NumProblemComponents(false); numProblem != 4 {
+		t.Errorf("ERROR: want %d problem components, got %d", 4, numProblem)
+	}
+
+	want := []string{
+		"18y3lk2rrbxq",
+		"6t9q2x7c000d",
+		"7xmg9bzxnp6f",
+		"9fxsjz7b7v0d",
+	}
+	if d := cmp.Diff(want, problemComponentIDs(t, componentsSet)); d != "" {
+		t.Errorf("ERROR: problem components mismatch (-want, +got)\n:%s", d)
+	}
+
+	overridden := componentsSet.SeverityOverrideComponents(false)
+	if len(overridden) != 1 || overridden[0].ID != "2pz4b2l3ptdz" {
+		t.Errorf("ERROR: want overridden component 2pz4b2l3ptdz, got %v", overridden)
+	}
+
+	if got := componentsSet.ServiceState(false).Label; got != nagios.StateWARNINGLabel {
+		t.Errorf("ERROR: want state %s, got %s", nagios.StateWARNINGLabel, got)
+	}
+}
+
 // TestEmptyClientPerfDataAndConstructedPluginProducesDefaultTimeMetric
 // asserts that omitted performance data from client code produces a default
 // time metric when using the Plugin constructor.
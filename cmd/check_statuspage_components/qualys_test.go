@@ -905,4 +905,376 @@ var qualysEvalPluginStatusTestEntries = []evalPluginStatusTestdataFile{
 		},
 		pluginStatusMismatchExpected: false,
 	},
+	{
+		name:                       "(OK) Qualys, by component name, under_maintenance mapped to OK, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              defaultComponentFlag,
+		componentFlagValue:         "Container Security (CS)",
+		evalAllComponentsFlagValue: "false",
+		statusMapFlag:              defaultStatusMapFlag,
+		statusMapFlagValue:         "under_maintenance=OK",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, by group name, under_maintenance mapped to CRITICAL, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "AE Platform 1",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		statusMapFlag:              defaultStatusMapFlag,
+		statusMapFlagValue:         "under_maintenance=CRITICAL",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, eval all, major_outage mapped to WARNING, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		statusMapFlag:              defaultStatusMapFlag,
+		statusMapFlagValue:         "major_outage=WARNING",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(FAIL) Qualys, eval all, major_outage mapped to WARNING, invalid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		statusMapFlag:              defaultStatusMapFlag,
+		statusMapFlagValue:         "major_outage=WARNING",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		},
+		pluginStatusMismatchExpected: true, // major_outage is mapped to WARNING
+	},
+	{
+		name:                       "(OK) Qualys, by group name, group severity override limits state, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "EU Platform 1",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		severityFlagValues: []string{
+			"EU Platform 1:max=WARNING",
+		},
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, by group name, component severity override takes precedence over group, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "EU Platform 1",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		severityFlagValues: []string{
+			"EU Platform 1:max=WARNING",
+			"2pz4b2l3ptdz:major_outage=UNKNOWN",
+		},
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateUNKNOWNLabel,
+			ExitCode: nagios.StateUNKNOWNExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	}, {
+		name:                       "(OK) Qualys, eval all, problem components below percentage thresholds, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		warningFlagValue:           "5%",
+		criticalFlagValue:          "10%",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, eval all, problem components exceed count warning threshold, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		warningFlagValue:           "3",
+		criticalFlagValue:          "10",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, eval all, problem components exceed percentage critical threshold, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		criticalFlagValue:          "1%",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(FAIL) Qualys, eval all, problem components below count thresholds, invalid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		warningFlagValue:           "10",
+		criticalFlagValue:          "20",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		},
+		pluginStatusMismatchExpected: true, // thresholds take precedence over major_outage status
+	},
+	{
+		name:                       "(OK) Qualys, group with under_maintenance subcomponent mapped to OK, count warning threshold not exceeded, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "US Platform 3",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		statusMapFlag:              defaultStatusMapFlag,
+		statusMapFlagValue:         "under_maintenance=OK",
+		warningFlagValue:           "0",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, group with under_maintenance subcomponent mapped to OK, percentage critical threshold not exceeded, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "US Platform 3",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		statusMapFlag:              defaultStatusMapFlag,
+		statusMapFlagValue:         "under_maintenance=OK",
+		criticalFlagValue:          "0%",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, eval all, under_maintenance mapped to OK, count warning threshold exceeded, percentage critical threshold not exceeded, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		statusMapFlag:              defaultStatusMapFlag,
+		statusMapFlagValue:         "under_maintenance=OK",
+		warningFlagValue:           "0",
+		criticalFlagValue:          "1%",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, eval all, under_maintenance mapped to OK, major_outage component overridden to OK, thresholds not exceeded, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "true",
+		statusMapFlag:              defaultStatusMapFlag,
+		statusMapFlagValue:         "under_maintenance=OK",
+		severityFlagValues:         []string{"2pz4b2l3ptdz:max=OK"},
+		warningFlagValue:           "0",
+		criticalFlagValue:          "0%",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, by group name, parent group rollup, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "EU Platform 1",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		groupRollupFlagValue:       "parent",
+		groupQuorumFlagValue:       "",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, by group name, worst-of group rollup, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "IN Platform 1",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		groupRollupFlagValue:       "worst-of",
+		groupQuorumFlagValue:       "",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, by group name, quorum group rollup not reached, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "EU Platform 1",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		groupRollupFlagValue:       "quorum",
+		groupQuorumFlagValue:       "",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, by group name, quorum group rollup reached, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "IN Platform 1",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		groupRollupFlagValue:       "quorum",
+		groupQuorumFlagValue:       "5",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(FAIL) Qualys, by group name, quorum group rollup not reached, invalid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "EU Platform 1",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		groupRollupFlagValue:       "quorum",
+		groupQuorumFlagValue:       "50",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		},
+		pluginStatusMismatchExpected: true, // 1 of 25 subcomponents is below the quorum
+	},
+	{
+		name:                       "(OK) Qualys, by component name, status within min problem duration, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              defaultComponentFlag,
+		componentFlagValue:         "Container Security (CS)",
+		evalAllComponentsFlagValue: "false",
+		minProblemDurFlagValue:     "2h",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, by component name, status exceeds min problem duration, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  "",
+		groupFlagValue:             "",
+		componentFlag:              defaultComponentFlag,
+		componentFlagValue:         "Container Security (CS)",
+		evalAllComponentsFlagValue: "false",
+		minProblemDurFlagValue:     "30m",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(OK) Qualys, by group name, major outage within min problem duration, valid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "EU Platform 1",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		minProblemDurFlagValue:     "6h",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		},
+		pluginStatusMismatchExpected: false,
+	},
+	{
+		name:                       "(FAIL) Qualys, by group name, major outage within min problem duration, invalid plugin status",
+		filenameFlagValue:          "testdata/components/qualys-components.json",
+		groupFlag:                  defaultGroupFlag,
+		groupFlagValue:             "EU Platform 1",
+		componentFlag:              "",
+		componentFlagValue:         "",
+		evalAllComponentsFlagValue: "false",
+		minProblemDurFlagValue:     "6h",
+		expectedPluginStatus: nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		},
+		pluginStatusMismatchExpected: true, // major_outage status updated less than 6h before page update
+	},
 }
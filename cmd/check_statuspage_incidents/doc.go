@@ -0,0 +1,27 @@
+/*
+Nagios plugin used to monitor unresolved incidents from a status page powered
+by Atlassian Statuspage.
+
+# PURPOSE
+
+Vendors often open an incident before (or without ever) changing the status of
+any affected components. This plugin evaluates the impact of each unresolved
+incident, optionally limited to incidents affecting specific components or a
+component group, and maps the most severe impact to a Nagios state.
+
+The output for this plugin is designed to provide the one-line summary needed
+by Nagios for quick identification of a problem while providing longer, more
+detailed information for use in email and Teams notifications
+(https://github.com/atc0005/send2teams).
+
+# PROJECT HOME
+
+See our GitHub repo (https://github.com/atc0005/check-statuspage) for the
+latest code, to file an issue or submit improvements for review and potential
+inclusion into the project.
+
+# USAGE
+
+See our main README for supported settings and examples.
+*/
+package main
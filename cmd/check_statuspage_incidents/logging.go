@@ -0,0 +1,36 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-vmware
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"github.com/rs/zerolog"
+
+	"github.com/atc0005/check-statuspage/internal/reports"
+	"github.com/atc0005/check-statuspage/internal/statuspage"
+	"github.com/atc0005/check-statuspage/internal/statuspage/components"
+	"github.com/atc0005/check-statuspage/internal/statuspage/summary"
+)
+
+func handleLibraryLogging() {
+	switch {
+	case zerolog.GlobalLevel() == zerolog.DebugLevel ||
+		zerolog.GlobalLevel() == zerolog.TraceLevel:
+
+		statuspage.EnableLogging()
+		components.EnableLogging()
+		summary.EnableLogging()
+		reports.EnableLogging()
+
+	default:
+
+		statuspage.DisableLogging()
+		components.DisableLogging()
+		summary.DisableLogging()
+		reports.DisableLogging()
+	}
+}
@@ -0,0 +1,422 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/atc0005/go-nagios"
+
+	"github.com/atc0005/check-statuspage/internal/config"
+	"github.com/atc0005/check-statuspage/internal/reports"
+	"github.com/atc0005/check-statuspage/internal/statuspage"
+	"github.com/atc0005/check-statuspage/internal/statuspage/components"
+	"github.com/atc0005/check-statuspage/internal/statuspage/summary"
+
+	"github.com/rs/zerolog"
+)
+
+//go:generate go-winres make --product-version=git-tag --file-version=git-tag
+
+func main() {
+
+	plugin := nagios.NewPlugin()
+
+	// defer this from the start so it is the last deferred function to run
+	defer plugin.ReturnCheckResults()
+
+	// Setup configuration by parsing user-provided flags. Note plugin type so
+	// that only applicable CLI flags are exposed and any plugin-specific
+	// settings are applied.
+	cfg, cfgErr := config.New(config.AppType{PluginIncidents: true})
+	switch {
+	case errors.Is(cfgErr, config.ErrVersionRequested):
+		fmt.Println(config.Version())
+
+		return
+
+	case errors.Is(cfgErr, config.ErrHelpRequested):
+		fmt.Println(cfg.Help())
+
+		return
+
+	case cfgErr != nil:
+
+		// We make some assumptions when setting up our logger as we do not
+		// have a working configuration based on sysadmin-specified choices.
+		consoleWriter := zerolog.ConsoleWriter{Out: os.Stderr}
+		logger := zerolog.New(consoleWriter).With().Timestamp().Caller().Logger()
+
+		logger.Err(cfgErr).Msg("Error initializing application")
+
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s: Error initializing application",
+			nagios.StateUNKNOWNLabel,
+		)
+		plugin.AddError(cfgErr)
+		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+		return
+	}
+
+	// Enable library-level logging if debug or greater logging level is
+	// enabled app-wide.
+	handleLibraryLogging()
+
+	// Set context deadline equal to user-specified timeout value for plugin
+	// runtime/execution.
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout())
+	defer cancel()
+
+	// Record thresholds for use as Nagios "Long Service Output" content. This
+	// content is shown in the detailed web UI and in notifications generated
+	// by Nagios.
+	if cfg.ShowVerbose {
+		criticalIncidentImpacts := summary.ServiceStateToIncidentImpacts(
+			nagios.ServiceState{ExitCode: nagios.StateCRITICALExitCode},
+		)
+		plugin.CriticalThreshold = strings.Join(criticalIncidentImpacts, ", ")
+
+		warningIncidentImpacts := summary.ServiceStateToIncidentImpacts(
+			nagios.ServiceState{ExitCode: nagios.StateWARNINGExitCode},
+		)
+
+		plugin.WarningThreshold = strings.Join(warningIncidentImpacts, ", ")
+	}
+
+	if cfg.EmitBranding {
+		// If enabled, show application details at end of notification
+		plugin.BrandingCallback = config.Branding("Notification generated by ")
+	}
+
+	log := cfg.Log.With().
+		Str("filename", cfg.Filename).
+		Str("url", cfg.URL).
+		Str("provider", cfg.Provider).
+		Str("cache_dir", cfg.CacheDir).
+		Int("retries", cfg.Retries).
+		Dur("retry_delay", cfg.RetryDelay).
+		Int64("read_limit", cfg.ReadLimit).
+		Bool("allow_unknown_fields", cfg.AllowUnknownJSONFields).
+		Logger()
+
+	// Annotate results evaluated using the last good copy of a feed which
+	// could not be retrieved.
+	var staleErrs []*statuspage.StaleFeedError
+	defer func() {
+		reports.AnnotateStaleFeedResults(plugin, cfg.FallbackServiceState(), staleErrs)
+	}()
+
+	// Process one of local file or remote URL. Rely on config package
+	// validation to prevent the user from specifying both.
+	var incidentsSummary *summary.Summary
+	var feedSource string
+	switch {
+
+	case cfg.Filename != "":
+
+		feedSource = cfg.Filename
+
+		log.Debug().Msg("Processing JSON file")
+
+		var err error
+		incidentsSummary, err = summary.NewFromFile(cfg.Filename, cfg.ReadLimit, cfg.AllowUnknownJSONFields)
+		if err != nil {
+			log.Error().Err(err).Msg("Error occurred processing input file")
+
+			plugin.AddError(err)
+			plugin.ServiceOutput = fmt.Sprintf(
+				"%s: Failed to process JSON feed from file",
+				nagios.StateUNKNOWNLabel,
+			)
+			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+			var prepErr *statuspage.PrepError
+			if errors.As(err, &prepErr) {
+				plugin.ServiceOutput += ": " + prepErr.Message
+			}
+
+			return
+		}
+		log.Debug().Msg("Successfully processed JSON file")
+
+	case cfg.URL != "":
+
+		feedSource = cfg.URL
+
+		log.Debug().Msg("Processing JSON feed")
+
+		var err error
+		incidentsSummary, err = summary.NewFromURL(
+			ctx,
+			cfg.URL,
+			cfg.ReadLimit,
+			cfg.AllowUnknownJSONFields,
+			cfg.UserAgent(),
+			cfg.FetchOptions(),
+		)
+
+		var staleErr *statuspage.StaleFeedError
+		if errors.As(err, &staleErr) {
+			log.Warn().Err(err).Msg("Using last good copy of JSON feed")
+
+			staleErrs = append(staleErrs, staleErr)
+			err = nil
+		}
+
+		if err != nil {
+			log.Error().Err(err).Msg("Error processing JSON feed")
+
+			plugin.AddError(err)
+			plugin.ServiceOutput = fmt.Sprintf(
+				"%s: Failed to process JSON feed from URL",
+				nagios.StateUNKNOWNLabel,
+			)
+			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+			var prepErr *statuspage.PrepError
+			if errors.As(err, &prepErr) {
+				plugin.ServiceOutput += ": " + prepErr.Message
+			}
+
+			return
+		}
+		log.Debug().Msg("Successfully processed JSON feed")
+
+	}
+
+	if err := incidentsSummary.Validate(); err != nil {
+
+		log.Error().Msg("Failed to validate JSON feed")
+
+		plugin.AddError(err)
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s: Error validating JSON feed from %q",
+			nagios.StateUNKNOWNLabel,
+			feedSource,
+		)
+		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+		return
+
+	}
+
+	if err := incidentsSummary.Page.ValidateID(cfg.ExpectedPageID); err != nil {
+
+		log.Error().Err(err).Msg("JSON feed does not belong to specified provider")
+
+		plugin.AddError(err)
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s: JSON feed from %q does not belong to provider %q",
+			nagios.StateUNKNOWNLabel,
+			feedSource,
+			cfg.ProviderName,
+		)
+		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+		return
+
+	}
+
+	csFilter := components.Filter(cfg.ComponentFilter())
+
+	switch {
+	case len(csFilter.Groups) == 0 && len(csFilter.Components) == 0:
+
+		log.Debug().Msg("No components filter specified, evaluating all unresolved incidents")
+
+	default:
+
+		// Resolve component and component group names using the full
+		// collection of page components if the feed does not provide it.
+		if len(incidentsSummary.Components) == 0 && cfg.URL != "" {
+			if componentsURL := summary.ComponentsFeedURL(cfg.URL); componentsURL != "" {
+
+				log.Debug().
+					Str("components_url", componentsURL).
+					Msg("Retrieving page components to resolve filter values")
+
+				componentsSet, err := components.NewFromURL(
+					ctx,
+					componentsURL,
+					cfg.ReadLimit,
+					cfg.AllowUnknownJSONFields,
+					cfg.UserAgent(),
+					cfg.FetchOptions(),
+				)
+
+				var staleErr *statuspage.StaleFeedError
+				if errors.As(err, &staleErr) {
+					log.Warn().Err(err).Str("components_url", componentsURL).Msg("Using last good copy of page components")
+
+					staleErrs = append(staleErrs, staleErr)
+					err = nil
+				}
+
+				if err != nil {
+					log.Error().Err(err).Str("components_url", componentsURL).Msg("Error retrieving page components")
+
+					plugin.AddError(err)
+					plugin.ServiceOutput = fmt.Sprintf(
+						"%s: Failed to retrieve page components used to resolve specified search terms",
+						nagios.StateUNKNOWNLabel,
+					)
+					plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+					return
+				}
+
+				incidentsSummary.UseComponents(componentsSet)
+			}
+		}
+
+		log.Debug().
+			Stringer("filter", csFilter).
+			Msg("Applying user specified components filter to unresolved incidents")
+
+		if err := incidentsSummary.Filter(csFilter); err != nil {
+			log.Error().
+				Err(err).
+				Msg("Error applying search terms as filter to unresolved incidents")
+
+			plugin.AddError(err)
+			plugin.ServiceOutput = fmt.Sprintf(
+				"%s: Error filtering unresolved incidents using specified search terms",
+				nagios.StateUNKNOWNLabel,
+			)
+
+			plugin.LongServiceOutput = fmt.Sprintf(
+				"Specified filter: %s%s"+
+					"Double-check provided component group and component name or ID values"+
+					" against the components listed for %q.%s",
+				csFilter,
+				nagios.CheckOutputEOL,
+				feedSource,
+				nagios.CheckOutputEOL,
+			)
+
+			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+			return
+		}
+
+	}
+
+	numTotalIncidents := incidentsSummary.NumIncidents()
+	numExcludedIncidents := incidentsSummary.NumExcludedIncidents()
+	numProblemIncidents := incidentsSummary.NumProblemIncidents(true)
+	numRemainingProblemIncidents := incidentsSummary.NumProblemIncidents(false)
+	numExcludedProblemIncidents := numProblemIncidents - numRemainingProblemIncidents
+
+	pd := []nagios.PerformanceData{
+		// The `time` (runtime) metric is appended at plugin exit, so do not
+		// duplicate it here.
+		{
+			Label: "all_incidents",
+			Value: fmt.Sprintf("%d", numTotalIncidents),
+		},
+		{
+			Label: "excluded_incidents",
+			Value: fmt.Sprintf("%d", numExcludedIncidents),
+		},
+		{
+			Label: "all_problem_incidents",
+			Value: fmt.Sprintf("%d", numProblemIncidents),
+		},
+		{
+			Label: "excluded_problem_incidents",
+			Value: fmt.Sprintf("%d", numExcludedProblemIncidents),
+		},
+		{
+			Label: "remaining_problem_incidents",
+			Value: fmt.Sprintf("%d", numRemainingProblemIncidents),
+		},
+		{
+			Label: "remaining_incidents_critical",
+			Value: fmt.Sprintf("%d", incidentsSummary.NumCriticalIncidents(false)),
+		},
+		{
+			Label: "remaining_incidents_warning",
+			Value: fmt.Sprintf("%d", incidentsSummary.NumWarningIncidents(false)),
+		},
+		{
+			Label: "remaining_incidents_unknown",
+			Value: fmt.Sprintf("%d", incidentsSummary.NumUnknownIncidents(false)),
+		},
+		{
+			Label: "remaining_incidents_ok",
+			Value: fmt.Sprintf("%d", incidentsSummary.NumOKIncidents(false)),
+		},
+	}
+
+	// Update logger with new performance data related fields
+	log = log.With().
+		Int("total_incidents", numTotalIncidents).
+		Int("excluded_incidents", numExcludedIncidents).
+		Int("excluded_problem_incidents", numExcludedProblemIncidents).
+		Int("remaining_problem_incidents", numRemainingProblemIncidents).
+		Logger()
+
+	if err := plugin.AddPerfData(false, pd...); err != nil {
+		log.Error().
+			Err(err).
+			Msg("failed to add performance data")
+
+		// Surface the error in plugin output.
+		plugin.AddError(err)
+
+		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s: Failed to process performance data metrics",
+			nagios.StateUNKNOWNLabel,
+		)
+
+		return
+	}
+
+	serviceState := incidentsSummary.IncidentsServiceState(false)
+
+	switch {
+	case serviceState.ExitCode != nagios.StateOKExitCode:
+
+		log.Error().
+			Str("state", serviceState.Label).
+			Msg("Non-excluded unresolved incidents with service impact detected")
+
+		plugin.AddError(summary.ErrIncidentWithProblemImpactNotExcluded)
+
+	default:
+
+		// success path
+
+		log.Debug().Msg("No non-excluded unresolved incidents with service impact")
+
+	}
+
+	plugin.ExitStatusCode = serviceState.ExitCode
+
+	plugin.ServiceOutput = reports.IncidentsOneLineCheckSummary(
+		serviceState.Label,
+		incidentsSummary,
+		false,
+	)
+
+	plugin.LongServiceOutput = reports.IncidentsReport(
+		serviceState.Label,
+		csFilter,
+		incidentsSummary,
+		cfg.OmitOKComponents,
+		cfg.OmitSummaryResults,
+		cfg.ShowVerbose,
+	)
+
+}
@@ -0,0 +1,299 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/atc0005/check-statuspage/internal/config"
+	"github.com/atc0005/check-statuspage/internal/statuspage/components"
+	"github.com/atc0005/check-statuspage/internal/statuspage/summary"
+	"github.com/atc0005/go-nagios"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Shared flags and values across various tests
+const (
+	defaultFilenameFlag       = "--" + config.FilenameFlagLong
+	defaultLogLevelFlag       = "--" + config.LogLevelFlagLong
+	defaultLogLevelFlagValue  = config.LogLevelInfo
+	defaultComponentFlag      = "--" + config.ComponentsListFlagLong
+	defaultGroupFlag          = "--" + config.ComponentGroupFlagLong
+	defaultTimeoutFlag        = "--" + config.TimeoutFlagLong
+	defaultTimeoutFlagValue   = "10"
+	defaultReadLimitFlag      = "--" + config.ReadLimitFlagLong
+	defaultReadLimitFlagValue = "1048576" // 1 MB
+
+	instructureSummaryFile     = "testdata/summary/instructure-summary.json"
+	instructureIncidentsFile   = "testdata/incidents/instructure-incidents-unresolved.json"
+	instructureNoIncidentsFile = "testdata/incidents/instructure-incidents-unresolved-none.json"
+	instructureCanvasGroupID   = "41wg86q5vc14"
+	instructureCanvasID        = "9dlvqx1drp3d"
+	instructureCatalogID       = "jw0fn0dnpcgn"
+	instructureCatalogName     = "— Catalog"
+	instructureCanvasName      = "Canvas"
+	instructureNonexistentKey  = "This component does not exist"
+	instructureComponentsFile  = "testdata/components/instructure-components.json"
+	instructureCanvasTypo      = "Canvs"
+)
+
+// TestPluginStatusFromTestdataFiles loads, decodes, validates and optionally
+// filters each listed JSON testdata file and then asserts that the resulting
+// plugin status matches the expected value.
+func TestPluginStatusFromTestdataFiles(t *testing.T) {
+
+	warningState := nagios.ServiceState{
+		Label:    nagios.StateWARNINGLabel,
+		ExitCode: nagios.StateWARNINGExitCode,
+	}
+
+	okState := nagios.ServiceState{
+		Label:    nagios.StateOKLabel,
+		ExitCode: nagios.StateOKExitCode,
+	}
+
+	tests := []struct {
+		name                 string
+		filenameFlagValue    string
+		groupFlagValue       string
+		componentFlagValue   string
+		componentsFile       string
+		expectedPluginStatus nagios.ServiceState
+		filterErrorExpected  bool
+	}{
+		{
+			name:                 "Instructure summary, no filter",
+			filenameFlagValue:    instructureSummaryFile,
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Instructure unresolved incidents, no filter",
+			filenameFlagValue:    instructureIncidentsFile,
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Instructure summary, affected component by name",
+			filenameFlagValue:    instructureSummaryFile,
+			componentFlagValue:   instructureCanvasName,
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Instructure summary, affected component group by name",
+			filenameFlagValue:    instructureSummaryFile,
+			groupFlagValue:       instructureCanvasName,
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Instructure summary, unaffected subcomponent of affected group",
+			filenameFlagValue:    instructureSummaryFile,
+			groupFlagValue:       instructureCanvasGroupID,
+			componentFlagValue:   instructureCatalogName,
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                "Instructure summary, nonexistent component",
+			filenameFlagValue:   instructureSummaryFile,
+			componentFlagValue:  instructureNonexistentKey,
+			filterErrorExpected: true,
+		},
+		{
+			name:                 "Instructure unresolved incidents, affected component by ID",
+			filenameFlagValue:    instructureIncidentsFile,
+			componentFlagValue:   instructureCanvasID,
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Instructure unresolved incidents, affected component group by ID",
+			filenameFlagValue:    instructureIncidentsFile,
+			groupFlagValue:       instructureCanvasGroupID,
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Instructure unresolved incidents, unaffected component by ID",
+			filenameFlagValue:    instructureIncidentsFile,
+			componentFlagValue:   instructureCatalogID,
+			componentsFile:       instructureComponentsFile,
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                 "Instructure unresolved incidents, unaffected component by ID without page components",
+			filenameFlagValue:    instructureIncidentsFile,
+			componentFlagValue:   instructureCatalogID,
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                 "Instructure unresolved incidents, unaffected component by name without page components",
+			filenameFlagValue:    instructureIncidentsFile,
+			componentFlagValue:   instructureCatalogName,
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                 "Instructure unresolved incidents, affected and unaffected components without page components",
+			filenameFlagValue:    instructureIncidentsFile,
+			componentFlagValue:   instructureCanvasID + "," + instructureCatalogID,
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Instructure unresolved incidents, affected component group by name",
+			filenameFlagValue:    instructureIncidentsFile,
+			groupFlagValue:       instructureCanvasName,
+			componentsFile:       instructureComponentsFile,
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Instructure unresolved incidents, component group by name without page components",
+			filenameFlagValue:    instructureIncidentsFile,
+			groupFlagValue:       instructureCanvasName,
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                 "Instructure unresolved incidents, nonexistent component without page components",
+			filenameFlagValue:    instructureIncidentsFile,
+			componentFlagValue:   instructureNonexistentKey,
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                "Instructure unresolved incidents, nonexistent component",
+			filenameFlagValue:   instructureIncidentsFile,
+			componentFlagValue:  instructureNonexistentKey,
+			componentsFile:      instructureComponentsFile,
+			filterErrorExpected: true,
+		},
+		{
+			name:                 "Instructure no unresolved incidents, component without page components",
+			filenameFlagValue:    instructureNoIncidentsFile,
+			componentFlagValue:   instructureCatalogName,
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                 "Instructure no unresolved incidents, component group without page components",
+			filenameFlagValue:    instructureNoIncidentsFile,
+			groupFlagValue:       instructureCanvasGroupID,
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                "Instructure unresolved incidents, misspelled component group",
+			filenameFlagValue:   instructureIncidentsFile,
+			groupFlagValue:      instructureCanvasTypo,
+			componentsFile:      instructureComponentsFile,
+			filterErrorExpected: true,
+		},
+		{
+			name:                "Instructure summary, misspelled component group",
+			filenameFlagValue:   instructureSummaryFile,
+			groupFlagValue:      instructureCanvasTypo,
+			filterErrorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.name, func(t *testing.T) {
+
+			// Save old command-line arguments so that we can restore them later
+			// https://stackoverflow.com/questions/33723300/how-to-test-the-passing-of-arguments-in-golang
+			oldArgs := os.Args
+
+			// Defer restoring original command-line arguments
+			defer func() { os.Args = oldArgs }()
+
+			// Clear out any entries added by `go test` or leftovers from
+			// previous test cases.
+			os.Args = nil
+
+			// The testdata directory is two levels up
+			normalizedFullyQualifiedFilename := filepath.Join("../../", test.filenameFlagValue)
+
+			flagsAndValuesInOrder := []string{
+				config.PluginIncidentsAppName,
+				defaultFilenameFlag, normalizedFullyQualifiedFilename,
+				defaultLogLevelFlag, defaultLogLevelFlagValue,
+				defaultReadLimitFlag, defaultReadLimitFlagValue,
+				defaultTimeoutFlag, defaultTimeoutFlagValue,
+			}
+
+			if test.componentFlagValue != "" {
+				flagsAndValuesInOrder = append(flagsAndValuesInOrder, defaultComponentFlag, test.componentFlagValue)
+			}
+
+			if test.groupFlagValue != "" {
+				flagsAndValuesInOrder = append(flagsAndValuesInOrder, defaultGroupFlag, test.groupFlagValue)
+			}
+
+			for i, item := range flagsAndValuesInOrder {
+				if strings.TrimSpace(item) != "" {
+					os.Args = append(os.Args, item)
+				} else {
+					t.Logf("Skipping item %d due to empty value", i)
+				}
+			}
+
+			t.Log("INFO: New os.Args before init config:\n", os.Args)
+
+			// Expected to succeed (no potential failure allowance)
+			cfg, err := config.New(config.AppType{PluginIncidents: true})
+			if err != nil {
+				t.Fatalf("Failed to instantiate configuration: %v", err)
+			}
+
+			// Expected to succeed (no potential failure allowance)
+			incidentsSummary, err := summary.NewFromFile(cfg.Filename, cfg.ReadLimit, cfg.AllowUnknownJSONFields)
+			if err != nil {
+				t.Fatalf("Failed to decode summary: %v", err)
+			}
+
+			// Expected to succeed (no potential failure allowance)
+			if err := incidentsSummary.Validate(); err != nil {
+				t.Fatalf("Failed to validate summary: %v", err)
+			}
+
+			// Resolve filter values using the full collection of page
+			// components as the plugin does for feeds which do not provide
+			// it.
+			if test.componentsFile != "" {
+				componentsSet, err := components.NewFromFile(
+					filepath.Join("../../", test.componentsFile),
+					cfg.ReadLimit,
+					cfg.AllowUnknownJSONFields,
+				)
+				if err != nil {
+					t.Fatalf("Failed to decode components: %v", err)
+				}
+				incidentsSummary.UseComponents(componentsSet)
+			}
+
+			csFilter := components.Filter(cfg.ComponentFilter())
+			if len(csFilter.Groups) > 0 || len(csFilter.Components) > 0 {
+				err := incidentsSummary.Filter(csFilter)
+				switch {
+				case err != nil && test.filterErrorExpected:
+					t.Logf("OK: filter error occurred as expected: %v", err)
+
+					return
+
+				case err != nil:
+					t.Fatalf("Failed to apply filter to summary: %v", err)
+
+				case test.filterErrorExpected:
+					t.Fatalf("Filter error expected, but none occurred")
+				}
+			}
+
+			serviceState := incidentsSummary.IncidentsServiceState(false)
+			if d := cmp.Diff(test.expectedPluginStatus, serviceState); d != "" {
+				t.Errorf("(-want, +got)\n:%s", d)
+			} else {
+				t.Logf("OK: got expected plugin status %#v", serviceState)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Nagios plugin used to monitor active and upcoming scheduled maintenances from
+a status page powered by Atlassian Statuspage.
+
+# PURPOSE
+
+A component reporting an under_maintenance status is often the first sign of
+a scheduled maintenance, but by then it is too late to warn users. This
+plugin evaluates active scheduled maintenances along with upcoming scheduled
+maintenances starting within a specified lookahead duration, optionally
+limited to those affecting specific components or a component group.
+
+The output for this plugin is designed to provide the one-line summary needed
+by Nagios for quick identification of a problem while providing longer, more
+detailed information for use in email and Teams notifications
+(https://github.com/atc0005/send2teams).
+
+# PROJECT HOME
+
+See our GitHub repo (https://github.com/atc0005/check-statuspage) for the
+latest code, to file an issue or submit improvements for review and potential
+inclusion into the project.
+
+# USAGE
+
+See our main README for supported settings and examples.
+*/
+package main
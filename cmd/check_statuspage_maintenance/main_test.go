@@ -0,0 +1,383 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atc0005/check-statuspage/internal/config"
+	"github.com/atc0005/check-statuspage/internal/statuspage/components"
+	"github.com/atc0005/check-statuspage/internal/statuspage/summary"
+	"github.com/atc0005/go-nagios"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Shared flags and values across various tests
+const (
+	defaultFilenameFlag       = "--" + config.FilenameFlagLong
+	defaultLogLevelFlag       = "--" + config.LogLevelFlagLong
+	defaultLogLevelFlagValue  = config.LogLevelInfo
+	defaultComponentFlag      = "--" + config.ComponentsListFlagLong
+	defaultGroupFlag          = "--" + config.ComponentGroupFlagLong
+	defaultWithinFlag         = "--" + config.MaintenanceWithinFlag
+	defaultTimeoutFlag        = "--" + config.TimeoutFlagLong
+	defaultTimeoutFlagValue   = "10"
+	defaultReadLimitFlag      = "--" + config.ReadLimitFlagLong
+	defaultReadLimitFlagValue = "1048576" // 1 MB
+
+	instructureUpcomingFile     = "testdata/scheduled-maintenances/instructure-scheduled-maintenances-upcoming.json"
+	instructureActiveFile       = "testdata/scheduled-maintenances/instructure-scheduled-maintenances-active.json"
+	instructureCanvasGroupID    = "41wg86q5vc14"
+	instructureCanvasID         = "9dlvqx1drp3d"
+	instructureAssessmentsID    = "v6m5nhwgtshj"
+	instructureWebsiteID        = "j7jp6sq831c2"
+	instructureMasteryConnectID = "qw5j90r2w7k1"
+	instructureDropboxID        = "mtytktcmbk6p"
+	instructureComponentsFile   = "testdata/components/instructure-components.json"
+	instructureCanvasName       = "Canvas"
+	instructureCanvasTypo       = "Canvs"
+)
+
+// TestScheduledMaintenanceFeedURLs asserts that the upcoming and active
+// scheduled maintenance feed URLs are both returned when either is specified
+// and that other URLs are returned as-is.
+func TestScheduledMaintenanceFeedURLs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		url  string
+		want []string
+	}{
+		{
+			name: "upcoming feed",
+			url:  "https://status.instructure.com/api/v2/scheduled-maintenances/upcoming.json",
+			want: []string{
+				"https://status.instructure.com/api/v2/scheduled-maintenances/upcoming.json",
+				"https://status.instructure.com/api/v2/scheduled-maintenances/active.json",
+			},
+		},
+		{
+			name: "active feed",
+			url:  "https://status.instructure.com/api/v2/scheduled-maintenances/active.json",
+			want: []string{
+				"https://status.instructure.com/api/v2/scheduled-maintenances/upcoming.json",
+				"https://status.instructure.com/api/v2/scheduled-maintenances/active.json",
+			},
+		},
+		{
+			name: "summary feed",
+			url:  "https://status.instructure.com/api/v2/summary.json",
+			want: []string{
+				"https://status.instructure.com/api/v2/summary.json",
+			},
+		},
+		{
+			name: "all scheduled maintenances feed",
+			url:  "https://status.instructure.com/api/v2/scheduled-maintenances.json",
+			want: []string{
+				"https://status.instructure.com/api/v2/scheduled-maintenances.json",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := summary.ScheduledMaintenanceFeedURLs(test.url)
+			if d := cmp.Diff(test.want, got); d != "" {
+				t.Errorf("(-want, +got)\n:%s", d)
+			}
+		})
+	}
+}
+
+// TestComponentsFeedURL asserts that the components feed URL for a page is
+// derived from the URL of any of its API/JSON feeds.
+func TestComponentsFeedURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{
+			name: "upcoming feed",
+			url:  "https://status.instructure.com/api/v2/scheduled-maintenances/upcoming.json",
+			want: "https://status.instructure.com/api/v2/components.json",
+		},
+		{
+			name: "unresolved incidents feed",
+			url:  "https://status.instructure.com/api/v2/incidents/unresolved.json",
+			want: "https://status.instructure.com/api/v2/components.json",
+		},
+		{
+			name: "non-API path",
+			url:  "https://status.instructure.com/history",
+			want: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := summary.ComponentsFeedURL(test.url); got != test.want {
+				t.Errorf("ERROR: want %q, got %q", test.want, got)
+			} else {
+				t.Logf("OK: got expected URL %q", got)
+			}
+		})
+	}
+}
+
+// TestPluginStatusFromTestdataFiles loads, decodes, validates and optionally
+// filters each listed JSON testdata file and then asserts that the resulting
+// plugin status matches the expected value for a fixed evaluation time.
+func TestPluginStatusFromTestdataFiles(t *testing.T) {
+
+	// Fixed evaluation time between the creation of the upcoming scheduled
+	// maintenances and the start of the earliest one.
+	now := time.Date(2021, time.December, 9, 18, 0, 0, 0, time.UTC)
+
+	warningState := nagios.ServiceState{
+		Label:    nagios.StateWARNINGLabel,
+		ExitCode: nagios.StateWARNINGExitCode,
+	}
+
+	okState := nagios.ServiceState{
+		Label:    nagios.StateOKLabel,
+		ExitCode: nagios.StateOKExitCode,
+	}
+
+	tests := []struct {
+		name                 string
+		filenameFlagValue    string
+		groupFlagValue       string
+		componentFlagValue   string
+		withinFlagValue      string
+		componentsFile       string
+		expectedPluginStatus nagios.ServiceState
+		filterErrorExpected  bool
+	}{
+		{
+			name:                 "Upcoming, no filter, default lookahead",
+			filenameFlagValue:    instructureUpcomingFile,
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Upcoming, no filter, short lookahead",
+			filenameFlagValue:    instructureUpcomingFile,
+			withinFlagValue:      "6h",
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                 "Upcoming, affected component group by ID",
+			filenameFlagValue:    instructureUpcomingFile,
+			groupFlagValue:       instructureCanvasGroupID,
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Upcoming, affected component by ID",
+			filenameFlagValue:    instructureUpcomingFile,
+			componentFlagValue:   instructureCanvasID,
+			withinFlagValue:      "48h",
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Upcoming, component affected beyond lookahead",
+			filenameFlagValue:    instructureUpcomingFile,
+			componentFlagValue:   instructureAssessmentsID,
+			withinFlagValue:      "72h",
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                 "Upcoming, component group affected within long lookahead",
+			filenameFlagValue:    instructureUpcomingFile,
+			groupFlagValue:       instructureMasteryConnectID,
+			withinFlagValue:      "720h",
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Upcoming, unaffected component",
+			filenameFlagValue:    instructureUpcomingFile,
+			componentFlagValue:   instructureDropboxID,
+			componentsFile:       instructureComponentsFile,
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                 "Upcoming, unaffected component without page components",
+			filenameFlagValue:    instructureUpcomingFile,
+			componentFlagValue:   instructureDropboxID,
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                 "Upcoming, affected component group by name",
+			filenameFlagValue:    instructureUpcomingFile,
+			groupFlagValue:       instructureCanvasName,
+			componentsFile:       instructureComponentsFile,
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Upcoming, component group by name without page components",
+			filenameFlagValue:    instructureUpcomingFile,
+			groupFlagValue:       instructureCanvasName,
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                "Upcoming, misspelled component group",
+			filenameFlagValue:   instructureUpcomingFile,
+			groupFlagValue:      instructureCanvasTypo,
+			componentsFile:      instructureComponentsFile,
+			filterErrorExpected: true,
+		},
+		{
+			name:                 "Active, no filter",
+			filenameFlagValue:    instructureActiveFile,
+			withinFlagValue:      "1m",
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Active, affected component",
+			filenameFlagValue:    instructureActiveFile,
+			componentFlagValue:   instructureWebsiteID,
+			expectedPluginStatus: warningState,
+		},
+		{
+			name:                 "Active, unaffected component",
+			filenameFlagValue:    instructureActiveFile,
+			componentFlagValue:   instructureCanvasID,
+			componentsFile:       instructureComponentsFile,
+			expectedPluginStatus: okState,
+		},
+		{
+			name:                "Active, misspelled component",
+			filenameFlagValue:   instructureActiveFile,
+			componentFlagValue:  instructureCanvasTypo,
+			componentsFile:      instructureComponentsFile,
+			filterErrorExpected: true,
+		},
+		{
+			name:                 "Active, component group by name without page components",
+			filenameFlagValue:    instructureActiveFile,
+			groupFlagValue:       instructureCanvasName,
+			expectedPluginStatus: okState,
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.name, func(t *testing.T) {
+
+			// Save old command-line arguments so that we can restore them later
+			// https://stackoverflow.com/questions/33723300/how-to-test-the-passing-of-arguments-in-golang
+			oldArgs := os.Args
+
+			// Defer restoring original command-line arguments
+			defer func() { os.Args = oldArgs }()
+
+			// Clear out any entries added by `go test` or leftovers from
+			// previous test cases.
+			os.Args = nil
+
+			// The testdata directory is two levels up
+			normalizedFullyQualifiedFilename := filepath.Join("../../", test.filenameFlagValue)
+
+			flagsAndValuesInOrder := []string{
+				config.PluginMaintenanceAppName,
+				defaultFilenameFlag, normalizedFullyQualifiedFilename,
+				defaultLogLevelFlag, defaultLogLevelFlagValue,
+				defaultReadLimitFlag, defaultReadLimitFlagValue,
+				defaultTimeoutFlag, defaultTimeoutFlagValue,
+			}
+
+			if test.componentFlagValue != "" {
+				flagsAndValuesInOrder = append(flagsAndValuesInOrder, defaultComponentFlag, test.componentFlagValue)
+			}
+
+			if test.groupFlagValue != "" {
+				flagsAndValuesInOrder = append(flagsAndValuesInOrder, defaultGroupFlag, test.groupFlagValue)
+			}
+
+			if test.withinFlagValue != "" {
+				flagsAndValuesInOrder = append(flagsAndValuesInOrder, defaultWithinFlag, test.withinFlagValue)
+			}
+
+			for i, item := range flagsAndValuesInOrder {
+				if strings.TrimSpace(item) != "" {
+					os.Args = append(os.Args, item)
+				} else {
+					t.Logf("Skipping item %d due to empty value", i)
+				}
+			}
+
+			t.Log("INFO: New os.Args before init config:\n", os.Args)
+
+			// Expected to succeed (no potential failure allowance)
+			cfg, err := config.New(config.AppType{PluginMaintenance: true})
+			if err != nil {
+				t.Fatalf("Failed to instantiate configuration: %v", err)
+			}
+
+			// Expected to succeed (no potential failure allowance)
+			maintenanceSummary, err := summary.NewFromFile(cfg.Filename, cfg.ReadLimit, cfg.AllowUnknownJSONFields)
+			if err != nil {
+				t.Fatalf("Failed to decode summary: %v", err)
+			}
+
+			// Expected to succeed (no potential failure allowance)
+			if err := maintenanceSummary.Validate(); err != nil {
+				t.Fatalf("Failed to validate summary: %v", err)
+			}
+
+			// Resolve filter values using the full collection of page
+			// components as the plugin does for feeds which do not provide
+			// it.
+			if test.componentsFile != "" {
+				componentsSet, err := components.NewFromFile(
+					filepath.Join("../../", test.componentsFile),
+					cfg.ReadLimit,
+					cfg.AllowUnknownJSONFields,
+				)
+				if err != nil {
+					t.Fatalf("Failed to decode components: %v", err)
+				}
+				maintenanceSummary.UseComponents(componentsSet)
+			}
+
+			csFilter := components.Filter(cfg.ComponentFilter())
+			if len(csFilter.Groups) > 0 || len(csFilter.Components) > 0 {
+				err := maintenanceSummary.Filter(csFilter)
+				switch {
+				case err != nil && test.filterErrorExpected:
+					t.Logf("OK: filter error occurred as expected: %v", err)
+
+					return
+
+				case err != nil:
+					t.Fatalf("Failed to apply filter to summary: %v", err)
+
+				case test.filterErrorExpected:
+					t.Fatalf("Filter error expected, but none occurred")
+				}
+			}
+
+			serviceState := maintenanceSummary.ScheduledMaintenancesServiceState(now, cfg.MaintenanceWithin, false)
+			if d := cmp.Diff(test.expectedPluginStatus, serviceState); d != "" {
+				t.Errorf("(-want, +got)\n:%s", d)
+			} else {
+				t.Logf("OK: got expected plugin status %#v", serviceState)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Nagios plugin used to monitor the overall page status indicator from a status
+page powered by Atlassian Statuspage.
+
+# PURPOSE
+
+Some vendors leave every component in an operational status while setting the
+page-wide status indicator to reflect an ongoing problem. This plugin
+evaluates the page status indicator (none, minor, major or critical) and
+description provided by the status.json (or summary.json) endpoint and maps
+the indicator to a Nagios state.
+
+The output for this plugin is designed to provide the one-line summary needed
+by Nagios for quick identification of a problem while providing longer, more
+detailed information for use in email and Teams notifications
+(https://github.com/atc0005/send2teams).
+
+# PROJECT HOME
+
+See our GitHub repo (https://github.com/atc0005/check-statuspage) for the
+latest code, to file an issue or submit improvements for review and potential
+inclusion into the project.
+
+# USAGE
+
+See our main README for supported settings and examples.
+*/
+package main
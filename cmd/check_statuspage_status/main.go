@@ -0,0 +1,303 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/atc0005/go-nagios"
+
+	"github.com/atc0005/check-statuspage/internal/config"
+	"github.com/atc0005/check-statuspage/internal/reports"
+	"github.com/atc0005/check-statuspage/internal/statuspage"
+	"github.com/atc0005/check-statuspage/internal/statuspage/summary"
+
+	"github.com/rs/zerolog"
+)
+
+//go:generate go-winres make --product-version=git-tag --file-version=git-tag
+
+func main() {
+
+	plugin := nagios.NewPlugin()
+
+	// defer this from the start so it is the last deferred function to run
+	defer plugin.ReturnCheckResults()
+
+	// Setup configuration by parsing user-provided flags. Note plugin type so
+	// that only applicable CLI flags are exposed and any plugin-specific
+	// settings are applied.
+	cfg, cfgErr := config.New(config.AppType{PluginStatus: true})
+	switch {
+	case errors.Is(cfgErr, config.ErrVersionRequested):
+		fmt.Println(config.Version())
+
+		return
+
+	case errors.Is(cfgErr, config.ErrHelpRequested):
+		fmt.Println(cfg.Help())
+
+		return
+
+	case cfgErr != nil:
+
+		// We make some assumptions when setting up our logger as we do not
+		// have a working configuration based on sysadmin-specified choices.
+		consoleWriter := zerolog.ConsoleWriter{Out: os.Stderr}
+		logger := zerolog.New(consoleWriter).With().Timestamp().Caller().Logger()
+
+		logger.Err(cfgErr).Msg("Error initializing application")
+
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s: Error initializing application",
+			nagios.StateUNKNOWNLabel,
+		)
+		plugin.AddError(cfgErr)
+		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+		return
+	}
+
+	// Enable library-level logging if debug or greater logging level is
+	// enabled app-wide.
+	handleLibraryLogging()
+
+	// Set context deadline equal to user-specified timeout value for plugin
+	// runtime/execution.
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout())
+	defer cancel()
+
+	// Record thresholds for use as Nagios "Long Service Output" content. This
+	// content is shown in the detailed web UI and in notifications generated
+	// by Nagios.
+	if cfg.ShowVerbose {
+		plugin.CriticalThreshold = strings.Join(
+			summary.ServiceStateToStatusIndicators(
+				nagios.ServiceState{
+					Label:    nagios.StateCRITICALLabel,
+					ExitCode: nagios.StateCRITICALExitCode,
+				},
+			),
+			", ",
+		)
+
+		plugin.WarningThreshold = strings.Join(
+			summary.ServiceStateToStatusIndicators(
+				nagios.ServiceState{
+					Label:    nagios.StateWARNINGLabel,
+					ExitCode: nagios.StateWARNINGExitCode,
+				},
+			),
+			", ",
+		)
+	}
+
+	if cfg.EmitBranding {
+		// If enabled, show application details at end of notification
+		plugin.BrandingCallback = config.Branding("Notification generated by ")
+	}
+
+	log := cfg.Log.With().
+		Str("filename", cfg.Filename).
+		Str("url", cfg.URL).
+		Str("provider", cfg.Provider).
+		Str("cache_dir", cfg.CacheDir).
+		Int("retries", cfg.Retries).
+		Dur("retry_delay", cfg.RetryDelay).
+		Int64("read_limit", cfg.ReadLimit).
+		Bool("allow_unknown_fields", cfg.AllowUnknownJSONFields).
+		Logger()
+
+	// Annotate results evaluated using the last good copy of a feed which
+	// could not be retrieved.
+	var staleErrs []*statuspage.StaleFeedError
+	defer func() {
+		reports.AnnotateStaleFeedResults(plugin, cfg.FallbackServiceState(), staleErrs)
+	}()
+
+	// Process one of local file or remote URL. Rely on config package
+	// validation to prevent the user from specifying both.
+	var statusSummary *summary.Summary
+	var feedSource string
+	switch {
+
+	case cfg.Filename != "":
+
+		feedSource = cfg.Filename
+
+		log.Debug().Msg("Processing JSON file")
+
+		var err error
+		statusSummary, err = summary.NewFromFile(cfg.Filename, cfg.ReadLimit, cfg.AllowUnknownJSONFields)
+		if err != nil {
+			log.Error().Err(err).Msg("Error occurred processing input file")
+
+			plugin.AddError(err)
+			plugin.ServiceOutput = fmt.Sprintf(
+				"%s: Failed to process JSON feed from file",
+				nagios.StateUNKNOWNLabel,
+			)
+			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+			var prepErr *statuspage.PrepError
+			if errors.As(err, &prepErr) {
+				plugin.ServiceOutput += ": " + prepErr.Message
+			}
+
+			return
+		}
+		log.Debug().Msg("Successfully processed JSON file")
+
+	case cfg.URL != "":
+
+		feedSource = cfg.URL
+
+		log.Debug().Msg("Processing JSON feed")
+
+		var err error
+		statusSummary, err = summary.NewFromURL(
+			ctx,
+			cfg.URL,
+			cfg.ReadLimit,
+			cfg.AllowUnknownJSONFields,
+			cfg.UserAgent(),
+			cfg.FetchOptions(),
+		)
+
+		var staleErr *statuspage.StaleFeedError
+		if errors.As(err, &staleErr) {
+			log.Warn().Err(err).Msg("Using last good copy of JSON feed")
+
+			staleErrs = append(staleErrs, staleErr)
+			err = nil
+		}
+
+		if err != nil {
+			log.Error().Err(err).Msg("Error processing JSON feed")
+
+			plugin.AddError(err)
+			plugin.ServiceOutput = fmt.Sprintf(
+				"%s: Failed to process JSON feed from URL",
+				nagios.StateUNKNOWNLabel,
+			)
+			plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+			var prepErr *statuspage.PrepError
+			if errors.As(err, &prepErr) {
+				plugin.ServiceOutput += ": " + prepErr.Message
+			}
+
+			return
+		}
+		log.Debug().Msg("Successfully processed JSON feed")
+
+	}
+
+	if err := statusSummary.ValidateStatus(); err != nil {
+
+		log.Error().Msg("Failed to validate JSON feed")
+
+		plugin.AddError(err)
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s: Error validating JSON feed from %q",
+			nagios.StateUNKNOWNLabel,
+			feedSource,
+		)
+		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+		return
+
+	}
+
+	if err := statusSummary.Page.ValidateID(cfg.ExpectedPageID); err != nil {
+
+		log.Error().Err(err).Msg("JSON feed does not belong to specified provider")
+
+		plugin.AddError(err)
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s: JSON feed from %q does not belong to provider %q",
+			nagios.StateUNKNOWNLabel,
+			feedSource,
+			cfg.ProviderName,
+		)
+		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+
+		return
+
+	}
+
+	pd := []nagios.PerformanceData{
+		// The `time` (runtime) metric is appended at plugin exit, so do not
+		// duplicate it here.
+		{
+			Label: "page_status_indicator",
+			Value: fmt.Sprintf("%d", summary.StatusIndicatorLevel(statusSummary.Status.Indicator)),
+		},
+	}
+
+	// Update logger with new page status related fields
+	log = log.With().
+		Str("indicator", statusSummary.Status.Indicator).
+		Str("description", statusSummary.Status.Description).
+		Logger()
+
+	if err := plugin.AddPerfData(false, pd...); err != nil {
+		log.Error().
+			Err(err).
+			Msg("failed to add performance data")
+
+		// Surface the error in plugin output.
+		plugin.AddError(err)
+
+		plugin.ExitStatusCode = nagios.StateUNKNOWNExitCode
+		plugin.ServiceOutput = fmt.Sprintf(
+			"%s: Failed to process performance data metrics",
+			nagios.StateUNKNOWNLabel,
+		)
+
+		return
+	}
+
+	serviceState := statusSummary.StatusServiceState()
+
+	switch {
+	case serviceState.ExitCode != nagios.StateOKExitCode:
+
+		log.Error().
+			Str("state", serviceState.Label).
+			Msg("Page status indicator reports service impact")
+
+		plugin.AddError(summary.ErrPageStatusIndicatorNotOK)
+
+	default:
+
+		// success path
+
+		log.Debug().Msg("Page status indicator reports no service impact")
+
+	}
+
+	plugin.ExitStatusCode = serviceState.ExitCode
+
+	plugin.ServiceOutput = reports.PageStatusOneLineCheckSummary(
+		serviceState.Label,
+		statusSummary,
+	)
+
+	plugin.LongServiceOutput = reports.PageStatusReport(
+		serviceState.Label,
+		statusSummary,
+		cfg.OmitSummaryResults,
+		cfg.ShowVerbose,
+	)
+
+}
@@ -0,0 +1,144 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/atc0005/check-statuspage/internal/config"
+	"github.com/atc0005/check-statuspage/internal/statuspage/summary"
+	"github.com/atc0005/go-nagios"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Shared flags and values across various tests
+const (
+	defaultFilenameFlag       = "--" + config.FilenameFlagLong
+	defaultLogLevelFlag       = "--" + config.LogLevelFlagLong
+	defaultLogLevelFlagValue  = config.LogLevelInfo
+	defaultTimeoutFlag        = "--" + config.TimeoutFlagLong
+	defaultTimeoutFlagValue   = "10"
+	defaultReadLimitFlag      = "--" + config.ReadLimitFlagLong
+	defaultReadLimitFlagValue = "1048576" // 1 MB
+
+	instructureStatusFile  = "testdata/status/instructure-status.json"
+	instructureSummaryFile = "testdata/summary/instructure-summary.json"
+	githubStatusFile       = "testdata/status/github-status.json"
+	githubStatusMajorFile  = "testdata/status/github-status-major.json"
+)
+
+// TestPluginStatusFromTestdataFiles loads, decodes and validates each listed
+// JSON testdata file and then asserts that the resulting plugin status
+// matches the expected value.
+func TestPluginStatusFromTestdataFiles(t *testing.T) {
+
+	tests := []struct {
+		name                 string
+		filenameFlagValue    string
+		expectedPluginStatus nagios.ServiceState
+	}{
+		{
+			name:              "Instructure status, minor indicator",
+			filenameFlagValue: instructureStatusFile,
+			expectedPluginStatus: nagios.ServiceState{
+				Label:    nagios.StateWARNINGLabel,
+				ExitCode: nagios.StateWARNINGExitCode,
+			},
+		},
+		{
+			name:              "Instructure summary, minor indicator",
+			filenameFlagValue: instructureSummaryFile,
+			expectedPluginStatus: nagios.ServiceState{
+				Label:    nagios.StateWARNINGLabel,
+				ExitCode: nagios.StateWARNINGExitCode,
+			},
+		},
+		{
+			name:              "GitHub status, no indicator",
+			filenameFlagValue: githubStatusFile,
+			expectedPluginStatus: nagios.ServiceState{
+				Label:    nagios.StateOKLabel,
+				ExitCode: nagios.StateOKExitCode,
+			},
+		},
+		{
+			name:              "GitHub status, major indicator",
+			filenameFlagValue: githubStatusMajorFile,
+			expectedPluginStatus: nagios.ServiceState{
+				Label:    nagios.StateCRITICALLabel,
+				ExitCode: nagios.StateCRITICALExitCode,
+			},
+		},
+	}
+
+	for _, test := range tests {
+
+		t.Run(test.name, func(t *testing.T) {
+
+			// Save old command-line arguments so that we can restore them later
+			// https://stackoverflow.com/questions/33723300/how-to-test-the-passing-of-arguments-in-golang
+			oldArgs := os.Args
+
+			// Defer restoring original command-line arguments
+			defer func() { os.Args = oldArgs }()
+
+			// Clear out any entries added by `go test` or leftovers from
+			// previous test cases.
+			os.Args = nil
+
+			// The testdata directory is two levels up
+			normalizedFullyQualifiedFilename := filepath.Join("../../", test.filenameFlagValue)
+
+			flagsAndValuesInOrder := []string{
+				config.PluginStatusAppName,
+				defaultFilenameFlag, normalizedFullyQualifiedFilename,
+				defaultLogLevelFlag, defaultLogLevelFlagValue,
+				defaultReadLimitFlag, defaultReadLimitFlagValue,
+				defaultTimeoutFlag, defaultTimeoutFlagValue,
+			}
+
+			for i, item := range flagsAndValuesInOrder {
+				if strings.TrimSpace(item) != "" {
+					os.Args = append(os.Args, item)
+				} else {
+					t.Logf("Skipping item %d due to empty value", i)
+				}
+			}
+
+			t.Log("INFO: New os.Args before init config:\n", os.Args)
+
+			// Expected to succeed (no potential failure allowance)
+			cfg, err := config.New(config.AppType{PluginStatus: true})
+			if err != nil {
+				t.Fatalf("Failed to instantiate configuration: %v", err)
+			}
+
+			// Expected to succeed (no potential failure allowance)
+			statusSummary, err := summary.NewFromFile(cfg.Filename, cfg.ReadLimit, cfg.AllowUnknownJSONFields)
+			if err != nil {
+				t.Fatalf("Failed to decode summary: %v", err)
+			}
+
+			// Expected to succeed (no potential failure allowance)
+			if err := statusSummary.ValidateStatus(); err != nil {
+				t.Fatalf("Failed to validate summary: %v", err)
+			}
+
+			serviceState := statusSummary.StatusServiceState()
+			if d := cmp.Diff(test.expectedPluginStatus, serviceState); d != "" {
+				t.Errorf("(-want, +got)\n:%s", d)
+			} else {
+				t.Logf("OK: got expected plugin status %#v", serviceState)
+			}
+		})
+	}
+}
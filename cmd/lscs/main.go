@@ -20,6 +20,7 @@ import (
 	"github.com/sanity-io/litter"
 
 	"github.com/atc0005/check-statuspage/internal/config"
+	"github.com/atc0005/check-statuspage/internal/providers"
 	"github.com/atc0005/check-statuspage/internal/reports"
 	"github.com/atc0005/check-statuspage/internal/statuspage/components"
 
@@ -43,6 +44,17 @@ func main() {
 
 		return
 
+	case errors.Is(cfgErr, config.ErrListProvidersRequested):
+		catalog, err := providers.Catalog()
+		if err != nil {
+			zlog.Err(err).Msg("Error loading provider catalog")
+
+			return
+		}
+		fmt.Print(reports.ProvidersTable(catalog))
+
+		return
+
 	case cfgErr != nil:
 		// We're using the standalone Err function from rs/zerolog/log as we
 		// do not have a working configuration.
@@ -63,6 +75,10 @@ func main() {
 	log := cfg.Log.With().
 		Str("filename", cfg.Filename).
 		Str("url", cfg.URL).
+		Str("provider", cfg.Provider).
+		Str("cache_dir", cfg.CacheDir).
+		Int("retries", cfg.Retries).
+		Dur("retry_delay", cfg.RetryDelay).
 		Int64("read_limit", cfg.ReadLimit).
 		Bool("allow_unknown_fields", cfg.AllowUnknownJSONFields).
 		Logger()
@@ -101,6 +117,7 @@ func main() {
 			cfg.ReadLimit,
 			cfg.AllowUnknownJSONFields,
 			cfg.UserAgent(),
+			cfg.FetchOptions(),
 		)
 		if err != nil {
 			log.Error().Err(err).Msg("Error decoding JSON feed")
@@ -120,6 +137,15 @@ func main() {
 		return
 	}
 
+	if err := componentsSet.Page.ValidateID(cfg.ExpectedPageID); err != nil {
+		log.Error().
+			Err(err).
+			Str("feed_source", feedSource).
+			Msg("JSON feed does not belong to specified provider")
+
+		return
+	}
+
 	switch cfg.InspectorOutputFormat {
 
 	case config.InspectorOutputFormatOverview:
@@ -12,6 +12,7 @@ go 1.23.0
 require (
 	github.com/atc0005/go-nagios v0.20.0
 	github.com/google/go-cmp v0.7.0
+	github.com/klauspost/compress v1.18.0
 	github.com/rs/zerolog v1.34.0
 	github.com/sanity-io/litter v1.5.8
 )
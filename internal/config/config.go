@@ -14,6 +14,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
 )
@@ -31,6 +32,10 @@ var ErrVersionRequested = errors.New("version information requested")
 // information.
 var ErrHelpRequested = errors.New("help/usage information requested")
 
+// ErrListProvidersRequested indicates that the user requested the catalog of
+// known providers.
+var ErrListProvidersRequested = errors.New("provider catalog requested")
+
 // ErrConfigNotInitialized indicates that the configuration is not in a usable
 // state and application execution can not successfully proceed.
 var ErrConfigNotInitialized = errors.New("configuration not initialized")
@@ -46,6 +51,19 @@ type AppType struct {
 	// for evaluating Statuspage components.
 	PluginComponents bool
 
+	// PluginIncidents represents an application used as a monitoring plugin
+	// for evaluating unresolved Statuspage incidents.
+	PluginIncidents bool
+
+	// PluginMaintenance represents an application used as a monitoring
+	// plugin for evaluating active and upcoming Statuspage scheduled
+	// maintenances.
+	PluginMaintenance bool
+
+	// PluginStatus represents an application used as a monitoring plugin
+	// for evaluating the overall Statuspage page status indicator.
+	PluginStatus bool
+
 	// InspectorComponents represents an application used for one-off or
 	// isolated checks against Statuspage components. Unlike a Nagios plugin
 	// which is focused on specific attributes resulting in a severity-based
@@ -127,13 +145,37 @@ func (mvs *multiValueStringFlag) Set(value string) error {
 // component and group flags.
 type ComponentFilter struct {
 
-	// Group is an optional component group (aka, "parent" or "container")
-	// associated with individual subcomponents.
-	Group string
+	// Groups is an optional collection of component groups (aka, "parent"
+	// or "container"), each optionally associated with individual
+	// subcomponents.
+	Groups []ComponentGroupFilter
+
+	// Components is a collection of components which are not associated with
+	// a component group.
+	Components []string
 
-	// Components is a collection of components. If specified with a component
-	// group, then all specified components must be subcomponents of the
-	// specified component group.
+	// MatchMode is the mode used to match Group and Components values
+	// against component names.
+	MatchMode string
+
+	// ExcludeGroups is a collection of component groups excluded from
+	// evaluation along with all of their subcomponents.
+	ExcludeGroups []string
+
+	// ExcludeComponents is a collection of components excluded from
+	// evaluation.
+	ExcludeComponents []string
+}
+
+// ComponentGroupFilter pairs a user specified component group with an
+// optional list of subcomponents. If specified, all components must be
+// subcomponents of the component group.
+//
+// ComponentGroupFilter is an alias for an unnamed struct type (matching the
+// components.GroupFilter type) so that a ComponentFilter can be converted to
+// a components.Filter.
+type ComponentGroupFilter = struct {
+	Group      string
 	Components []string
 }
 
@@ -152,9 +194,14 @@ func (cgs *ComponentFilter) String() string {
 		return ""
 	}
 
+	groups := make([]string, 0, len(cgs.Groups))
+	for _, group := range cgs.Groups {
+		groups = append(groups, group.Group)
+	}
+
 	return fmt.Sprintf(
-		`{Group: "%s", Components: "%s"}`,
-		cgs.Group,
+		`{Groups: "%s", Components: "%s"}`,
+		strings.Join(groups, ", "),
 		strings.Join(cgs.Components, ", "),
 	)
 }
@@ -189,11 +236,11 @@ func (cgs *ComponentFilter) Set(value string) error {
 		items[i] = strings.ReplaceAll(items[i], "\"", "")
 	}
 
-	// Set group using first value
-	cgs.Group = items[0]
-
-	// Expand components list using remaining values
-	cgs.Components = append(cgs.Components, items[1:]...)
+	// Pair group (first value) with components list (remaining values)
+	cgs.Groups = append(cgs.Groups, ComponentGroupFilter{
+		Group:      items[0],
+		Components: items[1:],
+	})
 
 	return nil
 
@@ -203,13 +250,34 @@ func (cgs *ComponentFilter) Set(value string) error {
 // command-line flags.
 type Config struct {
 
-	// URL is the fully-qualified URL of a Statuspage API/JSON feed.
+	// URL is the fully-qualified URL of a Statuspage API/JSON feed. If the
+	// user specified a base status page URL or a Statuspage page ID, this is
+	// the API/JSON feed URL derived from that value.
 	URL string
 
+	// URLProvided is the URL, base status page URL or Statuspage page ID
+	// value as specified by the user.
+	URLProvided string
+
+	// Provider is the key of a known provider from the provider catalog as
+	// specified by the user.
+	Provider string
+
+	// ProviderName is the display name of the specified provider.
+	ProviderName string
+
+	// ExpectedPageID is the Statuspage page ID expected for the specified
+	// provider. This value is empty if a provider was not specified.
+	ExpectedPageID string
+
 	// Filename is the fully-qualified filename of a previously downloaded
 	// Statuspage API/JSON feed.
 	Filename string
 
+	// CacheDir is an optional directory used to cache the last response
+	// retrieved from each feed URL.
+	CacheDir string
+
 	// LoggingLevel is the supported logging level for this application.
 	LoggingLevel string
 
@@ -227,20 +295,27 @@ type Config struct {
 	// outside of the config package.
 	flagSet *flag.FlagSet
 
-	// componentGroup is a component group specified by the user. This field
-	// is set when the user opts to not specify sets, but rather a single
-	// component group for evaluation.
-	componentGroup string
-
-	// componentGroupSet is the set of component group and subcomponents for
-	// that group specified by the user. This field is set when the user opts
-	// to not specify individual components.
-	// componentGroupSet ComponentFilter
+	// componentGroups is a collection of component groups, each optionally
+	// paired with subcomponents, specified by the user for the primary feed.
+	componentGroups []ComponentGroupFilter
 
 	// componentsList is a collection of individual components specified by
 	// the user. This field is set when the user opts to not specify sets.
 	componentsList multiValueStringFlag
 
+	// excludeGroups is a collection of component groups (and their
+	// subcomponents) excluded from evaluation for the primary feed.
+	excludeGroups multiValueStringFlag
+
+	// excludeComponents is a collection of components excluded from
+	// evaluation for the primary feed.
+	excludeComponents multiValueStringFlag
+
+	// additionalFeeds is a collection of feeds specified by the user after
+	// the first (primary) feed. Each feed has its own component filter
+	// values. This field is only used by the components plugin.
+	additionalFeeds []FeedSpec
+
 	// Log is an embedded zerolog Logger initialized via config.New().
 	Log zerolog.Logger
 
@@ -253,6 +328,135 @@ type Config struct {
 	// abandoned and an error returned.
 	timeout int
 
+	// Concurrency is the maximum number of feeds retrieved concurrently when
+	// multiple feeds are specified.
+	Concurrency int
+
+	// Retries is the number of additional attempts made to retrieve a feed
+	// URL after a transient failure.
+	Retries int
+
+	// RetryDelay is the base delay between attempts to retrieve a feed URL.
+	RetryDelay time.Duration
+
+	// StaleWarning is the duration after which a status page which has not
+	// been updated results in a WARNING state. Not evaluated if zero.
+	StaleWarning time.Duration
+
+	// StaleCritical is the duration after which a status page which has not
+	// been updated results in a CRITICAL state. Not evaluated if zero.
+	StaleCritical time.Duration
+
+	// MinProblemDuration is the duration a non-operational component status
+	// must persist before the component is evaluated as a problem. All
+	// non-operational statuses are evaluated if zero.
+	MinProblemDuration time.Duration
+
+	// MaxStale is the maximum age of the last good copy of a feed evaluated
+	// in place of a feed URL which could not be retrieved. The last good
+	// copy of a feed is not used if zero.
+	MaxStale time.Duration
+
+	// FallbackState is the state applied to plugin results evaluated using
+	// the last good copy of a feed.
+	FallbackState string
+
+	// MatchMode is the mode used to match component and component group
+	// names specified via the component and group flags.
+	MatchMode string
+
+	// statusMap is a collection of status=state pairs overriding the state
+	// used for a component status. This field is only used by the components
+	// plugin.
+	statusMap multiValueStringFlag
+
+	// severityOverrides is a collection of severity rules for specific
+	// components or component groups. This field is only used by the
+	// components plugin.
+	severityOverrides severityOverrideFlag
+
+	// warningThreshold is the Nagios range (optionally with a percent
+	// suffix) for the evaluated components in a non-operational status
+	// which results in a WARNING state. This field is only used by the
+	// components plugin.
+	warningThreshold string
+
+	// GroupRollupMode is the mode used to evaluate each component group with
+	// evaluated subcomponents as a single unit.
+	GroupRollupMode string
+
+	// GroupQuorum is the percentage of evaluated subcomponents of a
+	// component group required to share a state for the quorum group rollup
+	// mode.
+	GroupQuorum int
+
+	// criticalThreshold is the Nagios range (optionally with a percent
+	// suffix) for the evaluated components in a non-operational status
+	// which results in a CRITICAL state. This field is only used by the
+	// components plugin.
+	criticalThreshold string
+
+	// ProxyURL is an optional HTTP(S) proxy URL used to retrieve feed URLs
+	// in place of proxy settings from environment variables.
+	ProxyURL string
+
+	// CABundle is an optional fully-qualified filename of a PEM encoded
+	// bundle of CA certificates trusted when retrieving feed URLs.
+	CABundle string
+
+	// ClientCert is an optional fully-qualified filename of a PEM encoded
+	// client certificate presented when retrieving feed URLs.
+	ClientCert string
+
+	// ClientKey is an optional fully-qualified filename of the PEM encoded
+	// private key for the client certificate.
+	ClientKey string
+
+	// MinTLSVersion is the minimum TLS version accepted when retrieving feed
+	// URLs.
+	MinTLSVersion string
+
+	// InsecureSkipVerify disables verification of the server certificate
+	// chain and host name when retrieving feed URLs.
+	InsecureSkipVerify bool
+
+	// BasicAuthUser is an optional username used for basic authentication
+	// when retrieving feed URLs.
+	BasicAuthUser string
+
+	// BasicAuthPasswordFile is the fully-qualified filename of a file
+	// containing the password used for basic authentication.
+	BasicAuthPasswordFile string
+
+	// BasicAuthPasswordEnv is the name of an environment variable containing
+	// the password used for basic authentication.
+	BasicAuthPasswordEnv string
+
+	// BearerTokenFile is the fully-qualified filename of a file containing a
+	// bearer token used for authentication.
+	BearerTokenFile string
+
+	// BearerTokenEnv is the name of an environment variable containing a
+	// bearer token used for authentication.
+	BearerTokenEnv string
+
+	// headers is a collection of HTTP headers in Name: Value format added to
+	// requests for feed URLs.
+	headers headerFlag
+
+	// basicAuthPassword is the password read from the user-specified file or
+	// environment variable.
+	basicAuthPassword string
+
+	// bearerToken is the bearer token read from the user-specified file or
+	// environment variable.
+	bearerToken string
+
+	// MaintenanceWithin is the lookahead duration used to determine whether
+	// an upcoming scheduled maintenance is close enough to starting to be
+	// reported as a problem.
+	MaintenanceWithin time.Duration
+
 	// EmitBranding controls whether "generated by" text is included at the
 	// bottom of application output. This output is included in the Nagios
 	// dashboard and notifications. This output may not mix well with branding
@@ -276,6 +480,10 @@ type Config struct {
 	// and exit the application.
 	ShowHelp bool
 
+	// ListProviders indicates whether the user opted to display the catalog
+	// of known providers and exit the application.
+	ListProviders bool
+
 	// OmitOKComponents indicates whether the user opted to omit components in
 	// an OK or operational status from results output. This setting does not
 	// apply to all output formats.
@@ -372,6 +580,15 @@ func appTypeLabel(appType AppType) string {
 	case appType.PluginComponents:
 		label = PluginComponentsAppType
 
+	case appType.PluginIncidents:
+		label = PluginIncidentsAppType
+
+	case appType.PluginMaintenance:
+		label = PluginMaintenanceAppType
+
+	case appType.PluginStatus:
+		label = PluginStatusAppType
+
 	case appType.InspectorComponents:
 		label = InspectorComponentsAppType
 
@@ -421,12 +638,32 @@ func New(appType AppType) (*Config, error) {
 	// returning it for use by the caller.
 	case config.ShowHelp:
 		return &config, ErrHelpRequested
+
+	// The configuration was successfully initialized, so we're good with
+	// returning it for use by the caller.
+	case config.ListProviders:
+		return &config, ErrListProvidersRequested
 	}
 
 	if err := config.validate(appType); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
 
+	if err := config.resolveSecrets(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	// Apply provider details (if specified) and then expand base status
+	// page URL or page ID values to the API/JSON feed used by this
+	// application type.
+	if err := config.resolveProvider(); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	if err := config.normalizeURL(appType); err != nil {
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
+	}
+
 	// initialize logging just as soon as validation is complete
 	if err := config.setupLogging(appType); err != nil {
 		return nil, fmt.Errorf(
@@ -435,6 +672,19 @@ func New(appType AppType) (*Config, error) {
 		)
 	}
 
+	if config.InsecureSkipVerify {
+		config.Log.Warn().
+			Str("flag", InsecureSkipVerifyFlagLong).
+			Msg("TLS certificate verification is DISABLED; feed content may be intercepted or modified")
+	}
+
+	if config.URL != config.URLProvided {
+		config.Log.Debug().
+			Str("url_provided", config.URLProvided).
+			Str("url", config.URL).
+			Msg("Normalized provided value to API/JSON feed URL")
+	}
+
 	return &config, nil
 
 }
@@ -695,6 +695,537 @@ func TestPluginComponentsConfigFlags(t *testing.T) {
 			},
 			errorExpected: false,
 		},
+		{
+			name: "Valid multiple Filename flags, specify component for each",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultFilenameFlag, defaultFilenameFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				defaultFilenameFlag, "testdata/components/github-components.json",
+				defaultComponentFlag, "GitHub Actions",
+				defaultLogLevelFlag, defaultLogLevelFlagValue,
+				defaultReadLimitFlag, defaultReadLimitFlagValue,
+				defaultTimeoutFlag, defaultTimeoutFlagValue,
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Valid mix of URL and Filename flags, specify component for each",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultFilenameFlag, defaultFilenameFlagValue,
+				defaultGroupFlag, defaultGroupFlagValue,
+				defaultURLFlag, "https://www.githubstatus.com",
+				defaultComponentFlag, "GitHub Actions",
+				defaultLogLevelFlag, defaultLogLevelFlagValue,
+				defaultReadLimitFlag, defaultReadLimitFlagValue,
+				defaultTimeoutFlag, defaultTimeoutFlagValue,
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid multiple Filename flags, missing component for second feed",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultFilenameFlag, defaultFilenameFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				defaultFilenameFlag, "testdata/components/github-components.json",
+				defaultLogLevelFlag, defaultLogLevelFlagValue,
+				defaultReadLimitFlag, defaultReadLimitFlagValue,
+				defaultTimeoutFlag, defaultTimeoutFlagValue,
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Valid multiple distinct group flags with paired components for same feed",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultFilenameFlag, defaultFilenameFlagValue,
+				defaultGroupFlag, "Canvas",
+				defaultComponentFlag, "— Chat",
+				defaultGroupFlag, "MasteryConnect",
+				defaultGroupFlag, "Portfolium",
+				defaultComponentFlag, "Website,EDU Platform",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid multiple group flags for same feed",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultFilenameFlag, defaultFilenameFlagValue,
+				defaultGroupFlag, defaultGroupFlagValue,
+				defaultGroupFlag, defaultGroupFlagValue,
+				defaultLogLevelFlag, defaultLogLevelFlagValue,
+				defaultReadLimitFlag, defaultReadLimitFlagValue,
+				defaultTimeoutFlag, defaultTimeoutFlagValue,
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid provider flag with multiple Filename flags",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				"--" + config.ProviderFlagLong, "instructure",
+				defaultFilenameFlag, defaultFilenameFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				defaultFilenameFlag, "testdata/components/github-components.json",
+				defaultComponentFlag, "GitHub Actions",
+				defaultLogLevelFlag, defaultLogLevelFlagValue,
+				defaultReadLimitFlag, defaultReadLimitFlagValue,
+				defaultTimeoutFlag, defaultTimeoutFlagValue,
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid multiple Filename flags, eval all with component for second feed",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				"--" + config.EvalAllComponentsFlagLong,
+				defaultFilenameFlag, defaultFilenameFlagValue,
+				defaultFilenameFlag, "testdata/components/github-components.json",
+				defaultComponentFlag, "GitHub Actions",
+				defaultLogLevelFlag, defaultLogLevelFlagValue,
+				defaultReadLimitFlag, defaultReadLimitFlagValue,
+				defaultTimeoutFlag, defaultTimeoutFlagValue,
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Valid max stale flag with cache dir flag",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.CacheDirFlagLong, "/tmp/check-statuspage",
+				"--" + config.MaxStaleFlagLong, "1h",
+				"--" + config.FallbackStateFlagLong, config.FallbackStateUnknown,
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid max stale flag without cache dir flag",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.MaxStaleFlagLong, "1h",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid fallback state 'critical'",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.FallbackStateFlagLong, "critical",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Valid proxy, CA bundle and TLS flags",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.ProxyURLFlagLong, "http://proxy.example.com:3128",
+				"--" + config.CABundleFlagLong, "/etc/ssl/private-ca.pem",
+				"--" + config.ClientCertFlagLong, "/etc/ssl/client.pem",
+				"--" + config.ClientKeyFlagLong, "/etc/ssl/client.key",
+				"--" + config.MinTLSVersionFlagLong, config.TLSVersion13,
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid proxy URL scheme",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.ProxyURLFlagLong, "ftp://proxy.example.com",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid client cert flag without client key flag",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.ClientCertFlagLong, "/etc/ssl/client.pem",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid minimum TLS version '1.4'",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.MinTLSVersionFlagLong, "1.4",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Valid stale warning and critical flags",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.StaleWarningFlagLong, "6h",
+				"--" + config.StaleCriticalFlagLong, "24h",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid stale critical flag less than stale warning flag",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.StaleWarningFlagLong, "24h",
+				"--" + config.StaleCriticalFlagLong, "6h",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Valid match mode flag 'glob'",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, "API*",
+				"--" + config.MatchModeFlagLong, config.MatchModeGlob,
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid match mode flag 'fuzzy'",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.MatchModeFlagLong, "fuzzy",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Valid status map flag",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.StatusMapFlagLong, "under_maintenance=OK,partial_outage=critical",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Valid repeated status map flags",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.StatusMapFlagLong, "under_maintenance=OK",
+				"--" + config.StatusMapFlagLong, "major_outage=WARNING",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid status map flag missing state",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.StatusMapFlagLong, "under_maintenance",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid status map flag unsupported status",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.StatusMapFlagLong, "operational=CRITICAL",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid status map flag unsupported state",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.StatusMapFlagLong, "under_maintenance=IGNORE",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid status map flag with same status mapped twice",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultComponentFlag, defaultComponentFlagValue,
+				"--" + config.StatusMapFlagLong, "under_maintenance=OK,under_maintenance=CRITICAL",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Valid repeated component severity flags",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.ComponentSeverityFlagLong, "Canvas:partial_outage=CRITICAL,max=CRITICAL",
+				"--" + config.ComponentSeverityFlagLong, "MasteryConnect:max=WARNING",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid component severity flag missing rules",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.ComponentSeverityFlagLong, "Canvas",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid component severity flag missing target",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.ComponentSeverityFlagLong, ":max=WARNING",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid component severity flag unsupported max state",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.ComponentSeverityFlagLong, "Canvas:max=IGNORE",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid component severity flag with same target specified twice",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.ComponentSeverityFlagLong, "Canvas:max=WARNING",
+				"--" + config.ComponentSeverityFlagLong, "canvas:major_outage=UNKNOWN",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Valid warning and critical threshold flags",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.WarningThresholdFlagLong, "3",
+				"--" + config.CriticalThresholdFlagLong, "10",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Valid percentage threshold flags",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.WarningThresholdFlagLong, "5%",
+				"--" + config.CriticalThresholdFlagLong, "~:20%",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Valid inverted critical threshold flag only",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.CriticalThresholdFlagLong, "@0:2",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid warning threshold flag not a range",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.WarningThresholdFlagLong, "five",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid critical threshold flag with start greater than end",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.CriticalThresholdFlagLong, "20:10%",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Valid group rollup flag with parent mode",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.GroupRollupFlagLong, "parent",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Valid group rollup flag with mixed case worst-of mode",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.GroupRollupFlagLong, "Worst-Of",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Valid group rollup flag with quorum mode and group quorum flag",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.GroupRollupFlagLong, "quorum",
+				"--" + config.GroupQuorumFlagLong, "75",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid group rollup flag with unsupported mode",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.GroupRollupFlagLong, "best-of",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid group quorum flag below range",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.GroupRollupFlagLong, "quorum",
+				"--" + config.GroupQuorumFlagLong, "0",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid group quorum flag above range",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.GroupRollupFlagLong, "quorum",
+				"--" + config.GroupQuorumFlagLong, "101",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Valid min problem duration flag",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.MinProblemDurationFlagLong, "15m",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid min problem duration flag with negative duration",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.MinProblemDurationFlagLong, "-5m",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid min problem duration flag without unit",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.MinProblemDurationFlagLong, "15",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Valid exclude group flag with eval all flag",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.ExcludeGroupsFlagLong, "Regions",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Valid exclude component flag with group flag",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				defaultGroupFlag, defaultGroupFlagValue,
+				"--" + config.ExcludeComponentsFlagLong, "Canvas Studio,Canvas Data",
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid exclude component flag without eval all, component or group flags",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.ExcludeComponentsFlagLong, "Canvas Studio",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Invalid empty exclude group flag value",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultURLFlag, defaultURLFlagValue,
+				"--" + config.EvalAllComponentsFlagLong,
+				"--" + config.ExcludeGroupsFlagLong, "Regions,",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Valid filename flag for standard input",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultFilenameFlag, "-",
+				defaultComponentFlag, defaultComponentFlagValue,
+			},
+			errorExpected: false,
+		},
+		{
+			name: "Invalid filename flag for standard input specified for multiple feeds",
+			flagsAndValuesInOrder: []string{
+				config.PluginComponentsAppName,
+				defaultFilenameFlag, "-",
+				defaultComponentFlag, defaultComponentFlagValue,
+				defaultFilenameFlag, "-",
+				defaultComponentFlag, defaultComponentFlagValue,
+			},
+			errorExpected: true,
+		},
 		{
 			name: "Unsupported output format flag, specify component",
 			flagsAndValuesInOrder: []string{
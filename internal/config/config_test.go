@@ -12,11 +12,17 @@ package config
 
 import (
 	"flag"
+	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/atc0005/check-statuspage/internal/statuspage"
 	"github.com/atc0005/check-statuspage/internal/textutils"
+	"github.com/atc0005/go-nagios"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 var expectedPluginComponentsFlags = []string{
@@ -26,13 +32,235 @@ var expectedPluginComponentsFlags = []string{
 	ComponentsListFlagLong,
 	ComponentGroupFlagShort,
 	ComponentGroupFlagLong,
+	ExcludeComponentsFlagShort,
+	ExcludeComponentsFlagLong,
+	ExcludeGroupsFlagShort,
+	ExcludeGroupsFlagLong,
+	MatchModeFlagShort,
+	MatchModeFlagLong,
+	StatusMapFlagShort,
+	StatusMapFlagLong,
+	ComponentSeverityFlagShort,
+	ComponentSeverityFlagLong,
+	WarningThresholdFlagShort,
+	WarningThresholdFlagLong,
+	CriticalThresholdFlagShort,
+	CriticalThresholdFlagLong,
+	GroupRollupFlagShort,
+	GroupRollupFlagLong,
+	GroupQuorumFlagShort,
+	GroupQuorumFlagLong,
+	MinProblemDurationFlagShort,
+	MinProblemDurationFlagLong,
 	EvalAllComponentsFlagShort,
 	EvalAllComponentsFlagLong,
+	ConcurrencyFlagShort,
+	ConcurrencyFlagLong,
+	StaleWarningFlagShort,
+	StaleWarningFlagLong,
+	StaleCriticalFlagShort,
+	StaleCriticalFlagLong,
+	CacheDirFlagShort,
+	CacheDirFlagLong,
+	RetriesFlagShort,
+	RetriesFlagLong,
+	RetryDelayFlagShort,
+	RetryDelayFlagLong,
+	HeaderFlagShort,
+	HeaderFlagLong,
+	BasicAuthUserFlagShort,
+	BasicAuthUserFlagLong,
+	BasicAuthPasswordFileFlagShort,
+	BasicAuthPasswordFileFlagLong,
+	BasicAuthPasswordEnvFlagShort,
+	BasicAuthPasswordEnvFlagLong,
+	BearerTokenFileFlagShort,
+	BearerTokenFileFlagLong,
+	BearerTokenEnvFlagShort,
+	BearerTokenEnvFlagLong,
+	ProxyURLFlagShort,
+	ProxyURLFlagLong,
+	CABundleFlagShort,
+	CABundleFlagLong,
+	ClientCertFlagShort,
+	ClientCertFlagLong,
+	ClientKeyFlagShort,
+	ClientKeyFlagLong,
+	MinTLSVersionFlagShort,
+	MinTLSVersionFlagLong,
+	InsecureSkipVerifyFlagShort,
+	InsecureSkipVerifyFlagLong,
+	MaxStaleFlagShort,
+	MaxStaleFlagLong,
+	FallbackStateFlagShort,
+	FallbackStateFlagLong,
+}
+
+var expectedPluginIncidentsFlags = []string{
+	BrandingFlag,
+	VerboseFlag,
+	ComponentsListFlagShort,
+	ComponentsListFlagLong,
+	ComponentGroupFlagShort,
+	ComponentGroupFlagLong,
+	MatchModeFlagShort,
+	MatchModeFlagLong,
+	CacheDirFlagShort,
+	CacheDirFlagLong,
+	RetriesFlagShort,
+	RetriesFlagLong,
+	RetryDelayFlagShort,
+	RetryDelayFlagLong,
+	HeaderFlagShort,
+	HeaderFlagLong,
+	BasicAuthUserFlagShort,
+	BasicAuthUserFlagLong,
+	BasicAuthPasswordFileFlagShort,
+	BasicAuthPasswordFileFlagLong,
+	BasicAuthPasswordEnvFlagShort,
+	BasicAuthPasswordEnvFlagLong,
+	BearerTokenFileFlagShort,
+	BearerTokenFileFlagLong,
+	BearerTokenEnvFlagShort,
+	BearerTokenEnvFlagLong,
+	ProxyURLFlagShort,
+	ProxyURLFlagLong,
+	CABundleFlagShort,
+	CABundleFlagLong,
+	ClientCertFlagShort,
+	ClientCertFlagLong,
+	ClientKeyFlagShort,
+	ClientKeyFlagLong,
+	MinTLSVersionFlagShort,
+	MinTLSVersionFlagLong,
+	InsecureSkipVerifyFlagShort,
+	InsecureSkipVerifyFlagLong,
+	MaxStaleFlagShort,
+	MaxStaleFlagLong,
+	FallbackStateFlagShort,
+	FallbackStateFlagLong,
+}
+
+var expectedPluginMaintenanceFlags = []string{
+	BrandingFlag,
+	VerboseFlag,
+	ComponentsListFlagShort,
+	ComponentsListFlagLong,
+	ComponentGroupFlagShort,
+	ComponentGroupFlagLong,
+	MatchModeFlagShort,
+	MatchModeFlagLong,
+	MaintenanceWithinFlag,
+	CacheDirFlagShort,
+	CacheDirFlagLong,
+	RetriesFlagShort,
+	RetriesFlagLong,
+	RetryDelayFlagShort,
+	RetryDelayFlagLong,
+	HeaderFlagShort,
+	HeaderFlagLong,
+	BasicAuthUserFlagShort,
+	BasicAuthUserFlagLong,
+	BasicAuthPasswordFileFlagShort,
+	BasicAuthPasswordFileFlagLong,
+	BasicAuthPasswordEnvFlagShort,
+	BasicAuthPasswordEnvFlagLong,
+	BearerTokenFileFlagShort,
+	BearerTokenFileFlagLong,
+	BearerTokenEnvFlagShort,
+	BearerTokenEnvFlagLong,
+	ProxyURLFlagShort,
+	ProxyURLFlagLong,
+	CABundleFlagShort,
+	CABundleFlagLong,
+	ClientCertFlagShort,
+	ClientCertFlagLong,
+	ClientKeyFlagShort,
+	ClientKeyFlagLong,
+	MinTLSVersionFlagShort,
+	MinTLSVersionFlagLong,
+	InsecureSkipVerifyFlagShort,
+	InsecureSkipVerifyFlagLong,
+	MaxStaleFlagShort,
+	MaxStaleFlagLong,
+	FallbackStateFlagShort,
+	FallbackStateFlagLong,
+}
+
+var expectedPluginStatusFlags = []string{
+	BrandingFlag,
+	VerboseFlag,
+	CacheDirFlagShort,
+	CacheDirFlagLong,
+	RetriesFlagShort,
+	RetriesFlagLong,
+	RetryDelayFlagShort,
+	RetryDelayFlagLong,
+	HeaderFlagShort,
+	HeaderFlagLong,
+	BasicAuthUserFlagShort,
+	BasicAuthUserFlagLong,
+	BasicAuthPasswordFileFlagShort,
+	BasicAuthPasswordFileFlagLong,
+	BasicAuthPasswordEnvFlagShort,
+	BasicAuthPasswordEnvFlagLong,
+	BearerTokenFileFlagShort,
+	BearerTokenFileFlagLong,
+	BearerTokenEnvFlagShort,
+	BearerTokenEnvFlagLong,
+	ProxyURLFlagShort,
+	ProxyURLFlagLong,
+	CABundleFlagShort,
+	CABundleFlagLong,
+	ClientCertFlagShort,
+	ClientCertFlagLong,
+	ClientKeyFlagShort,
+	ClientKeyFlagLong,
+	MinTLSVersionFlagShort,
+	MinTLSVersionFlagLong,
+	InsecureSkipVerifyFlagShort,
+	InsecureSkipVerifyFlagLong,
+	MaxStaleFlagShort,
+	MaxStaleFlagLong,
+	FallbackStateFlagShort,
+	FallbackStateFlagLong,
 }
 
 var expectedInspectorComponentsFlags = []string{
 	InspectorOutputFormatFlagShort,
 	InspectorOutputFormatFlagLong,
+	ListProvidersFlagShort,
+	ListProvidersFlagLong,
+	CacheDirFlagShort,
+	CacheDirFlagLong,
+	RetriesFlagShort,
+	RetriesFlagLong,
+	RetryDelayFlagShort,
+	RetryDelayFlagLong,
+	HeaderFlagShort,
+	HeaderFlagLong,
+	BasicAuthUserFlagShort,
+	BasicAuthUserFlagLong,
+	BasicAuthPasswordFileFlagShort,
+	BasicAuthPasswordFileFlagLong,
+	BasicAuthPasswordEnvFlagShort,
+	BasicAuthPasswordEnvFlagLong,
+	BearerTokenFileFlagShort,
+	BearerTokenFileFlagLong,
+	BearerTokenEnvFlagShort,
+	BearerTokenEnvFlagLong,
+	ProxyURLFlagShort,
+	ProxyURLFlagLong,
+	CABundleFlagShort,
+	CABundleFlagLong,
+	ClientCertFlagShort,
+	ClientCertFlagLong,
+	ClientKeyFlagShort,
+	ClientKeyFlagLong,
+	MinTLSVersionFlagShort,
+	MinTLSVersionFlagLong,
+	InsecureSkipVerifyFlagShort,
+	InsecureSkipVerifyFlagLong,
 }
 
 var expectedSharedFlags = []string{
@@ -44,6 +272,8 @@ var expectedSharedFlags = []string{
 	OmitSummaryResultsFlagLong,
 	URLFlagShort,
 	URLFlagLong,
+	ProviderFlagShort,
+	ProviderFlagLong,
 	FilenameFlagShort,
 	FilenameFlagLong,
 	AllowUnknownJSONFieldsFlagShort,
@@ -149,10 +379,11 @@ func TestExpectedPluginComponentsFlags(t *testing.T) {
 
 }
 
-// TestHelpFlag asserts that specifying help flags is both successful and
-// output contains all expected flags for the application type (e.g.,
-// components plugin vs components cli app).
-func TestHelpFlag(t *testing.T) {
+// TestExpectedPluginIncidentsFlags tests defined config flags for the
+// incidents plugin against a list of expected flags for the plugin. This is
+// done to help prevent documentation from getting out of date with config
+// flag changes.
+func TestExpectedPluginIncidentsFlags(t *testing.T) {
 
 	// https://stackoverflow.com/questions/33723300/how-to-test-the-passing-of-arguments-in-golang
 
@@ -162,137 +393,88 @@ func TestHelpFlag(t *testing.T) {
 	// Defer restoring original command-line arguments
 	defer func() { os.Args = oldArgs }()
 
-	tests := []struct {
-		name    string
-		appName string
-		appType AppType
-		flag    string
-	}{
-		{
-			name:    "Components plugin, short help flag",
-			appName: PluginComponentsAppName,
-			appType: AppType{PluginComponents: true},
-			flag:    HelpFlagShort,
-		},
-		{
-			name:    "Components plugin, long help flag",
-			appName: PluginComponentsAppName,
-			appType: AppType{PluginComponents: true},
-			flag:    HelpFlagLong,
-		},
-		{
-			name:    "Components CLI app, short help flag",
-			appName: InspectorComponentsAppName,
-			appType: AppType{InspectorComponents: true},
-			flag:    HelpFlagShort,
-		},
-		{
-			name:    "Components CLI app, long help flag",
-			appName: InspectorComponentsAppName,
-			appType: AppType{InspectorComponents: true},
-			flag:    HelpFlagLong,
-		},
+	// Note to self: Don't add/escape double-quotes here. The shell strips
+	// them away and the application never sees them.
+	os.Args = []string{
+		PluginIncidentsAppName,
+		"--" + FilenameFlagLong, "placeholder",
+		"--" + LogLevelFlagLong, "placeholder",
+		"--" + ComponentGroupFlagLong, "placeholder",
+		"--" + ComponentsListFlagLong, "placeholder",
 	}
 
-	for _, test := range tests {
-		t.Run(test.name, func(t *testing.T) {
-
-			// Save old command-line arguments so that we can restore them later
-			// https://stackoverflow.com/questions/33723300/how-to-test-the-passing-of-arguments-in-golang
-			oldArgs := os.Args
-
-			// Defer restoring original command-line arguments
-			defer func() { os.Args = oldArgs }()
-
-			// Clear out any entries added by `go test` or leftovers from
-			// previous test cases.
-			os.Args = nil
-
-			// Note to self: Don't add/escape double-quotes here. The shell strips
-			// them away and the application never sees them.
-			flagsAndValuesInOrder := []string{
-				test.appName, test.flag,
-			}
-
-			for i, item := range flagsAndValuesInOrder {
-				if strings.TrimSpace(item) != "" {
-					os.Args = append(os.Args, item)
-				} else {
-					t.Logf("Skipping item %d due to empty value", i)
-				}
-			}
-
-			t.Log("INFO: Old os.Args before rewriting:\n", oldArgs)
-			t.Log("INFO: New os.Args before init config:\n", os.Args)
-
-			var config Config
-			config.App = AppInfo{
-				Name:    myAppName,
-				Version: version,
-				URL:     myAppURL,
-				Plugin:  appTypeLabel(test.appType),
-			}
-
-			config.flagSet = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
-
-			if err := config.handleFlagsConfig(test.appType); err != nil {
-				t.Fatalf(
-					"ERROR: Failed to set flags configuration: %v",
-					err,
-				)
-			}
+	var config Config
+	appType := AppType{PluginIncidents: true}
+	config.App = AppInfo{
+		Name:    myAppName,
+		Version: version,
+		URL:     myAppURL,
+		Plugin:  appTypeLabel(appType),
+	}
 
-			helpOutput := config.Help()
+	config.flagSet = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
 
-			// t.Logf("helpOutput: %s", helpOutput)
+	if err := config.handleFlagsConfig(appType); err != nil {
+		t.Fatalf(
+			"ERROR: Failed to set flags configuration: %v",
+			err,
+		)
+	}
 
-			// Quick fail: look for known failure text in output.
-			if strings.Contains(helpOutput, ErrConfigNotInitialized.Error()) {
-				t.Fatalf(
-					"ERROR: Help text indicates uninitialized configuration: %s",
-					ErrConfigNotInitialized,
-				)
-			}
+	totalExpectedFlagsCount := len(expectedSharedFlags) + len(expectedPluginIncidentsFlags)
 
-			// combine the shared and dedicated flag lists
-			expectedFlags := make([]string, 0, len(expectedSharedFlags)+len(expectedPluginComponentsFlags))
-			switch {
-			case test.appType.InspectorComponents:
-				expectedFlags = append(expectedFlags, expectedSharedFlags...)
-				expectedFlags = append(expectedFlags, expectedInspectorComponentsFlags...)
-			case test.appType.PluginComponents:
-				expectedFlags = append(expectedFlags, expectedSharedFlags...)
-				expectedFlags = append(expectedFlags, expectedPluginComponentsFlags...)
+	definedFlags := make([]string, 0, totalExpectedFlagsCount)
+	config.flagSet.VisitAll(func(f *flag.Flag) {
+		definedFlags = append(definedFlags, f.Name)
+	})
+	definedFlagsCount := len(definedFlags)
 
-			}
+	if totalExpectedFlagsCount != len(definedFlags) {
+		t.Errorf(
+			"ERROR: Expected %d defined flags for %s; got %d defined flags",
+			totalExpectedFlagsCount,
+			PluginIncidentsAppName,
+			definedFlagsCount,
+		)
+	} else {
+		t.Logf(
+			"OK: Num Flags expected (%d) matches num flags defined (%d)",
+			totalExpectedFlagsCount,
+			definedFlagsCount,
+		)
+	}
 
-			// Ensure that we get useful help output by confirming that each
-			// defined flag is represented in the generated output.
-			for _, expectedFlag := range expectedFlags {
-				switch {
-				case !strings.Contains(helpOutput, expectedFlag):
-					t.Fatalf(
-						"ERROR: Help text is missing usage information for flag %q",
-						expectedFlag,
-					)
+	// combine the shared and dedicated flag lists
+	expectedFlags := make([]string, 0, totalExpectedFlagsCount)
+	expectedFlags = append(expectedFlags, expectedSharedFlags...)
+	expectedFlags = append(expectedFlags, expectedPluginIncidentsFlags...)
 
-				default:
-					t.Logf(
-						"OK: Help text usage information for flag %q found",
-						expectedFlag,
-					)
-				}
-			}
-		})
+	// Assert that each defined flag is represented exactly by an entry in the
+	// list of expected flags. Since we have already compared the length of
+	// each collection (defined vs expected), we don't have to compare in the
+	// opposite direction to assert that each collection is equal.
+	for _, definedFlag := range definedFlags {
+		if !textutils.InList(definedFlag, expectedFlags, false) {
+			t.Errorf(
+				"ERROR: defined flag %q is not in the list of expected flags",
+				definedFlag,
+			)
+		} else {
+			t.Logf(
+				"OK: defined flag %q is in the list of expected flags",
+				definedFlag,
+			)
+		}
 	}
+	t.Log("OK: Defined flags match expected flags")
 
 }
 
-// TestExpectedInspectorComponentsFlags tests defined config flags for the
-// components inspector app against a list of expected flags for the CLI app.
-// This is done to help prevent documentation from getting out of date with
-// config flag changes.
-func TestExpectedInspectorComponentsFlags(t *testing.T) {
+// TestExpectedPluginMaintenanceFlags tests defined config flags for the
+// maintenance plugin against a list of expected flags for the plugin. This is
+// done to help prevent documentation from getting out of date with config
+// flag changes.
+func TestExpectedPluginMaintenanceFlags(t *testing.T) {
 
 	// https://stackoverflow.com/questions/33723300/how-to-test-the-passing-of-arguments-in-golang
 
@@ -305,13 +487,15 @@ func TestExpectedInspectorComponentsFlags(t *testing.T) {
 	// Note to self: Don't add/escape double-quotes here. The shell strips
 	// them away and the application never sees them.
 	os.Args = []string{
-		InspectorComponentsAppName,
+		PluginMaintenanceAppName,
 		"--" + FilenameFlagLong, "placeholder",
 		"--" + LogLevelFlagLong, "placeholder",
+		"--" + ComponentGroupFlagLong, "placeholder",
+		"--" + ComponentsListFlagLong, "placeholder",
 	}
 
 	var config Config
-	appType := AppType{InspectorComponents: true}
+	appType := AppType{PluginMaintenance: true}
 	config.App = AppInfo{
 		Name:    myAppName,
 		Version: version,
@@ -328,7 +512,7 @@ func TestExpectedInspectorComponentsFlags(t *testing.T) {
 		)
 	}
 
-	totalExpectedFlagsCount := len(expectedSharedFlags) + len(expectedInspectorComponentsFlags)
+	totalExpectedFlagsCount := len(expectedSharedFlags) + len(expectedPluginMaintenanceFlags)
 
 	definedFlags := make([]string, 0, totalExpectedFlagsCount)
 	config.flagSet.VisitAll(func(f *flag.Flag) {
@@ -340,7 +524,7 @@ func TestExpectedInspectorComponentsFlags(t *testing.T) {
 		t.Errorf(
 			"ERROR: Expected %d defined flags for %s; got %d defined flags",
 			totalExpectedFlagsCount,
-			InspectorComponentsAppName,
+			PluginMaintenanceAppName,
 			definedFlagsCount,
 		)
 	} else {
@@ -354,7 +538,7 @@ func TestExpectedInspectorComponentsFlags(t *testing.T) {
 	// combine the shared and dedicated flag lists
 	expectedFlags := make([]string, 0, totalExpectedFlagsCount)
 	expectedFlags = append(expectedFlags, expectedSharedFlags...)
-	expectedFlags = append(expectedFlags, expectedInspectorComponentsFlags...)
+	expectedFlags = append(expectedFlags, expectedPluginMaintenanceFlags...)
 
 	// Assert that each defined flag is represented exactly by an entry in the
 	// list of expected flags. Since we have already compared the length of
@@ -376,3 +560,984 @@ func TestExpectedInspectorComponentsFlags(t *testing.T) {
 	t.Log("OK: Defined flags match expected flags")
 
 }
+
+// TestExpectedPluginStatusFlags tests defined config flags for the
+// status plugin against a list of expected flags for the plugin. This is
+// done to help prevent documentation from getting out of date with config
+// flag changes.
+func TestExpectedPluginStatusFlags(t *testing.T) {
+
+	// https://stackoverflow.com/questions/33723300/how-to-test-the-passing-of-arguments-in-golang
+
+	// Save old command-line arguments so that we can restore them later
+	oldArgs := os.Args
+
+	// Defer restoring original command-line arguments
+	defer func() { os.Args = oldArgs }()
+
+	// Note to self: Don't add/escape double-quotes here. The shell strips
+	// them away and the application never sees them.
+	os.Args = []string{
+		PluginStatusAppName,
+		"--" + FilenameFlagLong, "placeholder",
+		"--" + LogLevelFlagLong, "placeholder",
+	}
+
+	var config Config
+	appType := AppType{PluginStatus: true}
+	config.App = AppInfo{
+		Name:    myAppName,
+		Version: version,
+		URL:     myAppURL,
+		Plugin:  appTypeLabel(appType),
+	}
+
+	config.flagSet = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	if err := config.handleFlagsConfig(appType); err != nil {
+		t.Fatalf(
+			"ERROR: Failed to set flags configuration: %v",
+			err,
+		)
+	}
+
+	totalExpectedFlagsCount := len(expectedSharedFlags) + len(expectedPluginStatusFlags)
+
+	definedFlags := make([]string, 0, totalExpectedFlagsCount)
+	config.flagSet.VisitAll(func(f *flag.Flag) {
+		definedFlags = append(definedFlags, f.Name)
+	})
+	definedFlagsCount := len(definedFlags)
+
+	if totalExpectedFlagsCount != len(definedFlags) {
+		t.Errorf(
+			"ERROR: Expected %d defined flags for %s; got %d defined flags",
+			totalExpectedFlagsCount,
+			PluginStatusAppName,
+			definedFlagsCount,
+		)
+	} else {
+		t.Logf(
+			"OK: Num Flags expected (%d) matches num flags defined (%d)",
+			totalExpectedFlagsCount,
+			definedFlagsCount,
+		)
+	}
+
+	// combine the shared and dedicated flag lists
+	expectedFlags := make([]string, 0, totalExpectedFlagsCount)
+	expectedFlags = append(expectedFlags, expectedSharedFlags...)
+	expectedFlags = append(expectedFlags, expectedPluginStatusFlags...)
+
+	// Assert that each defined flag is represented exactly by an entry in the
+	// list of expected flags. Since we have already compared the length of
+	// each collection (defined vs expected), we don't have to compare in the
+	// opposite direction to assert that each collection is equal.
+	for _, definedFlag := range definedFlags {
+		if !textutils.InList(definedFlag, expectedFlags, false) {
+			t.Errorf(
+				"ERROR: defined flag %q is not in the list of expected flags",
+				definedFlag,
+			)
+		} else {
+			t.Logf(
+				"OK: defined flag %q is in the list of expected flags",
+				definedFlag,
+			)
+		}
+	}
+	t.Log("OK: Defined flags match expected flags")
+
+}
+
+// TestHelpFlag asserts that specifying help flags is both successful and
+// output contains all expected flags for the application type (e.g.,
+// components plugin vs components cli app).
+func TestHelpFlag(t *testing.T) {
+
+	// https://stackoverflow.com/questions/33723300/how-to-test-the-passing-of-arguments-in-golang
+
+	// Save old command-line arguments so that we can restore them later
+	oldArgs := os.Args
+
+	// Defer restoring original command-line arguments
+	defer func() { os.Args = oldArgs }()
+
+	tests := []struct {
+		name    string
+		appName string
+		appType AppType
+		flag    string
+	}{
+		{
+			name:    "Components plugin, short help flag",
+			appName: PluginComponentsAppName,
+			appType: AppType{PluginComponents: true},
+			flag:    HelpFlagShort,
+		},
+		{
+			name:    "Components plugin, long help flag",
+			appName: PluginComponentsAppName,
+			appType: AppType{PluginComponents: true},
+			flag:    HelpFlagLong,
+		},
+		{
+			name:    "Incidents plugin, short help flag",
+			appName: PluginIncidentsAppName,
+			appType: AppType{PluginIncidents: true},
+			flag:    HelpFlagShort,
+		},
+		{
+			name:    "Incidents plugin, long help flag",
+			appName: PluginIncidentsAppName,
+			appType: AppType{PluginIncidents: true},
+			flag:    HelpFlagLong,
+		},
+		{
+			name:    "Maintenance plugin, short help flag",
+			appName: PluginMaintenanceAppName,
+			appType: AppType{PluginMaintenance: true},
+			flag:    HelpFlagShort,
+		},
+		{
+			name:    "Maintenance plugin, long help flag",
+			appName: PluginMaintenanceAppName,
+			appType: AppType{PluginMaintenance: true},
+			flag:    HelpFlagLong,
+		},
+		{
+			name:    "Status plugin, short help flag",
+			appName: PluginStatusAppName,
+			appType: AppType{PluginStatus: true},
+			flag:    HelpFlagShort,
+		},
+		{
+			name:    "Status plugin, long help flag",
+			appName: PluginStatusAppName,
+			appType: AppType{PluginStatus: true},
+			flag:    HelpFlagLong,
+		},
+		{
+			name:    "Components CLI app, short help flag",
+			appName: InspectorComponentsAppName,
+			appType: AppType{InspectorComponents: true},
+			flag:    HelpFlagShort,
+		},
+		{
+			name:    "Components CLI app, long help flag",
+			appName: InspectorComponentsAppName,
+			appType: AppType{InspectorComponents: true},
+			flag:    HelpFlagLong,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Save old command-line arguments so that we can restore them later
+			// https://stackoverflow.com/questions/33723300/how-to-test-the-passing-of-arguments-in-golang
+			oldArgs := os.Args
+
+			// Defer restoring original command-line arguments
+			defer func() { os.Args = oldArgs }()
+
+			// Clear out any entries added by `go test` or leftovers from
+			// previous test cases.
+			os.Args = nil
+
+			// Note to self: Don't add/escape double-quotes here. The shell strips
+			// them away and the application never sees them.
+			flagsAndValuesInOrder := []string{
+				test.appName, test.flag,
+			}
+
+			for i, item := range flagsAndValuesInOrder {
+				if strings.TrimSpace(item) != "" {
+					os.Args = append(os.Args, item)
+				} else {
+					t.Logf("Skipping item %d due to empty value", i)
+				}
+			}
+
+			t.Log("INFO: Old os.Args before rewriting:\n", oldArgs)
+			t.Log("INFO: New os.Args before init config:\n", os.Args)
+
+			var config Config
+			config.App = AppInfo{
+				Name:    myAppName,
+				Version: version,
+				URL:     myAppURL,
+				Plugin:  appTypeLabel(test.appType),
+			}
+
+			config.flagSet = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+			if err := config.handleFlagsConfig(test.appType); err != nil {
+				t.Fatalf(
+					"ERROR: Failed to set flags configuration: %v",
+					err,
+				)
+			}
+
+			helpOutput := config.Help()
+
+			// t.Logf("helpOutput: %s", helpOutput)
+
+			// Quick fail: look for known failure text in output.
+			if strings.Contains(helpOutput, ErrConfigNotInitialized.Error()) {
+				t.Fatalf(
+					"ERROR: Help text indicates uninitialized configuration: %s",
+					ErrConfigNotInitialized,
+				)
+			}
+
+			// combine the shared and dedicated flag lists
+			expectedFlags := make([]string, 0, len(expectedSharedFlags)+len(expectedPluginComponentsFlags))
+			switch {
+			case test.appType.InspectorComponents:
+				expectedFlags = append(expectedFlags, expectedSharedFlags...)
+				expectedFlags = append(expectedFlags, expectedInspectorComponentsFlags...)
+			case test.appType.PluginComponents:
+				expectedFlags = append(expectedFlags, expectedSharedFlags...)
+				expectedFlags = append(expectedFlags, expectedPluginComponentsFlags...)
+			case test.appType.PluginIncidents:
+				expectedFlags = append(expectedFlags, expectedSharedFlags...)
+				expectedFlags = append(expectedFlags, expectedPluginIncidentsFlags...)
+			case test.appType.PluginMaintenance:
+				expectedFlags = append(expectedFlags, expectedSharedFlags...)
+				expectedFlags = append(expectedFlags, expectedPluginMaintenanceFlags...)
+			case test.appType.PluginStatus:
+				expectedFlags = append(expectedFlags, expectedSharedFlags...)
+				expectedFlags = append(expectedFlags, expectedPluginStatusFlags...)
+
+			}
+
+			// Ensure that we get useful help output by confirming that each
+			// defined flag is represented in the generated output.
+			for _, expectedFlag := range expectedFlags {
+				switch {
+				case !strings.Contains(helpOutput, expectedFlag):
+					t.Fatalf(
+						"ERROR: Help text is missing usage information for flag %q",
+						expectedFlag,
+					)
+
+				default:
+					t.Logf(
+						"OK: Help text usage information for flag %q found",
+						expectedFlag,
+					)
+				}
+			}
+		})
+	}
+
+}
+
+// TestExpectedInspectorComponentsFlags tests defined config flags for the
+// components inspector app against a list of expected flags for the CLI app.
+// This is done to help prevent documentation from getting out of date with
+// config flag changes.
+func TestExpectedInspectorComponentsFlags(t *testing.T) {
+
+	// https://stackoverflow.com/questions/33723300/how-to-test-the-passing-of-arguments-in-golang
+
+	// Save old command-line arguments so that we can restore them later
+	oldArgs := os.Args
+
+	// Defer restoring original command-line arguments
+	defer func() { os.Args = oldArgs }()
+
+	// Note to self: Don't add/escape double-quotes here. The shell strips
+	// them away and the application never sees them.
+	os.Args = []string{
+		InspectorComponentsAppName,
+		"--" + FilenameFlagLong, "placeholder",
+		"--" + LogLevelFlagLong, "placeholder",
+	}
+
+	var config Config
+	appType := AppType{InspectorComponents: true}
+	config.App = AppInfo{
+		Name:    myAppName,
+		Version: version,
+		URL:     myAppURL,
+		Plugin:  appTypeLabel(appType),
+	}
+
+	config.flagSet = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+	if err := config.handleFlagsConfig(appType); err != nil {
+		t.Fatalf(
+			"ERROR: Failed to set flags configuration: %v",
+			err,
+		)
+	}
+
+	totalExpectedFlagsCount := len(expectedSharedFlags) + len(expectedInspectorComponentsFlags)
+
+	definedFlags := make([]string, 0, totalExpectedFlagsCount)
+	config.flagSet.VisitAll(func(f *flag.Flag) {
+		definedFlags = append(definedFlags, f.Name)
+	})
+	definedFlagsCount := len(definedFlags)
+
+	if totalExpectedFlagsCount != len(definedFlags) {
+		t.Errorf(
+			"ERROR: Expected %d defined flags for %s; got %d defined flags",
+			totalExpectedFlagsCount,
+			InspectorComponentsAppName,
+			definedFlagsCount,
+		)
+	} else {
+		t.Logf(
+			"OK: Num Flags expected (%d) matches num flags defined (%d)",
+			totalExpectedFlagsCount,
+			definedFlagsCount,
+		)
+	}
+
+	// combine the shared and dedicated flag lists
+	expectedFlags := make([]string, 0, totalExpectedFlagsCount)
+	expectedFlags = append(expectedFlags, expectedSharedFlags...)
+	expectedFlags = append(expectedFlags, expectedInspectorComponentsFlags...)
+
+	// Assert that each defined flag is represented exactly by an entry in the
+	// list of expected flags. Since we have already compared the length of
+	// each collection (defined vs expected), we don't have to compare in the
+	// opposite direction to assert that each collection is equal.
+	for _, definedFlag := range definedFlags {
+		if !textutils.InList(definedFlag, expectedFlags, false) {
+			t.Errorf(
+				"ERROR: defined flag %q is not in the list of expected flags",
+				definedFlag,
+			)
+		} else {
+			t.Logf(
+				"OK: defined flag %q is in the list of expected flags",
+				definedFlag,
+			)
+		}
+	}
+	t.Log("OK: Defined flags match expected flags")
+
+}
+
+// TestNormalizeFeedURL asserts that base status page URLs, hostnames and
+// page ID values are expanded to the expected API/JSON feed URL, that
+// fully-qualified feed URLs are returned as-is and that feed URLs for a
+// different endpoint are rejected.
+func TestNormalizeFeedURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		value         string
+		endpoint      string
+		want          string
+		errorExpected bool
+	}{
+		{
+			name:     "Fully-qualified components feed URL",
+			value:    "https://www.githubstatus.com/api/v2/components.json",
+			endpoint: apiEndpointComponents,
+			want:     "https://www.githubstatus.com/api/v2/components.json",
+		},
+		{
+			name:     "Fully-qualified summary feed URL",
+			value:    "https://www.githubstatus.com/api/v2/summary.json",
+			endpoint: apiEndpointIncidentsUnresolved,
+			want:     "https://www.githubstatus.com/api/v2/summary.json",
+		},
+		{
+			name:     "Fully-qualified active scheduled maintenances feed URL",
+			value:    "https://status.instructure.com/api/v2/scheduled-maintenances/active.json",
+			endpoint: apiEndpointMaintenancesUpcoming,
+			want:     "https://status.instructure.com/api/v2/scheduled-maintenances/active.json",
+		},
+		{
+			name:     "Fully-qualified feed URL with path prefix",
+			value:    "https://mirror.example.com/github/api/v2/status.json",
+			endpoint: apiEndpointStatus,
+			want:     "https://mirror.example.com/github/api/v2/status.json",
+		},
+		{
+			name:     "Non-API path URL",
+			value:    "https://mirror.example.com/github/status",
+			endpoint: apiEndpointStatus,
+			want:     "https://mirror.example.com/github/status",
+		},
+		{
+			name:          "Summary feed URL for components endpoint",
+			value:         "https://www.githubstatus.com/api/v2/summary.json",
+			endpoint:      apiEndpointComponents,
+			errorExpected: true,
+		},
+		{
+			name:     "Summary feed URL for status endpoint",
+			value:    "https://www.githubstatus.com/api/v2/summary.json",
+			endpoint: apiEndpointStatus,
+			want:     "https://www.githubstatus.com/api/v2/summary.json",
+		},
+		{
+			name:          "Components feed URL for incidents endpoint",
+			value:         "https://x.statuspage.io/api/v2/components.json",
+			endpoint:      apiEndpointIncidentsUnresolved,
+			errorExpected: true,
+		},
+		{
+			name:          "Components feed URL for status endpoint",
+			value:         "https://x.statuspage.io/api/v2/components.json",
+			endpoint:      apiEndpointStatus,
+			errorExpected: true,
+		},
+		{
+			name:          "Components feed URL for maintenance endpoint",
+			value:         "https://x.statuspage.io/api/v2/components.json",
+			endpoint:      apiEndpointMaintenancesUpcoming,
+			errorExpected: true,
+		},
+		{
+			name:          "Status feed URL for components endpoint",
+			value:         "https://www.githubstatus.com/api/v2/status.json",
+			endpoint:      apiEndpointComponents,
+			errorExpected: true,
+		},
+		{
+			name:          "All incidents feed URL for incidents endpoint",
+			value:         "https://www.githubstatus.com/api/v2/incidents.json",
+			endpoint:      apiEndpointIncidentsUnresolved,
+			errorExpected: true,
+		},
+		{
+			name:          "Active scheduled maintenances feed URL for incidents endpoint",
+			value:         "https://status.instructure.com/api/v2/scheduled-maintenances/active.json",
+			endpoint:      apiEndpointIncidentsUnresolved,
+			errorExpected: true,
+		},
+		{
+			name:     "Base URL",
+			value:    "https://www.githubstatus.com",
+			endpoint: apiEndpointComponents,
+			want:     "https://www.githubstatus.com/api/v2/components.json",
+		},
+		{
+			name:     "Base URL with trailing slash",
+			value:    "https://www.githubstatus.com/",
+			endpoint: apiEndpointIncidentsUnresolved,
+			want:     "https://www.githubstatus.com/api/v2/incidents/unresolved.json",
+		},
+		{
+			name:     "API path URL",
+			value:    "https://status.instructure.com/api/v2",
+			endpoint: apiEndpointMaintenancesUpcoming,
+			want:     "https://status.instructure.com/api/v2/scheduled-maintenances/upcoming.json",
+		},
+		{
+			name:     "Hostname without scheme",
+			value:    "status.instructure.com",
+			endpoint: apiEndpointStatus,
+			want:     "https://status.instructure.com/api/v2/status.json",
+		},
+		{
+			name:     "Page ID",
+			value:    "kctbh9vrtdwd",
+			endpoint: apiEndpointComponents,
+			want:     "https://kctbh9vrtdwd.statuspage.io/api/v2/components.json",
+		},
+		{
+			name:     "Page ID with surrounding whitespace",
+			value:    " nlxv32btr6v7 ",
+			endpoint: apiEndpointStatus,
+			want:     "https://nlxv32btr6v7.statuspage.io/api/v2/status.json",
+		},
+		{
+			name:          "Whitespace only value",
+			value:         "   ",
+			endpoint:      apiEndpointComponents,
+			errorExpected: true,
+		},
+		{
+			name:          "URL without hostname",
+			value:         "https:///api/v2/components.json",
+			endpoint:      apiEndpointComponents,
+			errorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := normalizeFeedURL(test.value, test.endpoint)
+			switch {
+			case err != nil && test.errorExpected:
+				t.Logf("OK: error occurred as expected: %v", err)
+
+			case err != nil:
+				t.Fatalf("ERROR: unexpected error normalizing %q: %v", test.value, err)
+
+			case test.errorExpected:
+				t.Fatalf("ERROR: error expected normalizing %q, got %q", test.value, got)
+
+			case got != test.want:
+				t.Errorf("ERROR: want %q, got %q", test.want, got)
+
+			default:
+				t.Logf("OK: %q normalized to %q", test.value, got)
+			}
+		})
+	}
+}
+
+// TestResolveProvider asserts that a specified provider supplies the expected
+// page ID and, if neither a URL nor filename is specified, the URL.
+func TestResolveProvider(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		config   Config
+		wantURL  string
+		wantPage string
+	}{
+		{
+			name:     "Provider only",
+			config:   Config{Provider: "github"},
+			wantURL:  "https://www.githubstatus.com/api/v2/components.json",
+			wantPage: "kctbh9vrtdwd",
+		},
+		{
+			name:     "Provider with URL",
+			config:   Config{Provider: "github", URL: "https://status.box.com"},
+			wantURL:  "https://status.box.com/api/v2/components.json",
+			wantPage: "kctbh9vrtdwd",
+		},
+		{
+			name:     "Provider with filename",
+			config:   Config{Provider: "instructure", Filename: "placeholder"},
+			wantURL:  "",
+			wantPage: "nlxv32btr6v7",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := test.config
+			if err := c.resolveProvider(); err != nil {
+				t.Fatalf("ERROR: Failed to resolve provider: %v", err)
+			}
+
+			if err := c.normalizeURL(AppType{PluginComponents: true}); err != nil {
+				t.Fatalf("ERROR: Failed to normalize URL: %v", err)
+			}
+
+			switch {
+			case c.URL != test.wantURL:
+				t.Errorf("ERROR: want URL %q, got %q", test.wantURL, c.URL)
+			case c.ExpectedPageID != test.wantPage:
+				t.Errorf("ERROR: want page ID %q, got %q", test.wantPage, c.ExpectedPageID)
+			default:
+				t.Logf("OK: provider %q resolved to URL %q", c.Provider, c.URL)
+			}
+		})
+	}
+}
+
+// TestFeedsFilterPairing asserts that component and group flags are applied
+// (along with the exclusion flags) to the most recently specified URL or
+// filename flag, that component flags are paired with the most recently
+// specified group flag for a feed and that the match mode applies to all
+// feeds.
+func TestFeedsFilterPairing(t *testing.T) {
+
+	// Save old command-line arguments so that we can restore them later
+	oldArgs := os.Args
+
+	// Defer restoring original command-line arguments
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{
+		PluginComponentsAppName,
+		"--" + ComponentGroupFlagLong, "Canvas",
+		"--" + FilenameFlagLong, "instructure.json",
+		"--" + ComponentsListFlagLong, "Canvas LMS",
+		"--" + ComponentGroupFlagLong, "MasteryConnect",
+		"--" + ComponentsListFlagLong, "Reporting",
+		"--" + ComponentsListFlagLong, "Portal",
+		"--" + ComponentGroupFlagLong, "Portfolium",
+		"--" + ExcludeComponentsFlagLong, "Canvas Studio",
+		"--" + URLFlagLong, "kctbh9vrtdwd",
+		"--" + ComponentsListFlagLong, "Git Operations,API Requests",
+		"--" + URLFlagLong, "https://status.box.com",
+		"--" + ComponentsListFlagLong, "Box Sign",
+		"--" + ComponentGroupFlagLong, "Box",
+		"--" + ExcludeGroupsFlagLong, "Regions",
+		"--" + ExcludeComponentsFlagLong, "Box Notes,Box Relay",
+		"--" + MatchModeFlagLong, MatchModeCaseInsensitive,
+	}
+
+	c, err := New(AppType{PluginComponents: true})
+	if err != nil {
+		t.Fatalf("ERROR: Failed to instantiate configuration: %v", err)
+	}
+
+	want := []struct {
+		source string
+		filter ComponentFilter
+	}{
+		{
+			source: "instructure.json",
+			filter: ComponentFilter{
+				Groups: []ComponentGroupFilter{
+					{Group: "Canvas", Components: []string{"Canvas LMS"}},
+					{Group: "MasteryConnect", Components: []string{"Reporting", "Portal"}},
+					{Group: "Portfolium"},
+				},
+				MatchMode:         MatchModeCaseInsensitive,
+				ExcludeComponents: []string{"Canvas Studio"},
+			},
+		},
+		{
+			source: "https://kctbh9vrtdwd.statuspage.io/api/v2/components.json",
+			filter: ComponentFilter{Components: []string{"Git Operations", "API Requests"}, MatchMode: MatchModeCaseInsensitive},
+		},
+		{
+			source: "https://status.box.com/api/v2/components.json",
+			filter: ComponentFilter{
+				Groups: []ComponentGroupFilter{
+					{Group: "Box", Components: []string{"Box Sign"}},
+				},
+				MatchMode:         MatchModeCaseInsensitive,
+				ExcludeGroups:     []string{"Regions"},
+				ExcludeComponents: []string{"Box Notes", "Box Relay"},
+			},
+		},
+	}
+
+	feeds := c.Feeds()
+	if len(feeds) != len(want) {
+		t.Fatalf("ERROR: want %d feeds, got %d", len(want), len(feeds))
+	}
+
+	for i, feed := range feeds {
+		if feed.Source() != want[i].source {
+			t.Errorf("ERROR: feed %d: want source %q, got %q", i, want[i].source, feed.Source())
+		}
+
+		if d := cmp.Diff(want[i].filter, feed.ComponentFilter()); d != "" {
+			t.Errorf("ERROR: feed %d: (-want, +got)\n:%s", i, d)
+		}
+	}
+}
+
+// TestComponentFilterRepeatedGroups asserts that the incidents and
+// maintenance plugins accept the group flag multiple times and pair
+// component flags with the most recently specified group flag.
+func TestComponentFilterRepeatedGroups(t *testing.T) {
+
+	// Save old command-line arguments so that we can restore them later
+	oldArgs := os.Args
+
+	// Defer restoring original command-line arguments
+	defer func() { os.Args = oldArgs }()
+
+	tests := []struct {
+		appName string
+		appType AppType
+	}{
+		{
+			appName: PluginIncidentsAppName,
+			appType: AppType{PluginIncidents: true},
+		},
+		{
+			appName: PluginMaintenanceAppName,
+			appType: AppType{PluginMaintenance: true},
+		},
+	}
+
+	want := ComponentFilter{
+		Groups: []ComponentGroupFilter{
+			{Group: "Canvas", Components: []string{"Canvas LMS"}},
+			{Group: "MasteryConnect", Components: []string{"Reporting", "Portal"}},
+			{Group: "Portfolium"},
+		},
+		MatchMode: defaultMatchMode,
+	}
+
+	for _, test := range tests {
+		t.Run(test.appName, func(t *testing.T) {
+			os.Args = []string{
+				test.appName,
+				"--" + FilenameFlagLong, "instructure.json",
+				"--" + ComponentsListFlagLong, "Canvas LMS",
+				"--" + ComponentGroupFlagLong, "Canvas",
+				"--" + ComponentGroupFlagLong, "MasteryConnect",
+				"--" + ComponentsListFlagLong, "Reporting,Portal",
+				"--" + ComponentGroupFlagLong, "Portfolium",
+			}
+
+			c, err := New(test.appType)
+			if err != nil {
+				t.Fatalf("ERROR: Failed to instantiate configuration: %v", err)
+			}
+
+			if d := cmp.Diff(want, c.ComponentFilter()); d != "" {
+				t.Errorf("ERROR: (-want, +got)\n:%s", d)
+			} else {
+				t.Log("OK: all group flag values retained")
+			}
+		})
+	}
+}
+
+// TestRequestAuthSecrets asserts that user-specified headers and secrets
+// read from files or environment variables are applied to the options used
+// to retrieve feed URLs.
+func TestRequestAuthSecrets(t *testing.T) {
+
+	// Save old command-line arguments so that we can restore them later
+	oldArgs := os.Args
+
+	// Defer restoring original command-line arguments
+	defer func() { os.Args = oldArgs }()
+
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatalf("ERROR: failed to write password file: %v", err)
+	}
+
+	t.Setenv("CHECK_STATUSPAGE_TEST_TOKEN", "abc123")
+
+	tests := []struct {
+		name          string
+		args          []string
+		want          statuspage.FetchOptions
+		errorExpected bool
+	}{
+		{
+			name: "Basic auth password from file with header",
+			args: []string{
+				"--" + BasicAuthUserFlagLong, "monitoring",
+				"--" + BasicAuthPasswordFileFlagLong, passwordFile,
+				"--" + HeaderFlagLong, "x-audience: customers, partners",
+			},
+			want: statuspage.FetchOptions{
+				BasicAuthUser:     "monitoring",
+				BasicAuthPassword: "s3cret",
+				Headers:           http.Header{"X-Audience": []string{"customers, partners"}},
+			},
+		},
+		{
+			name: "Bearer token from environment variable",
+			args: []string{
+				"--" + BearerTokenEnvFlagLong, "CHECK_STATUSPAGE_TEST_TOKEN",
+			},
+			want: statuspage.FetchOptions{
+				BearerToken: "abc123",
+			},
+		},
+		{
+			name: "Bearer token from unset environment variable",
+			args: []string{
+				"--" + BearerTokenEnvFlagLong, "CHECK_STATUSPAGE_TEST_UNSET_TOKEN",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Basic auth user without password",
+			args: []string{
+				"--" + BasicAuthUserFlagLong, "monitoring",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Basic auth with bearer token",
+			args: []string{
+				"--" + BasicAuthUserFlagLong, "monitoring",
+				"--" + BasicAuthPasswordFileFlagLong, passwordFile,
+				"--" + BearerTokenEnvFlagLong, "CHECK_STATUSPAGE_TEST_TOKEN",
+			},
+			errorExpected: true,
+		},
+		{
+			name: "Malformed header",
+			args: []string{
+				"--" + HeaderFlagLong, "X-Audience customers",
+			},
+			errorExpected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Args = append(
+				[]string{
+					PluginStatusAppName,
+					"--" + URLFlagLong, "kctbh9vrtdwd",
+				},
+				tt.args...,
+			)
+
+			c, err := New(AppType{PluginStatus: true})
+			switch {
+			case tt.errorExpected && err == nil:
+				t.Fatal("ERROR: want error, got nil")
+
+			case tt.errorExpected:
+				t.Logf("OK: got expected error: %v", err)
+
+				return
+
+			case err != nil:
+				t.Fatalf("ERROR: Failed to instantiate configuration: %v", err)
+			}
+
+			got := c.FetchOptions()
+			want := tt.want
+			want.Retries = got.Retries
+			want.RetryDelay = got.RetryDelay
+			want.MinTLSVersion = got.MinTLSVersion
+
+			if d := cmp.Diff(want, got); d != "" {
+				t.Errorf("ERROR: (-want, +got)\n:%s", d)
+			}
+		})
+	}
+}
+
+// TestStatusMap asserts that user-specified status map values are normalized
+// and converted to the equivalent Nagios ServiceState values.
+func TestStatusMap(t *testing.T) {
+
+	// Save old command-line arguments so that we can restore them later
+	oldArgs := os.Args
+
+	// Defer restoring original command-line arguments
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{
+		PluginComponentsAppName,
+		"--" + URLFlagLong, "kctbh9vrtdwd",
+		"--" + EvalAllComponentsFlagLong,
+		"--" + StatusMapFlagLong, "Under_Maintenance=ok, partial_outage = CRITICAL",
+		"--" + StatusMapFlagLong, "major_outage=unknown",
+	}
+
+	c, err := New(AppType{PluginComponents: true})
+	if err != nil {
+		t.Fatalf("ERROR: Failed to instantiate configuration: %v", err)
+	}
+
+	want := map[string]nagios.ServiceState{
+		ComponentStatusUnderMaintenance: {
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		},
+		ComponentStatusPartialOutage: {
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		},
+		ComponentStatusMajorOutage: {
+			Label:    nagios.StateUNKNOWNLabel,
+			ExitCode: nagios.StateUNKNOWNExitCode,
+		},
+	}
+
+	if d := cmp.Diff(want, c.StatusMap()); d != "" {
+		t.Errorf("ERROR: (-want, +got)\n:%s", d)
+	}
+}
+
+// TestSeverityOverrides asserts that user-specified component severity
+// override values are split into a target and rules and converted to the
+// equivalent Nagios ServiceState values.
+func TestSeverityOverrides(t *testing.T) {
+
+	// Save old command-line arguments so that we can restore them later
+	oldArgs := os.Args
+
+	// Defer restoring original command-line arguments
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{
+		PluginComponentsAppName,
+		"--" + URLFlagLong, "kctbh9vrtdwd",
+		"--" + EvalAllComponentsFlagLong,
+		"--" + ComponentSeverityFlagLong, "Git Operations:partial_outage=critical, degraded_performance=WARNING",
+		"--" + ComponentSeverityFlagLong, "— Support: Phones/Chat:max=warning",
+	}
+
+	c, err := New(AppType{PluginComponents: true})
+	if err != nil {
+		t.Fatalf("ERROR: Failed to instantiate configuration: %v", err)
+	}
+
+	want := []ComponentSeverityOverride{
+		{
+			Target: "Git Operations",
+			Statuses: map[string]nagios.ServiceState{
+				ComponentStatusPartialOutage: {
+					Label:    nagios.StateCRITICALLabel,
+					ExitCode: nagios.StateCRITICALExitCode,
+				},
+				ComponentStatusDegradedPerformance: {
+					Label:    nagios.StateWARNINGLabel,
+					ExitCode: nagios.StateWARNINGExitCode,
+				},
+			},
+		},
+		{
+			Target: "— Support: Phones/Chat",
+			MaxState: nagios.ServiceState{
+				Label:    nagios.StateWARNINGLabel,
+				ExitCode: nagios.StateWARNINGExitCode,
+			},
+		},
+	}
+
+	if d := cmp.Diff(want, c.SeverityOverrides()); d != "" {
+		t.Errorf("ERROR: (-want, +got)\n:%s", d)
+	}
+}
+
+// TestProblemThresholds asserts that user-specified warning and critical
+// thresholds are split into a Nagios range and an optional percent
+// indicator.
+func TestProblemThresholds(t *testing.T) {
+
+	// Save old command-line arguments so that we can restore them later
+	oldArgs := os.Args
+
+	// Defer restoring original command-line arguments
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{
+		PluginComponentsAppName,
+		"--" + URLFlagLong, "kctbh9vrtdwd",
+		"--" + EvalAllComponentsFlagLong,
+		"--" + WarningThresholdFlagLong, " 5 ",
+		"--" + CriticalThresholdFlagLong, "@10:50%",
+	}
+
+	c, err := New(AppType{PluginComponents: true})
+	if err != nil {
+		t.Fatalf("ERROR: Failed to instantiate configuration: %v", err)
+	}
+
+	if !c.HasProblemThresholds() {
+		t.Error("ERROR: Expected thresholds to be reported as specified")
+	}
+
+	wantWarning := ProblemComponentsThreshold{Range: "5"}
+	if d := cmp.Diff(wantWarning, c.WarningThreshold()); d != "" {
+		t.Errorf("ERROR: (-want, +got)\n:%s", d)
+	}
+
+	wantCritical := ProblemComponentsThreshold{Range: "@10:50", Percent: true}
+	if d := cmp.Diff(wantCritical, c.CriticalThreshold()); d != "" {
+		t.Errorf("ERROR: (-want, +got)\n:%s", d)
+	}
+}
@@ -7,6 +7,8 @@
 
 package config
 
+import "time"
+
 const myAppName string = "check-statuspage"
 const myAppURL string = "https://github.com/atc0005/" + myAppName
 
@@ -42,6 +44,71 @@ const (
 	TimeoutFlagShort                string = "t"
 	LogLevelFlagLong                string = "log-level"
 	LogLevelFlagShort               string = "ll"
+	MaintenanceWithinFlag           string = "within"
+	ProviderFlagLong                string = "provider"
+	ProviderFlagShort               string = "p"
+	ListProvidersFlagLong           string = "list-providers"
+	ListProvidersFlagShort          string = "lp"
+	ConcurrencyFlagLong             string = "concurrency"
+	ConcurrencyFlagShort            string = "cc"
+	CacheDirFlagLong                string = "cache-dir"
+	CacheDirFlagShort               string = "cd"
+	RetriesFlagLong                 string = "retries"
+	RetriesFlagShort                string = "rt"
+	RetryDelayFlagLong              string = "retry-delay"
+	RetryDelayFlagShort             string = "rd"
+	MaxStaleFlagLong                string = "max-stale"
+	MaxStaleFlagShort               string = "ms"
+	FallbackStateFlagLong           string = "fallback-state"
+	FallbackStateFlagShort          string = "fs"
+	ProxyURLFlagLong                string = "proxy-url"
+	ProxyURLFlagShort               string = "px"
+	CABundleFlagLong                string = "ca-bundle"
+	CABundleFlagShort               string = "cab"
+	ClientCertFlagLong              string = "client-cert"
+	ClientCertFlagShort             string = "cert"
+	ClientKeyFlagLong               string = "client-key"
+	ClientKeyFlagShort              string = "key"
+	MinTLSVersionFlagLong           string = "min-tls-version"
+	MinTLSVersionFlagShort          string = "mtv"
+	InsecureSkipVerifyFlagLong      string = "insecure-skip-verify"
+	InsecureSkipVerifyFlagShort     string = "isv"
+	HeaderFlagLong                  string = "header"
+	HeaderFlagShort                 string = "hdr"
+	BasicAuthUserFlagLong           string = "basic-auth-user"
+	BasicAuthUserFlagShort          string = "bau"
+	BasicAuthPasswordFileFlagLong   string = "basic-auth-password-file"
+	BasicAuthPasswordFileFlagShort  string = "bapf"
+	BasicAuthPasswordEnvFlagLong    string = "basic-auth-password-env"
+	BasicAuthPasswordEnvFlagShort   string = "bape"
+	BearerTokenFileFlagLong         string = "bearer-token-file"
+	BearerTokenFileFlagShort        string = "btf"
+	BearerTokenEnvFlagLong          string = "bearer-token-env"
+	BearerTokenEnvFlagShort         string = "bte"
+	StaleWarningFlagLong            string = "stale-warning"
+	StaleWarningFlagShort           string = "sw"
+	StaleCriticalFlagLong           string = "stale-critical"
+	StaleCriticalFlagShort          string = "sc"
+	MatchModeFlagLong               string = "match-mode"
+	MatchModeFlagShort              string = "mm"
+	ExcludeComponentsFlagLong       string = "exclude-component"
+	ExcludeComponentsFlagShort      string = "xc"
+	ExcludeGroupsFlagLong           string = "exclude-group"
+	ExcludeGroupsFlagShort          string = "xg"
+	StatusMapFlagLong               string = "status-map"
+	StatusMapFlagShort              string = "sm"
+	ComponentSeverityFlagLong       string = "component-severity"
+	ComponentSeverityFlagShort      string = "cs"
+	WarningThresholdFlagLong        string = "warning"
+	WarningThresholdFlagShort       string = "w"
+	CriticalThresholdFlagLong       string = "critical"
+	CriticalThresholdFlagShort      string = "cr"
+	GroupRollupFlagLong             string = "group-rollup"
+	GroupRollupFlagShort            string = "gr"
+	GroupQuorumFlagLong             string = "group-quorum"
+	GroupQuorumFlagShort            string = "gq"
+	MinProblemDurationFlagLong      string = "min-problem-duration"
+	MinProblemDurationFlagShort     string = "mpd"
 )
 
 // shorthandFlagSuffix is appended to short flag help text to emphasize that
@@ -53,35 +120,87 @@ const (
 	helpFlagHelp                   string = "Emit this help text"
 	versionFlagHelp                string = "Whether to display application version and then immediately exit application."
 	logLevelFlagHelp               string = "Sets log level to one of disabled, panic, fatal, error, warn, info, debug or trace."
-	urlFlagHelp                    string = "The fully-qualified URL of a Statuspage API/JSON feed (e.g., https://www.githubstatus.com/api/v2/components.json)."
-	filenameFlagHelp               string = "The fully-qualified filename of a previously downloaded Statuspage API/JSON feed (e.g., /tmp/statuspage/github/components.json)."
+	urlFlagHelp                    string = "The fully-qualified URL of a Statuspage API/JSON feed (e.g., https://www.githubstatus.com/api/v2/components.json). A base status page URL (e.g., https://www.githubstatus.com) or Statuspage page ID (e.g., kctbh9vrtdwd) may also be specified and is expanded to the API/JSON feed applicable to this application."
+	filenameFlagHelp               string = "The fully-qualified filename of a previously downloaded Statuspage API/JSON feed (e.g., /tmp/statuspage/github/components.json). Gzip (.gz) and zstd (.zst) compressed files are supported. Specify - to read from standard input."
 	timeoutRuntimeFlagHelp         string = "Timeout value in seconds allowed before an execution attempt is abandoned and an error returned."
 	readLimitFlagHelp              string = "Limit in bytes used to help prevent abuse when reading input that could be larger than expected. The default value is nearly 4x the largest observed (formatted) feed size."
 	allowUnknownJSONFieldsFlagHelp string = "Whether unknown JSON fields encountered while decoding JSON data should be ignored."
 	omitOKComponentsFlagHelp       string = "Whether listed components in results output should be limited to just those in a non-operational state. Does not apply to all output formats."
 	omitSummaryResultsFlagHelp     string = "Whether summary in results output should be omitted."
+	cacheDirFlagHelp               string = "Optional directory used to cache the last response retrieved from each feed URL. If specified, conditional requests are submitted using the cached ETag and Last-Modified values and an unchanged feed is decoded from the cache."
+	retriesFlagHelp                string = "Number of additional attempts made to retrieve a feed URL after a connection error, timeout or transient HTTP status code (502, 503, 504). All attempts are made within the timeout value for the application."
+	retryDelayFlagHelp             string = "Base delay (e.g., 500ms, 2s) between attempts to retrieve a feed URL. The delay increases exponentially (with jitter) for each attempt."
+	proxyURLFlagHelp               string = "Optional HTTP(S) proxy URL (e.g., http://proxy.example.com:3128) used to retrieve feed URLs. Overrides proxy settings from environment variables."
+	caBundleFlagHelp               string = "Optional fully-qualified filename of a PEM encoded bundle of CA certificates trusted (in addition to the system certificate pool) when retrieving feed URLs."
+	clientCertFlagHelp             string = "Optional fully-qualified filename of a PEM encoded client certificate presented when retrieving feed URLs. Requires the client-key flag."
+	clientKeyFlagHelp              string = "Optional fully-qualified filename of the PEM encoded private key for the client certificate. Requires the client-cert flag."
+	minTLSVersionFlagHelp          string = "Minimum TLS version (one of 1.0, 1.1, 1.2 or 1.3) accepted when retrieving feed URLs."
+	insecureSkipVerifyFlagHelp     string = "Whether verification of the server certificate chain and host name should be DISABLED when retrieving feed URLs. This is INSECURE and should only be used for troubleshooting."
+	headerFlagHelp                 string = "Optional HTTP header in Name: Value format (e.g., 'X-Audience: customers') added to requests for feed URLs. May be repeated to add multiple headers."
+	basicAuthUserFlagHelp          string = "Optional username used for basic authentication when retrieving feed URLs. Requires the basic-auth-password-file or basic-auth-password-env flag."
+	basicAuthPasswordFileFlagHelp  string = "Fully-qualified filename of a file containing the password used for basic authentication when retrieving feed URLs."
+	basicAuthPasswordEnvFlagHelp   string = "Name of an environment variable containing the password used for basic authentication when retrieving feed URLs."
+	bearerTokenFileFlagHelp        string = "Fully-qualified filename of a file containing a bearer token used for authentication when retrieving feed URLs."
+	bearerTokenEnvFlagHelp         string = "Name of an environment variable containing a bearer token used for authentication when retrieving feed URLs."
+	concurrencyFlagHelp            string = "Maximum number of feeds retrieved concurrently when multiple feeds are specified. All feeds are retrieved within the timeout value for the application."
+	providerFlagHelp               string = "Key of a known Statuspage powered provider (e.g., github). If neither the URL nor filename flag is specified, the status page for the provider is evaluated. The expected page ID for the provider is used to assert that the evaluated feed belongs to the provider. See the list-providers flag of the lscs tool for known providers."
 )
 
 // Inspector type application flag help text
 const (
-	inspectorOutputFormatFlagHelp string = "Sets output format to one of overview, table, verbose, debug, list or json."
+	inspectorOutputFormatFlagHelp  string = "Sets output format to one of overview, table, verbose, debug, list or json."
+	inspectorListProvidersFlagHelp string = "Whether to display the catalog of known Statuspage powered providers and then immediately exit application."
 )
 
 // Plugin type application flag help text
 const (
-	brandingFlagHelp          string = "Toggles emission of branding details with plugin status details. This output is disabled by default."
-	componentsListFlagHelp    string = "One or more comma-separated component (name or ID) values. Can be used by itself or with the flag to specify a component group. If used with the component group flag, all specified components are required to be subcomponents of the group."
-	componentGroupFlagHelp    string = "A single name or ID value for a component group. Can be used by itself or with the flag to specify a list of components. If used with the components flag all specified components are required to be subcomponents of the group."
-	evalAllComponentsFlagHelp string = "Whether all components should be evaluated. Incompatible with flag to specify list of components, component group or component group set."
-	verboseFlagHelp           string = "Whether to display verbose details in the final plugin output."
+	brandingFlagHelp           string = "Toggles emission of branding details with plugin status details. This output is disabled by default."
+	componentsListFlagHelp     string = "One or more comma-separated component (name or ID) values. Can be used by itself or with the flag to specify a component group. If used with the component group flag, components are paired with the most recently specified group (or the first group if specified before any group) and are required to be subcomponents of that group."
+	componentGroupFlagHelp     string = "A name or ID value for a component group. May be repeated to specify multiple groups. Can be used by itself or with the flag to specify a list of components. A group without paired components has all subcomponents evaluated."
+	evalAllComponentsFlagHelp  string = "Whether all components should be evaluated. Incompatible with flag to specify list of components, component group or component group set. May be combined with the flags to exclude components or component groups."
+	verboseFlagHelp            string = "Whether to display verbose details in the final plugin output."
+	matchModeFlagHelp          string = "Sets the mode used to match component and component group names to one of exact, case-insensitive, glob or regex. ID values are always matched case-insensitively."
+	excludeComponentsFlagHelp  string = "One or more comma-separated component (name or ID) values excluded from evaluation. May be combined with the eval-all flag or with the flags to specify a list of components or a component group."
+	excludeGroupsFlagHelp      string = "One or more comma-separated component group (name or ID) values excluded from evaluation along with all of their subcomponents. May be combined with the eval-all flag or with the flags to specify a list of components or a component group."
+	staleWarningFlagHelp       string = "Duration (e.g., 6h, 24h) after which a status page which has not been updated results in a WARNING state. Not evaluated if not specified."
+	staleCriticalFlagHelp      string = "Duration (e.g., 12h, 72h) after which a status page which has not been updated results in a CRITICAL state. Not evaluated if not specified."
+	minProblemDurationFlagHelp string = "Duration (e.g., 5m, 15m) a non-operational component status must persist, measured from the time the component was last updated, before the component is evaluated as a problem. All non-operational statuses are evaluated if not specified."
+	maxStaleFlagHelp           string = "Maximum age (e.g., 15m, 1h) of the last good copy of a feed evaluated in place of a feed URL which could not be retrieved. Requires the cache-dir flag. The last good copy of a feed is not used if not specified."
+	fallbackStateFlagHelp      string = "Sets the state (one of warning or unknown) of results evaluated using the last good copy of a feed. A more severe state determined from the last good copy of the feed is retained."
+	componentSeverityFlagHelp  string = "Severity rules for a single component or component group (name or ID) in target:rule,rule format (e.g., 'Git Operations:partial_outage=CRITICAL' or 'Codespaces:max=WARNING'). Each rule is a status=state pair overriding the status map or max=state to limit the most severe state. Rules for a component group apply to its subcomponents. May be repeated for multiple components or component groups."
+	warningThresholdFlagHelp   string = "Nagios range (e.g., 5, 10:, @0:3) for the number of evaluated components in a non-operational status which results in a WARNING state. Append % (e.g., 5%) to apply the range to the percentage of evaluated components in a non-operational status. If this flag or the critical flag is specified, the plugin state is determined by the thresholds instead of by the most severe component state."
+	criticalThresholdFlagHelp  string = "Nagios range (e.g., 10, 20:, @0:5) for the number of evaluated components in a non-operational status which results in a CRITICAL state. Append % (e.g., 10%) to apply the range to the percentage of evaluated components in a non-operational status. If this flag or the warning flag is specified, the plugin state is determined by the thresholds instead of by the most severe component state."
+	groupRollupFlagHelp        string = "Sets the mode used to evaluate each component group with evaluated subcomponents as a single unit to one of none, parent, worst-of or quorum. The parent mode uses the status of the component group itself, worst-of uses the most severe state of its evaluated subcomponents and quorum uses the most severe state shared (at that state or a more severe one) by at least the group-quorum percentage of its evaluated subcomponents. Subcomponents are evaluated separately if set to none."
+	groupQuorumFlagHelp        string = "Percentage (1-100) of evaluated subcomponents of a component group required to share a state for the quorum group rollup mode (e.g., 50 results in a CRITICAL state only if at least half of the evaluated subcomponents map to a CRITICAL state)."
+	statusMapFlagHelp          string = "One or more comma-separated status=state pairs (e.g., under_maintenance=OK,partial_outage=CRITICAL) overriding the state used for a component status. Supported statuses are degraded_performance, partial_outage, major_outage and under_maintenance. Supported states are OK, WARNING, CRITICAL and UNKNOWN. By default major_outage is CRITICAL and all other non-operational statuses are WARNING."
+
+	// Appended to flag help text for the repeatable feed flags used by the
+	// components plugin.
+	feedSourceFlagHelpSuffix string = " May be repeated (along with the filename or URL flag) to evaluate multiple feeds."
+	feedFilterFlagHelpSuffix string = " Applies to the most recently specified URL or filename flag; repeat for each feed."
+)
+
+// Incidents plugin type application flag help text
+const (
+	incidentsComponentsListFlagHelp string = "One or more comma-separated component (name or ID) values used to limit evaluation to incidents affecting those components. Can be used by itself or with the flag to specify a component group. If used with the component group flag, components are paired with the most recently specified group (or the first group if specified before any group) and are required to be subcomponents of that group."
+	incidentsComponentGroupFlagHelp string = "A name or ID value for a component group used to limit evaluation to incidents affecting subcomponents of that group. May be repeated to specify multiple groups. Can be used by itself or with the flag to specify a list of components. If not specified along with the components flag, all unresolved incidents are evaluated."
+)
+
+// Maintenance plugin type application flag help text
+const (
+	maintenanceComponentsListFlagHelp string = "One or more comma-separated component (name or ID) values used to limit evaluation to scheduled maintenances affecting those components. Can be used by itself or with the flag to specify a component group. If used with the component group flag, components are paired with the most recently specified group (or the first group if specified before any group) and are required to be subcomponents of that group."
+	maintenanceComponentGroupFlagHelp string = "A name or ID value for a component group used to limit evaluation to scheduled maintenances affecting subcomponents of that group. May be repeated to specify multiple groups. Can be used by itself or with the flag to specify a list of components. If not specified along with the components flag, all scheduled maintenances are evaluated."
+	maintenanceWithinFlagHelp         string = "Lookahead duration (e.g., 30m, 12h, 72h) used to determine whether an upcoming scheduled maintenance starts soon enough to be reported. Active scheduled maintenances are always reported."
 )
 
 // Default flag settings if not overridden by user input
 const (
 	defaultURL                    string = ""
+	defaultProvider               string = ""
+	defaultCacheDir               string = ""
+	defaultListProviders          bool   = false
 	defaultFilename               string = ""
 	defaultLogLevel               string = "info"
-	defaultComponentGroup         string = ""
 	defaultHelp                   bool   = false
 	defaultBranding               bool   = false
 	defaultVerbose                bool   = false
@@ -91,6 +210,32 @@ const (
 	defaultDisplayVersionAndExit  bool   = false
 	defaultAllowUnknownJSONFields bool   = false
 	defaultRuntimeTimeout         int    = 10
+	defaultConcurrency            int    = 4
+	defaultRetries                int    = 2
+	defaultFallbackState          string = FallbackStateWarning
+	defaultProxyURL               string = ""
+	defaultCABundle               string = ""
+	defaultClientCert             string = ""
+	defaultClientKey              string = ""
+	defaultMinTLSVersion          string = TLSVersion12
+	defaultInsecureSkipVerify     bool   = false
+	defaultBasicAuthUser          string = ""
+	defaultBasicAuthPasswordFile  string = ""
+	defaultBasicAuthPasswordEnv   string = ""
+	defaultBearerTokenFile        string = ""
+	defaultBearerTokenEnv         string = ""
+	defaultMatchMode              string = MatchModeExact
+	defaultWarningThreshold       string = ""
+	defaultGroupRollup            string = GroupRollupNone
+	defaultGroupQuorum            int    = 50
+	defaultCriticalThreshold      string = ""
+
+	defaultMaintenanceWithin  time.Duration = 24 * time.Hour
+	defaultRetryDelay         time.Duration = 500 * time.Millisecond
+	defaultMaxStale           time.Duration = 0
+	defaultStaleWarning       time.Duration = 0
+	defaultStaleCritical      time.Duration = 0
+	defaultMinProblemDuration time.Duration = 0
 
 	// Set a read limit to help prevent abuse from unexpected/overly large
 	// input. The limit set here is OVERLY generous and is unlikely to be met
@@ -114,6 +259,12 @@ const (
 const (
 	PluginComponentsAppType    string = "plugin-components"
 	PluginComponentsAppName    string = "check_components"
+	PluginIncidentsAppType     string = "plugin-incidents"
+	PluginIncidentsAppName     string = "check_incidents"
+	PluginMaintenanceAppType   string = "plugin-maintenance"
+	PluginMaintenanceAppName   string = "check_maintenance"
+	PluginStatusAppType        string = "plugin-status"
+	PluginStatusAppName        string = "check_status"
 	InspectorComponentsAppType string = "inspector-components"
 	InspectorComponentsAppName string = "lscs"
 )
@@ -152,6 +303,49 @@ const (
 // MB represents 1 Megabyte
 const MB int64 = 1048576
 
+// Supported states for plugin results evaluated using the last good copy of a
+// feed.
+const (
+	FallbackStateWarning string = "warning"
+	FallbackStateUnknown string = "unknown"
+)
+
+// Supported modes used to match component and component group names. These
+// values mirror the match modes supported by the components package.
+const (
+	MatchModeExact           string = "exact"
+	MatchModeCaseInsensitive string = "case-insensitive"
+	MatchModeGlob            string = "glob"
+	MatchModeRegex           string = "regex"
+)
+
+// Supported modes used to evaluate a component group as a single unit. These
+// values mirror the group rollup modes supported by the components package.
+const (
+	GroupRollupNone    string = "none"
+	GroupRollupParent  string = "parent"
+	GroupRollupWorstOf string = "worst-of"
+	GroupRollupQuorum  string = "quorum"
+)
+
+// Component status values which may be mapped to a different state via the
+// status map flag. These values mirror the component statuses of the
+// components package.
+const (
+	ComponentStatusDegradedPerformance string = "degraded_performance"
+	ComponentStatusPartialOutage       string = "partial_outage"
+	ComponentStatusMajorOutage         string = "major_outage"
+	ComponentStatusUnderMaintenance    string = "under_maintenance"
+)
+
+// Supported minimum TLS versions
+const (
+	TLSVersion10 string = "1.0"
+	TLSVersion11 string = "1.1"
+	TLSVersion12 string = "1.2"
+	TLSVersion13 string = "1.3"
+)
+
 // Supported Inspector type application output formats
 const (
 	InspectorOutputFormatOverview string = "overview"
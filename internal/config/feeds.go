@@ -0,0 +1,322 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FeedSpec represents a Statuspage API/JSON feed specified by the user along
+// with the component filter values applicable to that feed.
+type FeedSpec struct {
+
+	// URL is the fully-qualified URL of a Statuspage API/JSON feed. If the
+	// user specified a base status page URL or a Statuspage page ID, this is
+	// the API/JSON feed URL derived from that value.
+	URL string
+
+	// URLProvided is the URL, base status page URL or Statuspage page ID
+	// value as specified by the user.
+	URLProvided string
+
+	// Filename is the fully-qualified filename of a previously downloaded
+	// Statuspage API/JSON feed.
+	Filename string
+
+	// componentGroups is a collection of component groups, each optionally
+	// paired with subcomponents, specified by the user for this feed.
+	componentGroups []ComponentGroupFilter
+
+	// componentsList is a collection of individual components specified by
+	// the user for this feed which are not paired with a component group.
+	componentsList multiValueStringFlag
+
+	// matchMode is the mode used to match component and component group
+	// names for this feed.
+	matchMode string
+
+	// excludeGroups is a collection of component groups (and their
+	// subcomponents) excluded from evaluation for this feed.
+	excludeGroups multiValueStringFlag
+
+	// excludeComponents is a collection of components excluded from
+	// evaluation for this feed.
+	excludeComponents multiValueStringFlag
+}
+
+// Source returns the filename or URL used to retrieve the feed.
+func (fs FeedSpec) Source() string {
+	if fs.Filename != "" {
+		return fs.Filename
+	}
+
+	return fs.URL
+}
+
+// ComponentFilter returns the user-specified component filter values for the
+// feed.
+func (fs FeedSpec) ComponentFilter() ComponentFilter {
+	return ComponentFilter{
+		Groups:            fs.componentGroups,
+		Components:        fs.componentsList,
+		MatchMode:         fs.matchMode,
+		ExcludeGroups:     fs.excludeGroups,
+		ExcludeComponents: fs.excludeComponents,
+	}
+}
+
+// filterSpecified indicates whether the user specified a component group or
+// list of components for the feed.
+func (fs FeedSpec) filterSpecified() bool {
+	return len(fs.componentGroups) > 0 || len(fs.componentsList) > 0
+}
+
+// feedTarget returns the feed that component filter flags currently apply
+// to. This is the most recently specified additional feed or, if none have
+// been specified, the primary feed.
+func (c *Config) feedTarget() (*[]ComponentGroupFilter, *multiValueStringFlag) {
+	if len(c.additionalFeeds) > 0 {
+		last := &c.additionalFeeds[len(c.additionalFeeds)-1]
+
+		return &last.componentGroups, &last.componentsList
+	}
+
+	return &c.componentGroups, &c.componentsList
+}
+
+// feedExclusionTarget returns the component group and component exclusion
+// values for the feed that exclusion flags currently apply to. See
+// feedTarget for details.
+func (c *Config) feedExclusionTarget() (*multiValueStringFlag, *multiValueStringFlag) {
+	if len(c.additionalFeeds) > 0 {
+		last := &c.additionalFeeds[len(c.additionalFeeds)-1]
+
+		return &last.excludeGroups, &last.excludeComponents
+	}
+
+	return &c.excludeGroups, &c.excludeComponents
+}
+
+// addFeedSource records the given URL or filename value. The first value is
+// recorded as the primary feed; each value after that is recorded as an
+// additional feed.
+func (c *Config) addFeedSource(url string, filename string) {
+	if len(c.additionalFeeds) == 0 && c.URL == "" && c.Filename == "" {
+		c.URL = url
+		c.Filename = filename
+
+		return
+	}
+
+	c.additionalFeeds = append(c.additionalFeeds, FeedSpec{
+		URL:      url,
+		Filename: filename,
+	})
+}
+
+// Feeds returns the primary feed followed by any additional feeds specified
+// by the user. The user-specified match mode applies to all feeds.
+func (c Config) Feeds() []FeedSpec {
+	feeds := make([]FeedSpec, 0, len(c.additionalFeeds)+1)
+
+	feeds = append(feeds, FeedSpec{
+		URL:               c.URL,
+		URLProvided:       c.URLProvided,
+		Filename:          c.Filename,
+		componentGroups:   c.componentGroups,
+		componentsList:    c.componentsList,
+		excludeGroups:     c.excludeGroups,
+		excludeComponents: c.excludeComponents,
+	})
+
+	feeds = append(feeds, c.additionalFeeds...)
+
+	for i := range feeds {
+		feeds[i].matchMode = c.MatchMode
+	}
+
+	return feeds
+}
+
+// feedSourceFlag is a custom type that satisfies the flag.Value interface in
+// order to accept repeated URL or filename flags. Each occurrence of the
+// flag specifies a separate feed.
+type feedSourceFlag struct {
+	config   *Config
+	filename bool
+}
+
+// String returns a comma separated string consisting of all specified URL or
+// filename values.
+func (fsf *feedSourceFlag) String() string {
+
+	// From the `flag` package docs:
+	// "The flag package may call the String method with a zero-valued
+	// receiver, such as a nil pointer."
+	if fsf == nil || fsf.config == nil {
+		return ""
+	}
+
+	var values []string
+	for _, feed := range fsf.config.Feeds() {
+		value := feed.URL
+		if fsf.filename {
+			value = feed.Filename
+		}
+
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// Set is called once by the flag package, in command line order, for each
+// flag present.
+func (fsf *feedSourceFlag) Set(value string) error {
+	switch {
+	case fsf.filename:
+		fsf.config.addFeedSource("", value)
+	default:
+		fsf.config.addFeedSource(value, "")
+	}
+
+	return nil
+}
+
+// feedGroupFlag is a custom type that satisfies the flag.Value interface in
+// order to apply component groups to the most recently specified feed.
+type feedGroupFlag struct {
+	config *Config
+}
+
+// String returns a comma separated string consisting of the component groups
+// for the most recently specified feed.
+func (fgf *feedGroupFlag) String() string {
+	if fgf == nil || fgf.config == nil {
+		return ""
+	}
+
+	groups, _ := fgf.config.feedTarget()
+
+	names := make([]string, 0, len(*groups))
+	for _, group := range *groups {
+		names = append(names, group.Group)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Set is called once by the flag package, in command line order, for each
+// flag present. Components specified for the feed before the first group are
+// paired with that group.
+func (fgf *feedGroupFlag) Set(value string) error {
+	groups, componentsList := fgf.config.feedTarget()
+
+	for _, group := range *groups {
+		if strings.EqualFold(strings.TrimSpace(group.Group), strings.TrimSpace(value)) {
+			return fmt.Errorf(
+				"%s flag specified more than once with value %q for the same feed",
+				ComponentGroupFlagLong,
+				value,
+			)
+		}
+	}
+
+	group := ComponentGroupFilter{Group: value}
+	if len(*groups) == 0 && len(*componentsList) > 0 {
+		group.Components = *componentsList
+		*componentsList = nil
+	}
+
+	*groups = append(*groups, group)
+
+	return nil
+}
+
+// feedComponentsFlag is a custom type that satisfies the flag.Value
+// interface in order to apply components to the most recently specified
+// feed. Components are paired with the most recently specified component
+// group for the feed, if any.
+type feedComponentsFlag struct {
+	config *Config
+}
+
+// String returns a comma separated string consisting of the components for
+// the most recently specified feed.
+func (fcf *feedComponentsFlag) String() string {
+	if fcf == nil || fcf.config == nil {
+		return ""
+	}
+
+	groups, componentsList := fcf.config.feedTarget()
+
+	items := []string(*componentsList)
+	for _, group := range *groups {
+		items = append(items, group.Components...)
+	}
+
+	return strings.Join(items, ", ")
+}
+
+// Set is called once by the flag package, in command line order, for each
+// flag present.
+func (fcf *feedComponentsFlag) Set(value string) error {
+	groups, componentsList := fcf.config.feedTarget()
+
+	if len(*groups) == 0 {
+		return componentsList.Set(value)
+	}
+
+	var items multiValueStringFlag
+	if err := items.Set(value); err != nil {
+		return err
+	}
+
+	last := &(*groups)[len(*groups)-1]
+	last.Components = append(last.Components, items...)
+
+	return nil
+}
+
+// feedExclusionFlag is a custom type that satisfies the flag.Value interface
+// in order to apply component group or component exclusions to the most
+// recently specified feed.
+type feedExclusionFlag struct {
+	config *Config
+	group  bool
+}
+
+// target returns the component group or component exclusion values for the
+// most recently specified feed.
+func (fef *feedExclusionFlag) target() *multiValueStringFlag {
+	excludeGroups, excludeComponents := fef.config.feedExclusionTarget()
+	if fef.group {
+		return excludeGroups
+	}
+
+	return excludeComponents
+}
+
+// String returns a comma separated string consisting of the component group
+// or component exclusions for the most recently specified feed.
+func (fef *feedExclusionFlag) String() string {
+	if fef == nil || fef.config == nil {
+		return ""
+	}
+
+	return fef.target().String()
+}
+
+// Set is called once by the flag package, in command line order, for each
+// flag present.
+func (fef *feedExclusionFlag) Set(value string) error {
+	return fef.target().Set(value)
+}
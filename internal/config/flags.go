@@ -34,20 +34,132 @@ func (c *Config) handleFlagsConfig(appType AppType) error {
 
 		c.flagSet.BoolVar(&c.ShowVerbose, VerboseFlag, defaultVerbose, verboseFlagHelp)
 
-		c.flagSet.Var(&c.componentsList, ComponentsListFlagShort, componentsListFlagHelp+shorthandFlagSuffix)
-		c.flagSet.Var(&c.componentsList, ComponentsListFlagLong, componentsListFlagHelp)
+		// The URL, filename, component and group flags are repeatable in
+		// order to evaluate multiple feeds. Component and group flags apply
+		// to the most recently specified feed.
+		feedComponents := &feedComponentsFlag{config: c}
+		c.flagSet.Var(feedComponents, ComponentsListFlagShort, componentsListFlagHelp+feedFilterFlagHelpSuffix+shorthandFlagSuffix)
+		c.flagSet.Var(feedComponents, ComponentsListFlagLong, componentsListFlagHelp+feedFilterFlagHelpSuffix)
 
-		c.flagSet.StringVar(&c.componentGroup, ComponentGroupFlagShort, defaultComponentGroup, componentGroupFlagHelp+shorthandFlagSuffix)
-		c.flagSet.StringVar(&c.componentGroup, ComponentGroupFlagLong, defaultComponentGroup, componentGroupFlagHelp)
+		feedGroup := &feedGroupFlag{config: c}
+		c.flagSet.Var(feedGroup, ComponentGroupFlagShort, componentGroupFlagHelp+feedFilterFlagHelpSuffix+shorthandFlagSuffix)
+		c.flagSet.Var(feedGroup, ComponentGroupFlagLong, componentGroupFlagHelp+feedFilterFlagHelpSuffix)
+
+		feedExcludeComponents := &feedExclusionFlag{config: c}
+		c.flagSet.Var(feedExcludeComponents, ExcludeComponentsFlagShort, excludeComponentsFlagHelp+feedFilterFlagHelpSuffix+shorthandFlagSuffix)
+		c.flagSet.Var(feedExcludeComponents, ExcludeComponentsFlagLong, excludeComponentsFlagHelp+feedFilterFlagHelpSuffix)
+
+		feedExcludeGroups := &feedExclusionFlag{config: c, group: true}
+		c.flagSet.Var(feedExcludeGroups, ExcludeGroupsFlagShort, excludeGroupsFlagHelp+feedFilterFlagHelpSuffix+shorthandFlagSuffix)
+		c.flagSet.Var(feedExcludeGroups, ExcludeGroupsFlagLong, excludeGroupsFlagHelp+feedFilterFlagHelpSuffix)
+
+		c.flagSet.StringVar(&c.MatchMode, MatchModeFlagShort, defaultMatchMode, matchModeFlagHelp+shorthandFlagSuffix)
+		c.flagSet.StringVar(&c.MatchMode, MatchModeFlagLong, defaultMatchMode, matchModeFlagHelp)
+
+		c.flagSet.Var(&c.statusMap, StatusMapFlagShort, statusMapFlagHelp+shorthandFlagSuffix)
+		c.flagSet.Var(&c.statusMap, StatusMapFlagLong, statusMapFlagHelp)
+
+		c.flagSet.Var(&c.severityOverrides, ComponentSeverityFlagShort, componentSeverityFlagHelp+shorthandFlagSuffix)
+		c.flagSet.Var(&c.severityOverrides, ComponentSeverityFlagLong, componentSeverityFlagHelp)
+
+		feedURL := &feedSourceFlag{config: c}
+		c.flagSet.Var(feedURL, URLFlagShort, urlFlagHelp+feedSourceFlagHelpSuffix+shorthandFlagSuffix)
+		c.flagSet.Var(feedURL, URLFlagLong, urlFlagHelp+feedSourceFlagHelpSuffix)
+
+		feedFilename := &feedSourceFlag{config: c, filename: true}
+		c.flagSet.Var(feedFilename, FilenameFlagShort, filenameFlagHelp+feedSourceFlagHelpSuffix+shorthandFlagSuffix)
+		c.flagSet.Var(feedFilename, FilenameFlagLong, filenameFlagHelp+feedSourceFlagHelpSuffix)
 
 		c.flagSet.BoolVar(&c.EvalAllComponents, EvalAllComponentsFlagShort, defaultEvalAllComponents, evalAllComponentsFlagHelp+shorthandFlagSuffix)
 		c.flagSet.BoolVar(&c.EvalAllComponents, EvalAllComponentsFlagLong, defaultEvalAllComponents, evalAllComponentsFlagHelp)
 
+		c.flagSet.IntVar(&c.Concurrency, ConcurrencyFlagShort, defaultConcurrency, concurrencyFlagHelp+shorthandFlagSuffix)
+		c.flagSet.IntVar(&c.Concurrency, ConcurrencyFlagLong, defaultConcurrency, concurrencyFlagHelp)
+
+		c.flagSet.StringVar(&c.warningThreshold, WarningThresholdFlagShort, defaultWarningThreshold, warningThresholdFlagHelp+shorthandFlagSuffix)
+		c.flagSet.StringVar(&c.warningThreshold, WarningThresholdFlagLong, defaultWarningThreshold, warningThresholdFlagHelp)
+
+		c.flagSet.StringVar(&c.criticalThreshold, CriticalThresholdFlagShort, defaultCriticalThreshold, criticalThresholdFlagHelp+shorthandFlagSuffix)
+		c.flagSet.StringVar(&c.criticalThreshold, CriticalThresholdFlagLong, defaultCriticalThreshold, criticalThresholdFlagHelp)
+
+		c.flagSet.StringVar(&c.GroupRollupMode, GroupRollupFlagShort, defaultGroupRollup, groupRollupFlagHelp+shorthandFlagSuffix)
+		c.flagSet.StringVar(&c.GroupRollupMode, GroupRollupFlagLong, defaultGroupRollup, groupRollupFlagHelp)
+
+		c.flagSet.IntVar(&c.GroupQuorum, GroupQuorumFlagShort, defaultGroupQuorum, groupQuorumFlagHelp+shorthandFlagSuffix)
+		c.flagSet.IntVar(&c.GroupQuorum, GroupQuorumFlagLong, defaultGroupQuorum, groupQuorumFlagHelp)
+
+		c.flagSet.DurationVar(&c.MinProblemDuration, MinProblemDurationFlagShort, defaultMinProblemDuration, minProblemDurationFlagHelp+shorthandFlagSuffix)
+		c.flagSet.DurationVar(&c.MinProblemDuration, MinProblemDurationFlagLong, defaultMinProblemDuration, minProblemDurationFlagHelp)
+
+		c.flagSet.DurationVar(&c.StaleWarning, StaleWarningFlagShort, defaultStaleWarning, staleWarningFlagHelp+shorthandFlagSuffix)
+		c.flagSet.DurationVar(&c.StaleWarning, StaleWarningFlagLong, defaultStaleWarning, staleWarningFlagHelp)
+
+		c.flagSet.DurationVar(&c.StaleCritical, StaleCriticalFlagShort, defaultStaleCritical, staleCriticalFlagHelp+shorthandFlagSuffix)
+		c.flagSet.DurationVar(&c.StaleCritical, StaleCriticalFlagLong, defaultStaleCritical, staleCriticalFlagHelp)
+
+	case appType.PluginIncidents:
+
+		c.flagSet.BoolVar(&c.EmitBranding, BrandingFlag, defaultBranding, brandingFlagHelp)
+
+		c.flagSet.BoolVar(&c.ShowVerbose, VerboseFlag, defaultVerbose, verboseFlagHelp)
+
+		// The group flag is repeatable; components are paired with the
+		// most recently specified group as for the components plugin.
+		feedComponents := &feedComponentsFlag{config: c}
+		c.flagSet.Var(feedComponents, ComponentsListFlagShort, incidentsComponentsListFlagHelp+shorthandFlagSuffix)
+		c.flagSet.Var(feedComponents, ComponentsListFlagLong, incidentsComponentsListFlagHelp)
+
+		feedGroup := &feedGroupFlag{config: c}
+		c.flagSet.Var(feedGroup, ComponentGroupFlagShort, incidentsComponentGroupFlagHelp+shorthandFlagSuffix)
+		c.flagSet.Var(feedGroup, ComponentGroupFlagLong, incidentsComponentGroupFlagHelp)
+
+		c.flagSet.StringVar(&c.MatchMode, MatchModeFlagShort, defaultMatchMode, matchModeFlagHelp+shorthandFlagSuffix)
+		c.flagSet.StringVar(&c.MatchMode, MatchModeFlagLong, defaultMatchMode, matchModeFlagHelp)
+
+	case appType.PluginMaintenance:
+
+		c.flagSet.BoolVar(&c.EmitBranding, BrandingFlag, defaultBranding, brandingFlagHelp)
+
+		c.flagSet.BoolVar(&c.ShowVerbose, VerboseFlag, defaultVerbose, verboseFlagHelp)
+
+		// The group flag is repeatable; components are paired with the
+		// most recently specified group as for the components plugin.
+		feedComponents := &feedComponentsFlag{config: c}
+		c.flagSet.Var(feedComponents, ComponentsListFlagShort, maintenanceComponentsListFlagHelp+shorthandFlagSuffix)
+		c.flagSet.Var(feedComponents, ComponentsListFlagLong, maintenanceComponentsListFlagHelp)
+
+		feedGroup := &feedGroupFlag{config: c}
+		c.flagSet.Var(feedGroup, ComponentGroupFlagShort, maintenanceComponentGroupFlagHelp+shorthandFlagSuffix)
+		c.flagSet.Var(feedGroup, ComponentGroupFlagLong, maintenanceComponentGroupFlagHelp)
+
+		c.flagSet.StringVar(&c.MatchMode, MatchModeFlagShort, defaultMatchMode, matchModeFlagHelp+shorthandFlagSuffix)
+		c.flagSet.StringVar(&c.MatchMode, MatchModeFlagLong, defaultMatchMode, matchModeFlagHelp)
+
+		c.flagSet.DurationVar(&c.MaintenanceWithin, MaintenanceWithinFlag, defaultMaintenanceWithin, maintenanceWithinFlagHelp)
+
+	case appType.PluginStatus:
+
+		c.flagSet.BoolVar(&c.EmitBranding, BrandingFlag, defaultBranding, brandingFlagHelp)
+
+		c.flagSet.BoolVar(&c.ShowVerbose, VerboseFlag, defaultVerbose, verboseFlagHelp)
+
 	case appType.InspectorComponents:
 
 		c.flagSet.StringVar(&c.InspectorOutputFormat, InspectorOutputFormatFlagShort, defaultInspectorOutputFormat, inspectorOutputFormatFlagHelp+shorthandFlagSuffix)
 		c.flagSet.StringVar(&c.InspectorOutputFormat, InspectorOutputFormatFlagLong, defaultInspectorOutputFormat, inspectorOutputFormatFlagHelp)
 
+		c.flagSet.BoolVar(&c.ListProviders, ListProvidersFlagShort, defaultListProviders, inspectorListProvidersFlagHelp+shorthandFlagSuffix)
+		c.flagSet.BoolVar(&c.ListProviders, ListProvidersFlagLong, defaultListProviders, inspectorListProvidersFlagHelp)
+
+	}
+
+	// Shared flags for plugin application types
+	if !appType.InspectorComponents {
+		c.flagSet.DurationVar(&c.MaxStale, MaxStaleFlagShort, defaultMaxStale, maxStaleFlagHelp+shorthandFlagSuffix)
+		c.flagSet.DurationVar(&c.MaxStale, MaxStaleFlagLong, defaultMaxStale, maxStaleFlagHelp)
+
+		c.flagSet.StringVar(&c.FallbackState, FallbackStateFlagShort, defaultFallbackState, fallbackStateFlagHelp+shorthandFlagSuffix)
+		c.flagSet.StringVar(&c.FallbackState, FallbackStateFlagLong, defaultFallbackState, fallbackStateFlagHelp)
 	}
 
 	// Shared flags for all application types
@@ -58,11 +170,62 @@ func (c *Config) handleFlagsConfig(appType AppType) error {
 	c.flagSet.BoolVar(&c.OmitOKComponents, OmitOKComponentsFlagShort, defaultOmitOKComponents, omitOKComponentsFlagHelp+shorthandFlagSuffix)
 	c.flagSet.BoolVar(&c.OmitOKComponents, OmitOKComponentsFlagLong, defaultOmitOKComponents, omitOKComponentsFlagHelp)
 
-	c.flagSet.StringVar(&c.URL, URLFlagShort, defaultURL, urlFlagHelp+shorthandFlagSuffix)
-	c.flagSet.StringVar(&c.URL, URLFlagLong, defaultURL, urlFlagHelp)
+	// The components plugin registers repeatable URL and filename flags.
+	if !appType.PluginComponents {
+		c.flagSet.StringVar(&c.URL, URLFlagShort, defaultURL, urlFlagHelp+shorthandFlagSuffix)
+		c.flagSet.StringVar(&c.URL, URLFlagLong, defaultURL, urlFlagHelp)
+
+		c.flagSet.StringVar(&c.Filename, FilenameFlagShort, defaultFilename, filenameFlagHelp+shorthandFlagSuffix)
+		c.flagSet.StringVar(&c.Filename, FilenameFlagLong, defaultFilename, filenameFlagHelp)
+	}
+
+	c.flagSet.StringVar(&c.CacheDir, CacheDirFlagShort, defaultCacheDir, cacheDirFlagHelp+shorthandFlagSuffix)
+	c.flagSet.StringVar(&c.CacheDir, CacheDirFlagLong, defaultCacheDir, cacheDirFlagHelp)
+
+	c.flagSet.StringVar(&c.ProxyURL, ProxyURLFlagShort, defaultProxyURL, proxyURLFlagHelp+shorthandFlagSuffix)
+	c.flagSet.StringVar(&c.ProxyURL, ProxyURLFlagLong, defaultProxyURL, proxyURLFlagHelp)
+
+	c.flagSet.StringVar(&c.CABundle, CABundleFlagShort, defaultCABundle, caBundleFlagHelp+shorthandFlagSuffix)
+	c.flagSet.StringVar(&c.CABundle, CABundleFlagLong, defaultCABundle, caBundleFlagHelp)
+
+	c.flagSet.StringVar(&c.ClientCert, ClientCertFlagShort, defaultClientCert, clientCertFlagHelp+shorthandFlagSuffix)
+	c.flagSet.StringVar(&c.ClientCert, ClientCertFlagLong, defaultClientCert, clientCertFlagHelp)
+
+	c.flagSet.StringVar(&c.ClientKey, ClientKeyFlagShort, defaultClientKey, clientKeyFlagHelp+shorthandFlagSuffix)
+	c.flagSet.StringVar(&c.ClientKey, ClientKeyFlagLong, defaultClientKey, clientKeyFlagHelp)
+
+	c.flagSet.StringVar(&c.MinTLSVersion, MinTLSVersionFlagShort, defaultMinTLSVersion, minTLSVersionFlagHelp+shorthandFlagSuffix)
+	c.flagSet.StringVar(&c.MinTLSVersion, MinTLSVersionFlagLong, defaultMinTLSVersion, minTLSVersionFlagHelp)
+
+	c.flagSet.BoolVar(&c.InsecureSkipVerify, InsecureSkipVerifyFlagShort, defaultInsecureSkipVerify, insecureSkipVerifyFlagHelp+shorthandFlagSuffix)
+	c.flagSet.BoolVar(&c.InsecureSkipVerify, InsecureSkipVerifyFlagLong, defaultInsecureSkipVerify, insecureSkipVerifyFlagHelp)
+
+	c.flagSet.Var(&c.headers, HeaderFlagShort, headerFlagHelp+shorthandFlagSuffix)
+	c.flagSet.Var(&c.headers, HeaderFlagLong, headerFlagHelp)
+
+	c.flagSet.StringVar(&c.BasicAuthUser, BasicAuthUserFlagShort, defaultBasicAuthUser, basicAuthUserFlagHelp+shorthandFlagSuffix)
+	c.flagSet.StringVar(&c.BasicAuthUser, BasicAuthUserFlagLong, defaultBasicAuthUser, basicAuthUserFlagHelp)
+
+	c.flagSet.StringVar(&c.BasicAuthPasswordFile, BasicAuthPasswordFileFlagShort, defaultBasicAuthPasswordFile, basicAuthPasswordFileFlagHelp+shorthandFlagSuffix)
+	c.flagSet.StringVar(&c.BasicAuthPasswordFile, BasicAuthPasswordFileFlagLong, defaultBasicAuthPasswordFile, basicAuthPasswordFileFlagHelp)
+
+	c.flagSet.StringVar(&c.BasicAuthPasswordEnv, BasicAuthPasswordEnvFlagShort, defaultBasicAuthPasswordEnv, basicAuthPasswordEnvFlagHelp+shorthandFlagSuffix)
+	c.flagSet.StringVar(&c.BasicAuthPasswordEnv, BasicAuthPasswordEnvFlagLong, defaultBasicAuthPasswordEnv, basicAuthPasswordEnvFlagHelp)
+
+	c.flagSet.StringVar(&c.BearerTokenFile, BearerTokenFileFlagShort, defaultBearerTokenFile, bearerTokenFileFlagHelp+shorthandFlagSuffix)
+	c.flagSet.StringVar(&c.BearerTokenFile, BearerTokenFileFlagLong, defaultBearerTokenFile, bearerTokenFileFlagHelp)
+
+	c.flagSet.StringVar(&c.BearerTokenEnv, BearerTokenEnvFlagShort, defaultBearerTokenEnv, bearerTokenEnvFlagHelp+shorthandFlagSuffix)
+	c.flagSet.StringVar(&c.BearerTokenEnv, BearerTokenEnvFlagLong, defaultBearerTokenEnv, bearerTokenEnvFlagHelp)
+
+	c.flagSet.IntVar(&c.Retries, RetriesFlagShort, defaultRetries, retriesFlagHelp+shorthandFlagSuffix)
+	c.flagSet.IntVar(&c.Retries, RetriesFlagLong, defaultRetries, retriesFlagHelp)
+
+	c.flagSet.DurationVar(&c.RetryDelay, RetryDelayFlagShort, defaultRetryDelay, retryDelayFlagHelp+shorthandFlagSuffix)
+	c.flagSet.DurationVar(&c.RetryDelay, RetryDelayFlagLong, defaultRetryDelay, retryDelayFlagHelp)
 
-	c.flagSet.StringVar(&c.Filename, FilenameFlagShort, defaultFilename, filenameFlagHelp+shorthandFlagSuffix)
-	c.flagSet.StringVar(&c.Filename, FilenameFlagLong, defaultFilename, filenameFlagHelp)
+	c.flagSet.StringVar(&c.Provider, ProviderFlagShort, defaultProvider, providerFlagHelp+shorthandFlagSuffix)
+	c.flagSet.StringVar(&c.Provider, ProviderFlagLong, defaultProvider, providerFlagHelp)
 
 	c.flagSet.BoolVar(&c.AllowUnknownJSONFields, AllowUnknownJSONFieldsFlagShort, defaultAllowUnknownJSONFields, allowUnknownJSONFieldsFlagHelp+shorthandFlagSuffix)
 	c.flagSet.BoolVar(&c.AllowUnknownJSONFields, AllowUnknownJSONFieldsFlagLong, defaultAllowUnknownJSONFields, allowUnknownJSONFieldsFlagHelp)
@@ -8,8 +8,13 @@
 package config
 
 import (
+	"crypto/tls"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/atc0005/check-statuspage/internal/statuspage"
+	"github.com/atc0005/go-nagios"
 )
 
 // Timeout converts the user-specified plugin runtime/execution timeout value
@@ -32,6 +37,48 @@ func (c Config) UserAgent() string {
 	)
 }
 
+// FetchOptions returns the user-specified settings used when retrieving a
+// Statuspage API/JSON feed from a URL.
+func (c Config) FetchOptions() statuspage.FetchOptions {
+	return statuspage.FetchOptions{
+		CacheDir:   c.CacheDir,
+		Retries:    c.Retries,
+		RetryDelay: c.RetryDelay,
+		MaxStale:   c.MaxStale,
+
+		ProxyURL:           c.ProxyURL,
+		CABundle:           c.CABundle,
+		ClientCert:         c.ClientCert,
+		ClientKey:          c.ClientKey,
+		MinTLSVersion:      c.minTLSVersion(),
+		InsecureSkipVerify: c.InsecureSkipVerify,
+
+		Headers:           c.requestHeaders(),
+		BasicAuthUser:     c.BasicAuthUser,
+		BasicAuthPassword: c.basicAuthPassword,
+		BearerToken:       c.bearerToken,
+	}
+}
+
+// minTLSVersion converts the user-specified minimum TLS version to the
+// equivalent crypto/tls package value. Zero (the Go default) is returned for
+// an unsupported value; configuration validation prevents use of an
+// unsupported value.
+func (c Config) minTLSVersion() uint16 {
+	switch c.MinTLSVersion {
+	case TLSVersion10:
+		return tls.VersionTLS10
+	case TLSVersion11:
+		return tls.VersionTLS11
+	case TLSVersion12:
+		return tls.VersionTLS12
+	case TLSVersion13:
+		return tls.VersionTLS13
+	default:
+		return 0
+	}
+}
+
 // ComponentFilter returns the user-specified component filter values.
 // Combinations of component group and subcomponents, just a group or the list
 // of individual components that should be monitored are returned, wrapped in
@@ -39,8 +86,125 @@ func (c Config) UserAgent() string {
 // incompatible flag types.
 func (c Config) ComponentFilter() ComponentFilter {
 	return ComponentFilter{
-		Group:      c.componentGroup,
-		Components: c.componentsList,
+		Groups:            c.componentGroups,
+		Components:        c.componentsList,
+		MatchMode:         c.MatchMode,
+		ExcludeGroups:     c.excludeGroups,
+		ExcludeComponents: c.excludeComponents,
+	}
+}
+
+// FallbackServiceState returns the Nagios ServiceState applied to plugin
+// results evaluated using the last good copy of a feed.
+func (c Config) FallbackServiceState() nagios.ServiceState {
+	switch strings.ToLower(c.FallbackState) {
+	case FallbackStateUnknown:
+		return nagios.ServiceState{
+			Label:    nagios.StateUNKNOWNLabel,
+			ExitCode: nagios.StateUNKNOWNExitCode,
+		}
+	default:
+		return nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		}
+	}
+}
+
+// ComponentGroupRollup reflects the user-specified mode used to evaluate
+// component groups as a single unit. The fields of this type mirror those of
+// the GroupRollup type of the components package.
+type ComponentGroupRollup struct {
+	Mode   string
+	Quorum int
+}
+
+// GroupRollup returns the user-specified group rollup mode (normalized to
+// lowercase) along with the quorum percentage used by the quorum mode.
+func (c Config) GroupRollup() ComponentGroupRollup {
+	return ComponentGroupRollup{
+		Mode:   strings.ToLower(c.GroupRollupMode),
+		Quorum: c.GroupQuorum,
+	}
+}
+
+// supportedFallbackStates returns a list of valid states applied to plugin
+// results evaluated using the last good copy of a feed. This list is
+// intended to be used for validating the user-specified fallback state.
+func supportedFallbackStates() []string {
+	return []string{
+		FallbackStateWarning,
+		FallbackStateUnknown,
+	}
+}
+
+// supportedMatchModes returns a list of valid modes used to match component
+// and component group names. This list is intended to be used for validating
+// the user-specified match mode.
+func supportedMatchModes() []string {
+	return []string{
+		MatchModeExact,
+		MatchModeCaseInsensitive,
+		MatchModeGlob,
+		MatchModeRegex,
+	}
+}
+
+// supportedGroupRollupModes returns a list of valid modes used to evaluate
+// component groups as a single unit. This list is intended to be used for
+// validating the user-specified group rollup mode.
+func supportedGroupRollupModes() []string {
+	return []string{
+		GroupRollupNone,
+		GroupRollupParent,
+		GroupRollupWorstOf,
+		GroupRollupQuorum,
+	}
+}
+
+// supportedStatusMapStatuses returns a list of valid component statuses
+// which may be mapped to a different state. This list is intended to be used
+// for validating the user-specified status map.
+func supportedStatusMapStatuses() []string {
+	return []string{
+		ComponentStatusDegradedPerformance,
+		ComponentStatusPartialOutage,
+		ComponentStatusMajorOutage,
+		ComponentStatusUnderMaintenance,
+	}
+}
+
+// supportedStatusMapStates returns a list of valid states which component
+// statuses may be mapped to. This list is intended to be used for validating
+// the user-specified status map.
+func supportedStatusMapStates() []string {
+	return []string{
+		nagios.StateOKLabel,
+		nagios.StateWARNINGLabel,
+		nagios.StateCRITICALLabel,
+		nagios.StateUNKNOWNLabel,
+	}
+}
+
+// supportedTLSVersions returns a list of valid minimum TLS versions. This
+// list is intended to be used for validating the user-specified minimum TLS
+// version.
+func supportedTLSVersions() []string {
+	return []string{
+		TLSVersion10,
+		TLSVersion11,
+		TLSVersion12,
+		TLSVersion13,
+	}
+}
+
+// supportedProxySchemes returns a list of valid proxy URL schemes. This list
+// is intended to be used for validating the user-specified proxy URL.
+func supportedProxySchemes() []string {
+	return []string{
+		"http",
+		"https",
+		"socks5",
 	}
 }
 
@@ -0,0 +1,159 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// headerFlag is a custom type that satisfies the flag.Value interface in
+// order to accept multiple HTTP header values. Unlike multiValueStringFlag,
+// values are not split on commas as header values may legitimately contain
+// them.
+type headerFlag []string
+
+// String returns a comma separated string consisting of all specified header
+// names. Header values are omitted as they may contain secrets.
+func (hf *headerFlag) String() string {
+
+	// From the `flag` package docs:
+	// "The flag package may call the String method with a zero-valued
+	// receiver, such as a nil pointer."
+	if hf == nil {
+		return ""
+	}
+
+	names := make([]string, 0, len(*hf))
+	for _, header := range *hf {
+		name, _, _ := strings.Cut(header, ":")
+		names = append(names, strings.TrimSpace(name))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Set is called once by the flag package, in command line order, for each
+// flag present.
+func (hf *headerFlag) Set(value string) error {
+	if _, _, err := parseHeader(value); err != nil {
+		return err
+	}
+
+	*hf = append(*hf, value)
+
+	return nil
+}
+
+// parseHeader splits the given "Name: Value" string into a canonical header
+// name and value. An error is returned if the header name is missing or
+// invalid.
+func parseHeader(header string) (string, string, error) {
+	name, value, found := strings.Cut(header, ":")
+	name = strings.TrimSpace(name)
+
+	switch {
+	case !found:
+		return "", "", fmt.Errorf(
+			"invalid header %q; expected Name: Value format",
+			header,
+		)
+
+	case name == "" || strings.ContainsAny(name, " \t\r\n"):
+		return "", "", fmt.Errorf(
+			"invalid header name %q",
+			name,
+		)
+
+	case strings.ContainsAny(value, "\r\n"):
+		return "", "", fmt.Errorf(
+			"invalid value for header %q; line breaks are not permitted",
+			name,
+		)
+	}
+
+	return http.CanonicalHeaderKey(name), strings.TrimSpace(value), nil
+}
+
+// requestHeaders returns the user-specified HTTP headers. Validation ensures
+// that all header values are well-formed.
+func (c Config) requestHeaders() http.Header {
+	if len(c.headers) == 0 {
+		return nil
+	}
+
+	headers := make(http.Header, len(c.headers))
+	for _, header := range c.headers {
+		name, value, err := parseHeader(header)
+		if err != nil {
+			continue
+		}
+
+		headers.Add(name, value)
+	}
+
+	return headers
+}
+
+// readSecret returns a secret value read from the specified file or
+// environment variable. An empty string is returned if neither is specified.
+// An error is returned if the specified file cannot be read, the specified
+// environment variable is not set or the secret is empty. Leading and
+// trailing whitespace (e.g., a trailing newline in a file) is removed.
+func readSecret(filename string, envVar string) (string, error) {
+
+	var secret string
+
+	switch {
+	case filename != "":
+		data, err := os.ReadFile(filepath.Clean(filename))
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret from file: %w", err)
+		}
+		secret = string(data)
+
+	case envVar != "":
+		value, ok := os.LookupEnv(envVar)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", envVar)
+		}
+		secret = value
+
+	default:
+		return "", nil
+	}
+
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return "", fmt.Errorf("empty secret value")
+	}
+
+	return secret, nil
+}
+
+// resolveSecrets reads the basic authentication password and bearer token
+// (if specified) from the user-specified files or environment variables.
+func (c *Config) resolveSecrets() error {
+
+	password, err := readSecret(c.BasicAuthPasswordFile, c.BasicAuthPasswordEnv)
+	if err != nil {
+		return fmt.Errorf("failed to read basic authentication password: %w", err)
+	}
+	c.basicAuthPassword = password
+
+	token, err := readSecret(c.BearerTokenFile, c.BearerTokenEnv)
+	if err != nil {
+		return fmt.Errorf("failed to read bearer token: %w", err)
+	}
+	c.bearerToken = token
+
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atc0005/check-statuspage/internal/textutils"
+	"github.com/atc0005/go-nagios"
+)
+
+// severityMaxStateKey is the rule key used to specify the most severe state
+// for a component via the component severity flag.
+const severityMaxStateKey string = "max"
+
+// ComponentSeverityOverride reflects user-specified severity rules for a
+// component or component group. The fields of this type mirror those of the
+// SeverityOverride type of the components package.
+type ComponentSeverityOverride struct {
+
+	// Target is the name or ID value of a component or component group.
+	Target string
+
+	// Statuses maps component statuses to the ServiceState used in place of
+	// the status map.
+	Statuses map[string]nagios.ServiceState
+
+	// MaxState is the most severe ServiceState for the component. Not
+	// applied if the Label is empty.
+	MaxState nagios.ServiceState
+}
+
+// severityOverrideFlag is a custom type that satisfies the flag.Value
+// interface in order to accept multiple component severity override values.
+// Unlike multiValueStringFlag, values are not split on commas as commas
+// separate the rules for a single component.
+type severityOverrideFlag []string
+
+// String returns a semicolon separated string consisting of all specified
+// severity override values.
+func (sof *severityOverrideFlag) String() string {
+
+	// From the `flag` package docs:
+	// "The flag package may call the String method with a zero-valued
+	// receiver, such as a nil pointer."
+	if sof == nil {
+		return ""
+	}
+
+	return strings.Join(*sof, "; ")
+}
+
+// Set is called once by the flag package, in command line order, for each
+// flag present.
+func (sof *severityOverrideFlag) Set(value string) error {
+	if _, err := parseSeverityOverride(value); err != nil {
+		return err
+	}
+
+	*sof = append(*sof, value)
+
+	return nil
+}
+
+// parseSeverityOverride parses the given "target:rule,rule" string where each
+// rule is either a "status=state" pair or "max=state". The target is split
+// from the rules at the last colon as component names may contain colons. An
+// error is returned if the target or rules are missing or invalid.
+func parseSeverityOverride(value string) (ComponentSeverityOverride, error) {
+
+	var override ComponentSeverityOverride
+
+	idx := strings.LastIndex(value, ":")
+	if idx < 0 {
+		return override, fmt.Errorf(
+			"invalid value %q provided to %s flag; expected target:status=state format",
+			value,
+			ComponentSeverityFlagLong,
+		)
+	}
+
+	override.Target = strings.TrimSpace(value[:idx])
+	if override.Target == "" {
+		return override, fmt.Errorf(
+			"invalid value %q provided to %s flag; missing component or component group",
+			value,
+			ComponentSeverityFlagLong,
+		)
+	}
+
+	for _, rule := range strings.Split(value[idx+1:], ",") {
+		key, state, _ := strings.Cut(rule, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		state = strings.ToUpper(strings.TrimSpace(state))
+
+		switch {
+		case key == severityMaxStateKey:
+			if override.MaxState.Label != "" {
+				return override, fmt.Errorf(
+					"%s rule provided to %s flag more than once for %q",
+					severityMaxStateKey,
+					ComponentSeverityFlagLong,
+					override.Target,
+				)
+			}
+
+			if !textutils.InList(state, supportedStatusMapStates(), false) {
+				return override, fmt.Errorf(
+					"invalid state %q provided to %s flag for %s rule; expected one of %v",
+					state,
+					ComponentSeverityFlagLong,
+					severityMaxStateKey,
+					supportedStatusMapStates(),
+				)
+			}
+
+			override.MaxState = nagios.ServiceState{
+				Label:    state,
+				ExitCode: nagios.StateLabelToExitCode(state),
+			}
+
+		default:
+			status, state, err := parseStatusMapping(rule, ComponentSeverityFlagLong)
+			if err != nil {
+				return override, err
+			}
+
+			if _, ok := override.Statuses[status]; ok {
+				return override, fmt.Errorf(
+					"status %q provided to %s flag more than once for %q",
+					status,
+					ComponentSeverityFlagLong,
+					override.Target,
+				)
+			}
+
+			if override.Statuses == nil {
+				override.Statuses = make(map[string]nagios.ServiceState)
+			}
+
+			override.Statuses[status] = nagios.ServiceState{
+				Label:    state,
+				ExitCode: nagios.StateLabelToExitCode(state),
+			}
+		}
+	}
+
+	return override, nil
+}
+
+// validateSeverityOverrides asserts that the user-specified component
+// severity override values are well-formed and that each component or
+// component group is specified only once.
+func (c Config) validateSeverityOverrides() error {
+	targets := make([]string, 0, len(c.severityOverrides))
+
+	for _, value := range c.severityOverrides {
+		override, err := parseSeverityOverride(value)
+		if err != nil {
+			return err
+		}
+
+		if textutils.InList(override.Target, targets, true) {
+			return fmt.Errorf(
+				"component or component group %q provided to %s flag more than once",
+				override.Target,
+				ComponentSeverityFlagLong,
+			)
+		}
+		targets = append(targets, override.Target)
+	}
+
+	return nil
+}
+
+// SeverityOverrides returns the user-specified component severity overrides
+// in the order specified. Validation ensures that all severity override
+// values are well-formed.
+func (c Config) SeverityOverrides() []ComponentSeverityOverride {
+	overrides := make([]ComponentSeverityOverride, 0, len(c.severityOverrides))
+
+	for _, value := range c.severityOverrides {
+		override, err := parseSeverityOverride(value)
+		if err != nil {
+			continue
+		}
+
+		overrides = append(overrides, override)
+	}
+
+	return overrides
+}
@@ -0,0 +1,103 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atc0005/check-statuspage/internal/textutils"
+	"github.com/atc0005/go-nagios"
+)
+
+// parseStatusMapping splits the given "status=state" string into a
+// lowercase component status and an uppercase state label. An error
+// referencing the given flag name is returned if the status or state is
+// missing or not supported.
+func parseStatusMapping(mapping string, flagName string) (string, string, error) {
+	status, state, found := strings.Cut(mapping, "=")
+	status = strings.ToLower(strings.TrimSpace(status))
+	state = strings.ToUpper(strings.TrimSpace(state))
+
+	switch {
+	case !found:
+		return "", "", fmt.Errorf(
+			"invalid value %q provided to %s flag; expected status=state format",
+			mapping,
+			flagName,
+		)
+
+	case !textutils.InList(status, supportedStatusMapStatuses(), false):
+		return "", "", fmt.Errorf(
+			"invalid status %q provided to %s flag; expected one of %v",
+			status,
+			flagName,
+			supportedStatusMapStatuses(),
+		)
+
+	case !textutils.InList(state, supportedStatusMapStates(), false):
+		return "", "", fmt.Errorf(
+			"invalid state %q provided to %s flag for status %q; expected one of %v",
+			state,
+			flagName,
+			status,
+			supportedStatusMapStates(),
+		)
+	}
+
+	return status, state, nil
+}
+
+// validateStatusMap asserts that the user-specified status map values are
+// well-formed and that each status is mapped only once.
+func (c Config) validateStatusMap() error {
+	seen := make(map[string]struct{}, len(c.statusMap))
+
+	for _, mapping := range c.statusMap {
+		status, _, err := parseStatusMapping(mapping, StatusMapFlagLong)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := seen[status]; ok {
+			return fmt.Errorf(
+				"status %q provided to %s flag more than once",
+				status,
+				StatusMapFlagLong,
+			)
+		}
+		seen[status] = struct{}{}
+	}
+
+	return nil
+}
+
+// StatusMap returns the user-specified mapping of component statuses to
+// Nagios ServiceState values. Only statuses specified by the user are
+// present; nil is returned if the status map flag was not specified.
+// Validation ensures that all status map values are well-formed.
+func (c Config) StatusMap() map[string]nagios.ServiceState {
+	if len(c.statusMap) == 0 {
+		return nil
+	}
+
+	statusMap := make(map[string]nagios.ServiceState, len(c.statusMap))
+	for _, mapping := range c.statusMap {
+		status, state, err := parseStatusMapping(mapping, StatusMapFlagLong)
+		if err != nil {
+			continue
+		}
+
+		statusMap[status] = nagios.ServiceState{
+			Label:    state,
+			ExitCode: nagios.StateLabelToExitCode(state),
+		}
+	}
+
+	return statusMap
+}
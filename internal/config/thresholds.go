@@ -0,0 +1,108 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// thresholdPercentSuffix is the suffix used to indicate that a threshold
+// applies to the percentage of evaluated components in a non-operational
+// status.
+const thresholdPercentSuffix string = "%"
+
+// ProblemComponentsThreshold reflects a user-specified threshold for the
+// number or percentage of evaluated components in a non-operational status.
+// The fields of this type mirror those of the ProblemThreshold type of the
+// components package.
+type ProblemComponentsThreshold struct {
+
+	// Range is the threshold in the Nagios range format without a percent
+	// suffix. Empty if the threshold was not specified.
+	Range string
+
+	// Percent indicates whether the Range applies to the percentage of
+	// evaluated components in a non-operational status.
+	Percent bool
+}
+
+// parseProblemComponentsThreshold parses the given Nagios range with an
+// optional percent suffix. An error referencing the given flag name is
+// returned if the value is not a valid Nagios range. A zero value threshold
+// is returned for an empty value.
+func parseProblemComponentsThreshold(value string, flagName string) (ProblemComponentsThreshold, error) {
+
+	var threshold ProblemComponentsThreshold
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return threshold, nil
+	}
+
+	if strings.HasSuffix(value, thresholdPercentSuffix) {
+		threshold.Percent = true
+		value = strings.TrimSuffix(value, thresholdPercentSuffix)
+	}
+
+	if nagios.ParseRangeString(value) == nil {
+		return ProblemComponentsThreshold{}, fmt.Errorf(
+			"invalid value %q provided to %s flag; expected Nagios range format (e.g., 5, 10:, @0:3) with optional %s suffix: %w",
+			value,
+			flagName,
+			thresholdPercentSuffix,
+			nagios.ErrInvalidRangeThreshold,
+		)
+	}
+
+	threshold.Range = value
+
+	return threshold, nil
+}
+
+// validateProblemThresholds asserts that the user-specified warning and
+// critical thresholds (if any) are valid Nagios ranges.
+func (c Config) validateProblemThresholds() error {
+	if _, err := parseProblemComponentsThreshold(c.warningThreshold, WarningThresholdFlagLong); err != nil {
+		return err
+	}
+
+	if _, err := parseProblemComponentsThreshold(c.criticalThreshold, CriticalThresholdFlagLong); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// WarningThreshold returns the user-specified threshold for evaluated
+// components in a non-operational status which results in a WARNING state. A
+// zero value is returned if the threshold was not specified. Validation
+// ensures that the threshold is well-formed.
+func (c Config) WarningThreshold() ProblemComponentsThreshold {
+	threshold, _ := parseProblemComponentsThreshold(c.warningThreshold, WarningThresholdFlagLong)
+
+	return threshold
+}
+
+// CriticalThreshold returns the user-specified threshold for evaluated
+// components in a non-operational status which results in a CRITICAL state.
+// A zero value is returned if the threshold was not specified. Validation
+// ensures that the threshold is well-formed.
+func (c Config) CriticalThreshold() ProblemComponentsThreshold {
+	threshold, _ := parseProblemComponentsThreshold(c.criticalThreshold, CriticalThresholdFlagLong)
+
+	return threshold
+}
+
+// HasProblemThresholds indicates whether the user specified a warning or
+// critical threshold for evaluated components in a non-operational status.
+func (c Config) HasProblemThresholds() bool {
+	return c.WarningThreshold().Range != "" || c.CriticalThreshold().Range != ""
+}
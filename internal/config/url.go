@@ -0,0 +1,214 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/atc0005/check-statuspage/internal/providers"
+)
+
+// Statuspage API/JSON feed path prefix and the endpoints used by the
+// application types provided by this project.
+const (
+	apiPathPrefix                   string = "/api/v2/"
+	apiEndpointComponents           string = "components.json"
+	apiEndpointStatus               string = "status.json"
+	apiEndpointIncidentsUnresolved  string = "incidents/unresolved.json"
+	apiEndpointMaintenancesUpcoming string = "scheduled-maintenances/upcoming.json"
+	apiEndpointMaintenancesActive   string = "scheduled-maintenances/active.json"
+	apiEndpointSummary              string = "summary.json"
+)
+
+// apiEndpointAlternates indexes the other Statuspage API/JSON feed endpoints
+// which provide the data needed by the application type using the given
+// (default) endpoint. The summary.json endpoint is not an alternate for the
+// components.json endpoint since the additional fields it provides are
+// rejected when decoding a components Set unless unknown fields are allowed.
+var apiEndpointAlternates = map[string][]string{
+	apiEndpointStatus:               {apiEndpointSummary},
+	apiEndpointIncidentsUnresolved:  {apiEndpointSummary},
+	apiEndpointMaintenancesUpcoming: {apiEndpointMaintenancesActive, apiEndpointSummary},
+}
+
+// statuspageHostSuffix is the domain used for status pages hosted by
+// Statuspage without a custom domain. Pages are reachable using the page ID
+// as the subdomain (e.g., https://kctbh9vrtdwd.statuspage.io).
+const statuspageHostSuffix string = ".statuspage.io"
+
+// pageIDRegex matches a bare Statuspage page ID value (e.g., kctbh9vrtdwd).
+var pageIDRegex = regexp.MustCompile(`^[a-z0-9]{12}$`)
+
+// apiEndpoint returns the Statuspage API/JSON feed endpoint used by the
+// specified application type.
+func apiEndpoint(appType AppType) string {
+	switch {
+	case appType.PluginIncidents:
+		return apiEndpointIncidentsUnresolved
+
+	case appType.PluginMaintenance:
+		return apiEndpointMaintenancesUpcoming
+
+	case appType.PluginStatus:
+		return apiEndpointStatus
+
+	default:
+		return apiEndpointComponents
+	}
+}
+
+// supportedEndpoints returns the Statuspage API/JSON feed endpoints which may
+// be used in place of the given (default) endpoint, including the endpoint
+// itself.
+func supportedEndpoints(endpoint string) []string {
+	return append([]string{endpoint}, apiEndpointAlternates[endpoint]...)
+}
+
+// normalizeFeedURL converts the given value to a fully-qualified Statuspage
+// API/JSON feed URL using the given endpoint. The value may be one of:
+//
+//   - a fully-qualified API/JSON feed URL (returned as-is if the feed is
+//     supported for the given endpoint)
+//   - a base status page URL (e.g., https://www.githubstatus.com)
+//   - a status page hostname (e.g., www.githubstatus.com)
+//   - a URL for the API path (e.g., https://www.githubstatus.com/api/v2/)
+//   - a bare Statuspage page ID (e.g., kctbh9vrtdwd)
+//
+// An error is returned if the value cannot be converted or if it refers to a
+// different Statuspage API/JSON feed than the given endpoint (or one of its
+// alternates). Otherwise the data from an unrelated feed (e.g., the
+// components.json feed given to the incidents plugin) would be decoded
+// without error as an empty result.
+func normalizeFeedURL(value string, endpoint string) (string, error) {
+
+	value = strings.TrimSpace(value)
+
+	switch {
+	case value == "":
+		return "", fmt.Errorf("empty URL value provided to %s flag", URLFlagLong)
+
+	case pageIDRegex.MatchString(value):
+		return "https://" + value + statuspageHostSuffix + apiPathPrefix + endpoint, nil
+
+	// Assume a hostname was provided without a scheme.
+	case !strings.Contains(value, "://"):
+		value = "https://" + value
+	}
+
+	parsedURL, err := url.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf(
+			"invalid URL %q provided to %s flag: %w",
+			value,
+			URLFlagLong,
+			err,
+		)
+	}
+
+	if parsedURL.Host == "" {
+		return "", fmt.Errorf(
+			"invalid URL %q provided to %s flag; missing hostname",
+			value,
+			URLFlagLong,
+		)
+	}
+
+	switch path := strings.TrimSuffix(parsedURL.Path, "/"); {
+	case path == "":
+		parsedURL.Path = apiPathPrefix + endpoint
+
+	case path+"/" == apiPathPrefix:
+		parsedURL.Path = apiPathPrefix + endpoint
+
+	case strings.Contains(path, apiPathPrefix) && strings.HasSuffix(path, ".json"):
+		// A specific feed was provided; leave as-is if supported.
+		requested := path[strings.LastIndex(path, apiPathPrefix)+len(apiPathPrefix):]
+		supported := supportedEndpoints(endpoint)
+		if !slices.Contains(supported, requested) {
+			return "", fmt.Errorf(
+				"unsupported API/JSON feed %q in URL %q provided to %s flag; expected one of %q",
+				requested,
+				value,
+				URLFlagLong,
+				supported,
+			)
+		}
+
+	default:
+		// Some other path was provided (e.g., a proxy or mirror); leave as-is.
+	}
+
+	return parsedURL.String(), nil
+}
+
+// normalizeURL replaces the user-specified URL value with the fully-qualified
+// Statuspage API/JSON feed URL for the specified application type. This is
+// applied to the primary feed and any additional feeds. The user-specified
+// value is retained for reference.
+func (c *Config) normalizeURL(appType AppType) error {
+
+	endpoint := apiEndpoint(appType)
+
+	if c.URL != "" {
+		feedURL, err := normalizeFeedURL(c.URL, endpoint)
+		if err != nil {
+			return err
+		}
+
+		c.URLProvided = c.URL
+		c.URL = feedURL
+	}
+
+	for i := range c.additionalFeeds {
+		if c.additionalFeeds[i].URL == "" {
+			continue
+		}
+
+		feedURL, err := normalizeFeedURL(c.additionalFeeds[i].URL, endpoint)
+		if err != nil {
+			return err
+		}
+
+		c.additionalFeeds[i].URLProvided = c.additionalFeeds[i].URL
+		c.additionalFeeds[i].URL = feedURL
+	}
+
+	return nil
+}
+
+// resolveProvider applies the details for the user-specified provider (if
+// any) from the provider catalog. The base status page URL for the provider
+// is used if neither a URL nor a filename was specified.
+func (c *Config) resolveProvider() error {
+
+	if c.Provider == "" {
+		return nil
+	}
+
+	provider, err := providers.Lookup(c.Provider)
+	if err != nil {
+		return fmt.Errorf(
+			"invalid value provided to %s flag: %w",
+			ProviderFlagLong,
+			err,
+		)
+	}
+
+	c.ProviderName = provider.Name
+	c.ExpectedPageID = provider.PageID
+
+	if c.URL == "" && c.Filename == "" {
+		c.URL = provider.URL
+	}
+
+	return nil
+}
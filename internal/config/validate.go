@@ -9,7 +9,12 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
+
+	"github.com/atc0005/check-statuspage/internal/providers"
+	"github.com/atc0005/check-statuspage/internal/statuspage"
+	"github.com/atc0005/check-statuspage/internal/textutils"
 )
 
 // validate verifies all Config struct fields have been provided acceptable
@@ -22,7 +27,7 @@ func (c Config) validate(appType AppType) error {
 
 		componentOrGroupSpecified := func() bool {
 			switch {
-			case c.componentGroup != "":
+			case len(c.componentGroups) > 0:
 				return true
 			case len(c.componentsList) > 0:
 				return true
@@ -51,28 +56,112 @@ func (c Config) validate(appType AppType) error {
 			)
 		}
 
-		// Assert that group, component flags were not provided only
-		// whitespace characters.
+		if err := c.validateComponentFilterValues(); err != nil {
+			return err
+		}
+
+		if err := c.validateAdditionalFeeds(); err != nil {
+			return err
+		}
+
+		if err := c.validateStatusMap(); err != nil {
+			return err
+		}
+
+		if err := c.validateSeverityOverrides(); err != nil {
+			return err
+		}
+
+		if err := c.validateProblemThresholds(); err != nil {
+			return err
+		}
+
+		supportedRollupModes := supportedGroupRollupModes()
+		if !textutils.InList(c.GroupRollupMode, supportedRollupModes, true) {
+			return fmt.Errorf(
+				"invalid group rollup mode specified; got %v, expected one of %v",
+				c.GroupRollupMode,
+				supportedRollupModes,
+			)
+		}
+
+		if c.GroupQuorum < 1 || c.GroupQuorum > 100 {
+			return fmt.Errorf(
+				"invalid value %d provided to %s flag; must be between 1 and 100",
+				c.GroupQuorum,
+				GroupQuorumFlagLong,
+			)
+		}
+
+		if c.Concurrency < 1 {
+			return fmt.Errorf(
+				"invalid value %d provided to %s flag; must be 1 or greater",
+				c.Concurrency,
+				ConcurrencyFlagLong,
+			)
+		}
+
 		switch {
-		case c.componentGroup != "":
-			if strings.TrimSpace(c.componentGroup) == "" {
-				return fmt.Errorf(
-					"whitespace only group value provided to %s flag",
-					ComponentGroupFlagLong,
-				)
-			}
+		case c.StaleWarning < 0:
+			return fmt.Errorf(
+				"invalid value %v provided to %s flag; must be 0 or greater",
+				c.StaleWarning,
+				StaleWarningFlagLong,
+			)
 
-		case len(c.componentsList) > 0:
-			for _, component := range c.componentsList {
-				if strings.TrimSpace(component) == "" {
-					return fmt.Errorf(
-						"whitespace only component value provided to %s flag",
-						ComponentsListFlagLong,
-					)
-				}
-			}
+		case c.MinProblemDuration < 0:
+			return fmt.Errorf(
+				"invalid value %v provided to %s flag; must be 0 or greater",
+				c.MinProblemDuration,
+				MinProblemDurationFlagLong,
+			)
+
+		case c.StaleCritical < 0:
+			return fmt.Errorf(
+				"invalid value %v provided to %s flag; must be 0 or greater",
+				c.StaleCritical,
+				StaleCriticalFlagLong,
+			)
+
+		case c.StaleWarning > 0 && c.StaleCritical > 0 && c.StaleCritical < c.StaleWarning:
+			return fmt.Errorf(
+				"invalid combination of flags; %s value %v is less than %s value %v",
+				StaleCriticalFlagLong,
+				c.StaleCritical,
+				StaleWarningFlagLong,
+				c.StaleWarning,
+			)
 		}
 
+	case appType.PluginIncidents:
+
+		// Component and group flags are optional; all unresolved incidents
+		// are evaluated if neither is specified.
+		if err := c.validateComponentFilterValues(); err != nil {
+			return err
+		}
+
+	case appType.PluginMaintenance:
+
+		// Component and group flags are optional; all scheduled maintenances
+		// are evaluated if neither is specified.
+		if err := c.validateComponentFilterValues(); err != nil {
+			return err
+		}
+
+		if c.MaintenanceWithin <= 0 {
+			return fmt.Errorf(
+				"invalid lookahead duration %v provided to %s flag",
+				c.MaintenanceWithin,
+				MaintenanceWithinFlag,
+			)
+		}
+
+	case appType.PluginStatus:
+
+		// No plugin-specific flags require validation; the page status
+		// indicator is evaluated as-is.
+
 	case appType.InspectorComponents:
 
 		supportedFormats := supportedInspectorOutputFormats()
@@ -96,10 +185,55 @@ func (c Config) validate(appType AppType) error {
 
 	}
 
+	// shared validation checks for plugin application types
+	if !appType.InspectorComponents {
+		if c.MaxStale < 0 {
+			return fmt.Errorf(
+				"invalid value %v provided to %s flag; must be 0 or greater",
+				c.MaxStale,
+				MaxStaleFlagLong,
+			)
+		}
+
+		if c.MaxStale > 0 && c.CacheDir == "" {
+			return fmt.Errorf(
+				"%s flag requires %s flag",
+				MaxStaleFlagLong,
+				CacheDirFlagLong,
+			)
+		}
+
+		supportedStates := supportedFallbackStates()
+		if !textutils.InList(c.FallbackState, supportedStates, true) {
+			return fmt.Errorf(
+				"invalid fallback state specified; got %v, expected one of %v",
+				c.FallbackState,
+				supportedStates,
+			)
+		}
+	}
+
 	// shared validation checks
 
-	if c.URL == "" && c.Filename == "" {
-		return fmt.Errorf("components feed URL or filename not provided")
+	if c.URL == "" && c.Filename == "" && c.Provider == "" {
+		return fmt.Errorf("feed URL, filename or provider not provided")
+	}
+
+	if c.Provider != "" {
+		if _, err := providers.Lookup(c.Provider); err != nil {
+			return fmt.Errorf(
+				"invalid value provided to %s flag: %w",
+				ProviderFlagLong,
+				err,
+			)
+		}
+	}
+
+	if c.Provider != "" && len(c.additionalFeeds) > 0 {
+		return fmt.Errorf(
+			"invalid combination of flags; %s flag is incompatible with multiple feeds",
+			ProviderFlagLong,
+		)
 	}
 
 	if c.URL != "" && c.Filename != "" {
@@ -114,6 +248,30 @@ func (c Config) validate(appType AppType) error {
 		return fmt.Errorf("invalid timeout value %d provided", c.Timeout())
 	}
 
+	if err := c.validateTransportSettings(); err != nil {
+		return err
+	}
+
+	if err := c.validateRequestAuth(); err != nil {
+		return err
+	}
+
+	if c.Retries < 0 {
+		return fmt.Errorf(
+			"invalid value %d provided to %s flag; must be 0 or greater",
+			c.Retries,
+			RetriesFlagLong,
+		)
+	}
+
+	if c.RetryDelay < 0 {
+		return fmt.Errorf(
+			"invalid value %v provided to %s flag; must be 0 or greater",
+			c.RetryDelay,
+			RetryDelayFlagLong,
+		)
+	}
+
 	requestedLoggingLevel := strings.ToLower(c.LoggingLevel)
 	if _, ok := loggingLevels[requestedLoggingLevel]; !ok {
 		return fmt.Errorf("invalid logging level %q", c.LoggingLevel)
@@ -123,3 +281,242 @@ func (c Config) validate(appType AppType) error {
 	return nil
 
 }
+
+// validateTransportSettings asserts that valid proxy and TLS settings were
+// provided for retrieving feed URLs.
+func (c Config) validateTransportSettings() error {
+
+	if c.ProxyURL != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		switch {
+		case err != nil:
+			return fmt.Errorf(
+				"invalid value %q provided to %s flag: %w",
+				c.ProxyURL,
+				ProxyURLFlagLong,
+				err,
+			)
+
+		case !textutils.InList(proxyURL.Scheme, supportedProxySchemes(), true):
+			return fmt.Errorf(
+				"invalid value %q provided to %s flag; expected URL with one of %v schemes",
+				c.ProxyURL,
+				ProxyURLFlagLong,
+				supportedProxySchemes(),
+			)
+
+		case proxyURL.Host == "":
+			return fmt.Errorf(
+				"invalid value %q provided to %s flag; missing host",
+				c.ProxyURL,
+				ProxyURLFlagLong,
+			)
+		}
+	}
+
+	if (c.ClientCert == "") != (c.ClientKey == "") {
+		return fmt.Errorf(
+			"invalid combination of flags; %s and %s flags must be specified together",
+			ClientCertFlagLong,
+			ClientKeyFlagLong,
+		)
+	}
+
+	supportedVersions := supportedTLSVersions()
+	if !textutils.InList(c.MinTLSVersion, supportedVersions, false) {
+		return fmt.Errorf(
+			"invalid minimum TLS version specified; got %v, expected one of %v",
+			c.MinTLSVersion,
+			supportedVersions,
+		)
+	}
+
+	return nil
+}
+
+// validateRequestAuth asserts that a valid combination of header and
+// authentication flags was provided for retrieving feed URLs.
+func (c Config) validateRequestAuth() error {
+
+	passwordSpecified := c.BasicAuthPasswordFile != "" || c.BasicAuthPasswordEnv != ""
+	tokenSpecified := c.BearerTokenFile != "" || c.BearerTokenEnv != ""
+
+	switch {
+	case c.BasicAuthPasswordFile != "" && c.BasicAuthPasswordEnv != "":
+		return fmt.Errorf(
+			"invalid combination of flags; %s flag is incompatible with %s flag",
+			BasicAuthPasswordFileFlagLong,
+			BasicAuthPasswordEnvFlagLong,
+		)
+
+	case c.BearerTokenFile != "" && c.BearerTokenEnv != "":
+		return fmt.Errorf(
+			"invalid combination of flags; %s flag is incompatible with %s flag",
+			BearerTokenFileFlagLong,
+			BearerTokenEnvFlagLong,
+		)
+
+	case c.BasicAuthUser != "" && !passwordSpecified:
+		return fmt.Errorf(
+			"%s flag requires %s or %s flag",
+			BasicAuthUserFlagLong,
+			BasicAuthPasswordFileFlagLong,
+			BasicAuthPasswordEnvFlagLong,
+		)
+
+	case c.BasicAuthUser == "" && passwordSpecified:
+		return fmt.Errorf(
+			"%s or %s flag requires %s flag",
+			BasicAuthPasswordFileFlagLong,
+			BasicAuthPasswordEnvFlagLong,
+			BasicAuthUserFlagLong,
+		)
+
+	case c.BasicAuthUser != "" && tokenSpecified:
+		return fmt.Errorf(
+			"invalid combination of flags; basic authentication flags are incompatible with bearer token flags",
+		)
+
+	case (c.BasicAuthUser != "" || tokenSpecified) && c.requestHeaders().Get("Authorization") != "":
+		return fmt.Errorf(
+			"invalid combination of flags; %s flag may not specify an Authorization header along with basic authentication or bearer token flags",
+			HeaderFlagLong,
+		)
+	}
+
+	return nil
+}
+
+// validateComponentFilterValues asserts that group, component flags were not
+// provided only whitespace characters and that a supported match mode was
+// specified.
+func (c Config) validateComponentFilterValues() error {
+	supportedModes := supportedMatchModes()
+	if !textutils.InList(c.MatchMode, supportedModes, true) {
+		return fmt.Errorf(
+			"invalid match mode specified; got %v, expected one of %v",
+			c.MatchMode,
+			supportedModes,
+		)
+	}
+
+	if err := validateFilterValues(c.ComponentFilter()); err != nil {
+		return err
+	}
+
+	return validateExclusionValues(c.excludeGroups, c.excludeComponents)
+}
+
+// validateAdditionalFeeds asserts that each feed specified after the primary
+// feed was provided acceptable component filter values and that standard
+// input is not specified for more than one feed.
+func (c Config) validateAdditionalFeeds() error {
+
+	// Standard input can only be read once.
+	var stdinFeeds int
+	for _, feed := range c.Feeds() {
+		if feed.Filename == statuspage.StdinFilename {
+			stdinFeeds++
+		}
+	}
+
+	if stdinFeeds > 1 {
+		return fmt.Errorf(
+			"standard input (%q) specified via %s flag for %d feeds; "+
+				"may only be specified once",
+			statuspage.StdinFilename,
+			FilenameFlagLong,
+			stdinFeeds,
+		)
+	}
+
+	for _, feed := range c.additionalFeeds {
+		switch {
+		case c.EvalAllComponents && feed.filterSpecified():
+			return fmt.Errorf(
+				"invalid combination of flags for feed %q; "+
+					"%s flag is incompatible with %q or %q flag",
+				feed.Source(),
+				EvalAllComponentsFlagLong,
+				ComponentsListFlagLong,
+				ComponentGroupFlagLong,
+			)
+
+		case !c.EvalAllComponents && !feed.filterSpecified():
+			return fmt.Errorf(
+				"missing component values for feed %q; must specify one of"+
+					" %s, %s or %s flags",
+				feed.Source(),
+				EvalAllComponentsFlagLong,
+				ComponentsListFlagLong,
+				ComponentGroupFlagLong,
+			)
+		}
+
+		if err := validateFilterValues(feed.ComponentFilter()); err != nil {
+			return fmt.Errorf("invalid values for feed %q: %w", feed.Source(), err)
+		}
+
+		if err := validateExclusionValues(feed.excludeGroups, feed.excludeComponents); err != nil {
+			return fmt.Errorf("invalid values for feed %q: %w", feed.Source(), err)
+		}
+	}
+
+	return nil
+}
+
+// validateFilterValues asserts that the group, component values of the
+// given filter are not only whitespace characters.
+func validateFilterValues(filter ComponentFilter) error {
+	validateComponents := func(componentsList []string) error {
+		for _, component := range componentsList {
+			if strings.TrimSpace(component) == "" {
+				return fmt.Errorf(
+					"whitespace only component value provided to %s flag",
+					ComponentsListFlagLong,
+				)
+			}
+		}
+
+		return nil
+	}
+
+	for _, group := range filter.Groups {
+		if strings.TrimSpace(group.Group) == "" {
+			return fmt.Errorf(
+				"whitespace only group value provided to %s flag",
+				ComponentGroupFlagLong,
+			)
+		}
+
+		if err := validateComponents(group.Components); err != nil {
+			return err
+		}
+	}
+
+	return validateComponents(filter.Components)
+}
+
+// validateExclusionValues asserts that the given component group and
+// component exclusion values are not empty or only whitespace characters.
+func validateExclusionValues(excludeGroups []string, excludeComponents []string) error {
+	for _, group := range excludeGroups {
+		if strings.TrimSpace(group) == "" {
+			return fmt.Errorf(
+				"empty or whitespace only group value provided to %s flag",
+				ExcludeGroupsFlagLong,
+			)
+		}
+	}
+
+	for _, component := range excludeComponents {
+		if strings.TrimSpace(component) == "" {
+			return fmt.Errorf(
+				"empty or whitespace only component value provided to %s flag",
+				ExcludeComponentsFlagLong,
+			)
+		}
+	}
+
+	return nil
+}
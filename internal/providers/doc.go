@@ -0,0 +1,11 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Package providers provides an embedded catalog of known vendors with
+// Atlassian Statuspage powered status pages. Each catalog entry provides the
+// base status page URL, a display name and the expected Statuspage page ID.
+package providers
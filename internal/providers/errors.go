@@ -0,0 +1,18 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package providers
+
+import "errors"
+
+// ErrProviderNotFound indicates that a specified provider key was not found
+// in the provider catalog.
+var ErrProviderNotFound = errors.New("provider not found in catalog")
+
+// ErrCatalogInvalid indicates that the embedded provider catalog could not
+// be decoded or contains invalid entries.
+var ErrCatalogInvalid = errors.New("provider catalog is invalid")
@@ -0,0 +1,139 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package providers
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// catalogJSON is the embedded collection of known providers.
+//
+//go:embed catalog.json
+var catalogJSON []byte
+
+// Provider represents a vendor with an Atlassian Statuspage powered status
+// page.
+type Provider struct {
+
+	// Key is the short, unique value used to select the provider (e.g.,
+	// github).
+	Key string `json:"key"`
+
+	// Name is the display name for the provider.
+	Name string `json:"name"`
+
+	// PageID is the expected Statuspage page ID for the provider. This value
+	// is used to detect a URL which refers to the wrong vendor.
+	PageID string `json:"page_id"`
+
+	// URL is the base status page URL for the provider (e.g.,
+	// https://www.githubstatus.com).
+	URL string `json:"url"`
+}
+
+// String implements the Stringer interface for a provider.
+func (p Provider) String() string {
+	return fmt.Sprintf(
+		"{Key: %s, Name: %s, PageID: %s, URL: %s}",
+		p.Key,
+		p.Name,
+		p.PageID,
+		p.URL,
+	)
+}
+
+// Catalog returns the collection of known providers sorted by key. An error
+// is returned if the embedded catalog cannot be decoded or contains invalid
+// entries.
+func Catalog() ([]Provider, error) {
+
+	var catalog []Provider
+	if err := json.Unmarshal(catalogJSON, &catalog); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCatalogInvalid, err)
+	}
+
+	for i, provider := range catalog {
+
+		// Saying entry 3 when the catalog shows as 4th might be confusing,
+		// so display using 1 as starting value.
+		humanReadableEntryNumber := i + 1
+
+		switch {
+		case provider.Key == "":
+			return nil, fmt.Errorf(
+				"%w: entry %d: Key field empty",
+				ErrCatalogInvalid,
+				humanReadableEntryNumber,
+			)
+
+		case provider.PageID == "":
+			return nil, fmt.Errorf(
+				"%w: entry %d: PageID field empty",
+				ErrCatalogInvalid,
+				humanReadableEntryNumber,
+			)
+
+		case provider.URL == "":
+			return nil, fmt.Errorf(
+				"%w: entry %d: URL field empty",
+				ErrCatalogInvalid,
+				humanReadableEntryNumber,
+			)
+		}
+	}
+
+	sort.Slice(catalog, func(i, j int) bool {
+		return catalog[i].Key < catalog[j].Key
+	})
+
+	return catalog, nil
+}
+
+// Keys returns the keys for all known providers sorted alphabetically.
+func Keys() []string {
+	catalog, err := Catalog()
+	if err != nil {
+		return []string{}
+	}
+
+	keys := make([]string, 0, len(catalog))
+	for _, provider := range catalog {
+		keys = append(keys, provider.Key)
+	}
+
+	return keys
+}
+
+// Lookup returns the provider matching the given key. Matching is case
+// insensitive. An error is returned if the key does not match a known
+// provider.
+func Lookup(key string) (Provider, error) {
+
+	catalog, err := Catalog()
+	if err != nil {
+		return Provider{}, err
+	}
+
+	key = strings.TrimSpace(key)
+	for _, provider := range catalog {
+		if strings.EqualFold(provider.Key, key) {
+			return provider, nil
+		}
+	}
+
+	return Provider{}, fmt.Errorf(
+		"%w: %q (valid values: %s)",
+		ErrProviderNotFound,
+		key,
+		strings.Join(Keys(), ", "),
+	)
+}
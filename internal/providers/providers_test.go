@@ -0,0 +1,77 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package providers_test
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/atc0005/check-statuspage/internal/providers"
+)
+
+// TestCatalog asserts that the embedded provider catalog can be decoded and
+// that each entry provides a unique key, a valid page ID and a base status
+// page URL.
+func TestCatalog(t *testing.T) {
+	t.Parallel()
+
+	pageIDRegex := regexp.MustCompile(`^[a-z0-9]{12}$`)
+
+	catalog, err := providers.Catalog()
+	if err != nil {
+		t.Fatalf("ERROR: Failed to load provider catalog: %v", err)
+	}
+
+	if len(catalog) == 0 {
+		t.Fatal("ERROR: Provider catalog is empty")
+	}
+
+	keys := make(map[string]struct{}, len(catalog))
+	for _, provider := range catalog {
+		if _, ok := keys[provider.Key]; ok {
+			t.Errorf("ERROR: duplicate provider key %q", provider.Key)
+		}
+		keys[provider.Key] = struct{}{}
+
+		if !pageIDRegex.MatchString(provider.PageID) {
+			t.Errorf("ERROR: invalid page ID for provider %s", provider)
+		}
+
+		u, err := url.Parse(provider.URL)
+		switch {
+		case err != nil:
+			t.Errorf("ERROR: invalid URL for provider %s: %v", provider, err)
+		case u.Scheme != "https" || u.Host == "":
+			t.Errorf("ERROR: URL for provider %s is not a https URL", provider)
+		case u.Path != "":
+			t.Errorf("ERROR: URL for provider %s is not a base status page URL", provider)
+		}
+	}
+}
+
+// TestLookup asserts that providers are matched by key (case insensitive) and
+// that unknown keys are rejected.
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	provider, err := providers.Lookup("GitHub")
+	switch {
+	case err != nil:
+		t.Fatalf("ERROR: Failed to lookup provider: %v", err)
+	case provider.PageID != "kctbh9vrtdwd":
+		t.Errorf("ERROR: want page ID %q, got %q", "kctbh9vrtdwd", provider.PageID)
+	default:
+		t.Logf("OK: found provider %s", provider)
+	}
+
+	if _, err := providers.Lookup("not-a-provider"); !errors.Is(err, providers.ErrProviderNotFound) {
+		t.Errorf("ERROR: want %v, got %v", providers.ErrProviderNotFound, err)
+	}
+}
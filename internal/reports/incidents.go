@@ -0,0 +1,288 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package reports
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/atc0005/check-statuspage/internal/statuspage/components"
+	"github.com/atc0005/check-statuspage/internal/statuspage/summary"
+	"github.com/atc0005/check-statuspage/internal/textutils"
+	"github.com/atc0005/go-nagios"
+)
+
+// printIncident is a helper function to display an incident in a consistent
+// way throughout the incidents report.
+func printIncident(w io.Writer, incident *summary.Incident, verbose bool) {
+	_, _ = fmt.Fprintf(
+		w,
+		"* %s [Impact: %s, Status: %s]%s",
+		incident.Name,
+		printStatus(incident.Impact),
+		printStatus(incident.Status),
+		nagios.CheckOutputEOL,
+	)
+
+	if verbose {
+		_, _ = fmt.Fprintf(
+			w,
+			"  ** ID: %s%s",
+			incident.ID,
+			nagios.CheckOutputEOL,
+		)
+	}
+
+	_, _ = fmt.Fprintf(
+		w,
+		"  ** Started: %s%s",
+		incident.StartedAt.Local().Format(time.DateTime+" PM"),
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"  ** Last Updated: %s%s",
+		incident.UpdatedAt.Local().Format(time.DateTime+" PM"),
+		nagios.CheckOutputEOL,
+	)
+
+	if incident.Shortlink != "" {
+		_, _ = fmt.Fprintf(
+			w,
+			"  ** Link: %s%s",
+			incident.Shortlink,
+			nagios.CheckOutputEOL,
+		)
+	}
+
+	affected := "N/A"
+	if len(incident.Components) > 0 {
+		names := make([]string, 0, len(incident.Components))
+		for _, component := range incident.Components {
+			names = append(names, component.Name)
+		}
+		affected = strings.Join(names, ", ")
+	}
+
+	_, _ = fmt.Fprintf(
+		w,
+		"  ** Affected components: %s%s",
+		affected,
+		nagios.CheckOutputEOL,
+	)
+
+	if update := incident.LatestUpdate(); update != nil {
+		_, _ = fmt.Fprintf(
+			w,
+			"  ** Latest update (%s): %s%s",
+			update.DisplayAt.Local().Format(time.DateTime+" PM"),
+			strings.TrimSpace(update.Body),
+			nagios.CheckOutputEOL,
+		)
+	}
+}
+
+// incidentsStatusSummary generates a brief summary of high-level incident
+// details. This summary is written to the provided io.Writer.
+func incidentsStatusSummary(w io.Writer, s *summary.Summary) {
+	_, _ = fmt.Fprintf(
+		w,
+		"%sSummary:%s%s",
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Page: %s (%s)%s",
+		s.Page.Name,
+		s.Page.URL,
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Last Updated (%s): %s%s",
+		s.Page.TimeZone,
+		s.Page.UpdatedAt.Format(time.RFC3339),
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Filtering applied to incidents: %t%s",
+		s.FilterApplied,
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Number of total unresolved incidents: %d%s",
+		s.NumIncidents(),
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Number of ignored incidents: %d%s",
+		s.NumExcludedIncidents(),
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Number of remaining problem incidents: %d%s",
+		s.NumProblemIncidents(false),
+		nagios.CheckOutputEOL,
+	)
+}
+
+// IncidentsOneLineCheckSummary is used to generate a one-line Nagios service
+// check results summary for unresolved incidents. This is the line most
+// prominent in notifications.
+func IncidentsOneLineCheckSummary(
+	stateLabel string,
+	s *summary.Summary,
+	evalExcluded bool,
+) string {
+	funcTimeStart := time.Now()
+
+	defer func() {
+		logger.Printf(
+			"It took %v to execute IncidentsOneLineCheckSummary func.\n",
+			time.Since(funcTimeStart),
+		)
+	}()
+
+	evaluatedIncidents := s.NumIncidents() - s.NumExcludedIncidents()
+	problemIncidents := s.ProblemIncidents(evalExcluded)
+	numProblemIncidents := len(problemIncidents)
+	problemImpactIdx := make(map[string]int)
+
+	for _, incident := range problemIncidents {
+		problemImpactIdx[incident.Impact]++
+	}
+
+	serviceState := s.IncidentsServiceState(evalExcluded)
+	potentialImpacts := summary.ServiceStateToIncidentImpacts(serviceState)
+
+	incidentImpacts := make([]string, 0, len(problemImpactIdx))
+	for impact, count := range problemImpactIdx {
+		if textutils.InList(impact, potentialImpacts, false) {
+			impactTally := fmt.Sprintf("%s (%d)", impact, count)
+			incidentImpacts = append(incidentImpacts, impactTally)
+		}
+	}
+	sort.Strings(incidentImpacts)
+
+	var impactTallies string
+	generalStatus := "unresolved incident is impacting service"
+	if numProblemIncidents > 1 || numProblemIncidents == 0 {
+		generalStatus = "unresolved incidents are impacting service"
+	}
+	if numProblemIncidents > 0 {
+		impactTallies = "[" + strings.Join(incidentImpacts, ", ") + "]"
+	}
+
+	// WARNING: 1 evaluated "Instructure" unresolved incident is impacting service (1 evaluated, 1 total) [minor (1)]
+	summaryTmpl := "%s: %d evaluated %q %s (%d evaluated, %d total) %s"
+	return strings.TrimSpace(fmt.Sprintf(
+		summaryTmpl,
+		stateLabel,
+		numProblemIncidents,
+		s.Page.Name,
+		generalStatus,
+		evaluatedIncidents,
+		s.NumIncidents(),
+		impactTallies,
+	))
+}
+
+// IncidentsReport generates a summary of evaluated unresolved incidents along
+// with specific details intended to aid in troubleshooting check results at
+// a glance.
+//
+// This information is provided for use with the Long Service Output field
+// commonly displayed on the detailed service check results display in the web
+// UI or in the body of many notifications.
+func IncidentsReport(
+	_ string,
+	filter components.Filter,
+	s *summary.Summary,
+	omitOKIncidents bool,
+	omitSummaryResults bool,
+	verbose bool,
+) string {
+	funcTimeStart := time.Now()
+
+	defer func() {
+		logger.Printf(
+			"It took %v to execute IncidentsReport func.\n",
+			time.Since(funcTimeStart),
+		)
+	}()
+
+	var report strings.Builder
+
+	switch {
+	case s.FilterApplied:
+		if verbose {
+			_, _ = fmt.Fprintf(
+				&report,
+				"Specified filter: %s%s",
+				filter,
+				nagios.CheckOutputEOL,
+			)
+		}
+	default:
+		if verbose {
+			_, _ = fmt.Fprintf(
+				&report,
+				"NOTE: Evaluating all unresolved incidents.%s",
+				nagios.CheckOutputEOL,
+			)
+		}
+	}
+
+	_, _ = fmt.Fprintf(
+		&report,
+		"%sIncidents:%s%s",
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+	)
+
+	var incidentsEmitted bool
+	for _, incident := range s.NotExcludedIncidents() {
+		if omitOKIncidents && incident.IsOKState() {
+			continue
+		}
+
+		printIncident(&report, incident, verbose)
+		incidentsEmitted = true
+	}
+
+	if !incidentsEmitted {
+		_, _ = fmt.Fprintf(
+			&report,
+			"* None%s",
+			nagios.CheckOutputEOL,
+		)
+	}
+
+	if !omitSummaryResults {
+		incidentsStatusSummary(&report, s)
+	}
+
+	return report.String()
+}
@@ -0,0 +1,309 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package reports
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/atc0005/check-statuspage/internal/statuspage/components"
+	"github.com/atc0005/check-statuspage/internal/statuspage/summary"
+	"github.com/atc0005/go-nagios"
+)
+
+// printScheduledMaintenance is a helper function to display a scheduled
+// maintenance in a consistent way throughout the scheduled maintenance
+// report.
+func printScheduledMaintenance(w io.Writer, maintenance *summary.ScheduledMaintenance, verbose bool) {
+	_, _ = fmt.Fprintf(
+		w,
+		"* %s [Status: %s]%s",
+		maintenance.Name,
+		printStatus(maintenance.Status),
+		nagios.CheckOutputEOL,
+	)
+
+	if verbose {
+		_, _ = fmt.Fprintf(
+			w,
+			"  ** ID: %s%s",
+			maintenance.ID,
+			nagios.CheckOutputEOL,
+		)
+	}
+
+	_, _ = fmt.Fprintf(
+		w,
+		"  ** Starts: %s%s",
+		maintenance.ScheduledFor.Local().Format(time.DateTime+" PM"),
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"  ** Ends: %s%s",
+		maintenance.ScheduledUntil.Local().Format(time.DateTime+" PM"),
+		nagios.CheckOutputEOL,
+	)
+
+	if maintenance.Shortlink != "" {
+		_, _ = fmt.Fprintf(
+			w,
+			"  ** Link: %s%s",
+			maintenance.Shortlink,
+			nagios.CheckOutputEOL,
+		)
+	}
+
+	affected := "N/A"
+	if len(maintenance.Components) > 0 {
+		names := make([]string, 0, len(maintenance.Components))
+		for _, component := range maintenance.Components {
+			names = append(names, component.Name)
+		}
+		affected = strings.Join(names, ", ")
+	}
+
+	_, _ = fmt.Fprintf(
+		w,
+		"  ** Affected components: %s%s",
+		affected,
+		nagios.CheckOutputEOL,
+	)
+}
+
+// printScheduledMaintenances is a helper function to display a titled
+// collection of scheduled maintenances. If the collection is empty, a
+// placeholder entry is emitted instead.
+func printScheduledMaintenances(w io.Writer, title string, maintenances []*summary.ScheduledMaintenance, verbose bool) {
+	_, _ = fmt.Fprintf(
+		w,
+		"%s%s:%s%s",
+		nagios.CheckOutputEOL,
+		title,
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+	)
+
+	if len(maintenances) == 0 {
+		_, _ = fmt.Fprintf(
+			w,
+			"* None%s",
+			nagios.CheckOutputEOL,
+		)
+
+		return
+	}
+
+	for _, maintenance := range maintenances {
+		printScheduledMaintenance(w, maintenance, verbose)
+	}
+}
+
+// scheduledMaintenanceStatusSummary generates a brief summary of high-level
+// scheduled maintenance details. This summary is written to the provided
+// io.Writer.
+func scheduledMaintenanceStatusSummary(w io.Writer, s *summary.Summary, within time.Duration, numActive int, numUpcoming int) {
+	_, _ = fmt.Fprintf(
+		w,
+		"%sSummary:%s%s",
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Page: %s (%s)%s",
+		s.Page.Name,
+		s.Page.URL,
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Last Updated (%s): %s%s",
+		s.Page.TimeZone,
+		s.Page.UpdatedAt.Format(time.RFC3339),
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Filtering applied to scheduled maintenances: %t%s",
+		s.FilterApplied,
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Lookahead for upcoming scheduled maintenances: %v%s",
+		within,
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Number of total scheduled maintenances: %d%s",
+		s.NumScheduledMaintenances(),
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Number of ignored scheduled maintenances: %d%s",
+		s.NumExcludedScheduledMaintenances(),
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Number of remaining active scheduled maintenances: %d%s",
+		numActive,
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		w,
+		"* Number of remaining upcoming scheduled maintenances within lookahead: %d%s",
+		numUpcoming,
+		nagios.CheckOutputEOL,
+	)
+}
+
+// ScheduledMaintenanceOneLineCheckSummary is used to generate a one-line
+// Nagios service check results summary for scheduled maintenances. This is
+// the line most prominent in notifications.
+func ScheduledMaintenanceOneLineCheckSummary(
+	stateLabel string,
+	s *summary.Summary,
+	now time.Time,
+	within time.Duration,
+	evalExcluded bool,
+) string {
+	funcTimeStart := time.Now()
+
+	defer func() {
+		logger.Printf(
+			"It took %v to execute ScheduledMaintenanceOneLineCheckSummary func.\n",
+			time.Since(funcTimeStart),
+		)
+	}()
+
+	evaluated := s.NumScheduledMaintenances() - s.NumExcludedScheduledMaintenances()
+	numActive := len(s.ActiveScheduledMaintenances(evalExcluded))
+	numUpcoming := len(s.UpcomingScheduledMaintenances(now, within, evalExcluded))
+
+	// WARNING: 2 evaluated "Instructure" scheduled maintenances are active or start within 24h0m0s (3 evaluated, 3 total) [active (1), upcoming (1)]
+	summaryTmpl := "%s: %d evaluated %q %s (%d evaluated, %d total)"
+
+	generalStatus := fmt.Sprintf("scheduled maintenance is active or starts within %v", within)
+	if numActive+numUpcoming != 1 {
+		generalStatus = fmt.Sprintf("scheduled maintenances are active or start within %v", within)
+	}
+
+	oneLineSummary := fmt.Sprintf(
+		summaryTmpl,
+		stateLabel,
+		numActive+numUpcoming,
+		s.Page.Name,
+		generalStatus,
+		evaluated,
+		s.NumScheduledMaintenances(),
+	)
+
+	if numActive+numUpcoming > 0 {
+		oneLineSummary += fmt.Sprintf(
+			" [active (%d), upcoming (%d)]",
+			numActive,
+			numUpcoming,
+		)
+	}
+
+	return oneLineSummary
+}
+
+// ScheduledMaintenanceReport generates a summary of evaluated scheduled
+// maintenances (name, start and end times and affected components) intended
+// to aid in reviewing check results at a glance.
+//
+// This information is provided for use with the Long Service Output field
+// commonly displayed on the detailed service check results display in the web
+// UI or in the body of many notifications.
+func ScheduledMaintenanceReport(
+	_ string,
+	filter components.Filter,
+	s *summary.Summary,
+	now time.Time,
+	within time.Duration,
+	omitOKMaintenances bool,
+	omitSummaryResults bool,
+	verbose bool,
+) string {
+	funcTimeStart := time.Now()
+
+	defer func() {
+		logger.Printf(
+			"It took %v to execute ScheduledMaintenanceReport func.\n",
+			time.Since(funcTimeStart),
+		)
+	}()
+
+	var report strings.Builder
+
+	switch {
+	case s.FilterApplied:
+		if verbose {
+			_, _ = fmt.Fprintf(
+				&report,
+				"Specified filter: %s%s",
+				filter,
+				nagios.CheckOutputEOL,
+			)
+		}
+	default:
+		if verbose {
+			_, _ = fmt.Fprintf(
+				&report,
+				"NOTE: Evaluating all scheduled maintenances.%s",
+				nagios.CheckOutputEOL,
+			)
+		}
+	}
+
+	active := s.ActiveScheduledMaintenances(false)
+	upcoming := s.UpcomingScheduledMaintenances(now, within, false)
+
+	printScheduledMaintenances(&report, "Active scheduled maintenances", active, verbose)
+
+	printScheduledMaintenances(
+		&report,
+		fmt.Sprintf("Upcoming scheduled maintenances (within %v)", within),
+		upcoming,
+		verbose,
+	)
+
+	if !omitOKMaintenances {
+		later := make([]*summary.ScheduledMaintenance, 0, len(s.ScheduledMaintenances))
+		for _, maintenance := range s.NotExcludedScheduledMaintenances() {
+			if maintenance.IsUpcoming() && !maintenance.StartsWithin(now, within) {
+				later = append(later, maintenance)
+			}
+		}
+
+		printScheduledMaintenances(&report, "Later scheduled maintenances", later, verbose)
+	}
+
+	if !omitSummaryResults {
+		scheduledMaintenanceStatusSummary(&report, s, within, len(active), len(upcoming))
+	}
+
+	return report.String()
+}
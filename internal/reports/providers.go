@@ -0,0 +1,48 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package reports
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/atc0005/check-statuspage/internal/providers"
+	"github.com/atc0005/go-nagios"
+)
+
+// ProvidersTable generates a table of known providers from the given
+// provider catalog. Each row lists the provider key (used with the provider
+// flag), display name, expected page ID and base status page URL.
+func ProvidersTable(catalog []providers.Provider) string {
+
+	var report strings.Builder
+
+	// See GH-44 regarding issues with lack of spacing between columns in
+	// email notifications (when using tabs).
+	w := tabwriter.NewWriter(&report, 4, 4, 3, ' ', 0)
+
+	_, _ = fmt.Fprintf(w, "Key\tName\tPage ID\tURL%s", nagios.CheckOutputEOL)
+	_, _ = fmt.Fprintf(w, "---\t----\t-------\t---%s", nagios.CheckOutputEOL)
+
+	for _, provider := range catalog {
+		_, _ = fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s%s",
+			provider.Key,
+			provider.Name,
+			provider.PageID,
+			provider.URL,
+			nagios.CheckOutputEOL,
+		)
+	}
+
+	_ = w.Flush()
+
+	return report.String()
+}
@@ -10,13 +10,13 @@ package reports
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/atc0005/check-statuspage/internal/statuspage/components"
-	"github.com/atc0005/check-statuspage/internal/textutils"
 	"github.com/atc0005/go-nagios"
 )
 
@@ -393,9 +393,7 @@ func ComponentsVerbose(componentsSet *components.Set, omitOKComponents bool, ver
 		)
 
 		for i, component := range componentsSet.ProblemComponents(true) {
-			if !component.IsOKState() {
-				_, _ = fmt.Fprint(&report, printVerboseComponent(component, i+1))
-			}
+			_, _ = fmt.Fprint(&report, printVerboseComponent(component, i+1))
 		}
 
 		_, _ = fmt.Fprint(&report, nagios.CheckOutputEOL)
@@ -1061,20 +1059,20 @@ func ComponentsOneLineCheckSummary(
 	numProblemComponents := len(problemComponents)
 	problemStatusIdx := make(map[string]int)
 
+	// Only statuses of components in the same state as the set are tallied.
+	serviceState := componentsSet.ServiceState(evalExcluded)
 	for _, component := range problemComponents {
-		problemStatusIdx[component.Status]++
+		if state, _ := componentsSet.ComponentServiceState(component); state.ExitCode == serviceState.ExitCode {
+			problemStatusIdx[component.Status]++
+		}
 	}
 
-	serviceState := componentsSet.ServiceState(evalExcluded)
-	potentialStatuses := components.ServiceStateToComponentStatuses(serviceState)
-
 	componentStatuses := make([]string, 0, len(problemStatusIdx))
 	for status, count := range problemStatusIdx {
-		if textutils.InList(status, potentialStatuses, false) {
-			statusTally := fmt.Sprintf("%s (%d)", status, count)
-			componentStatuses = append(componentStatuses, statusTally)
-		}
+		statusTally := fmt.Sprintf("%s (%d)", status, count)
+		componentStatuses = append(componentStatuses, statusTally)
 	}
+	sort.Strings(componentStatuses)
 
 	var statusTallies string
 	generalStatus := "component has a non-operational status"
@@ -1188,5 +1186,249 @@ func ComponentsReport(
 		_, _ = fmt.Fprint(&report, ComponentsTable(componentsSet, false, omitSummaryResults, &columnFilter, verbose))
 	}
 
+	severityOverridesReport(&report, componentsSet)
+	groupRollupReport(&report, componentsSet)
+	gracePeriodReport(&report, componentsSet)
+
+	return report.String()
+}
+
+// severityOverridesReport lists the evaluated components with a state
+// determined by a user-specified severity override along with the rule which
+// determined the state. Nothing is written to the provided io.Writer if no
+// severity overrides apply.
+func severityOverridesReport(w io.Writer, componentsSet *components.Set) {
+	overridden := componentsSet.SeverityOverrideComponents(false)
+	if len(overridden) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(
+		w,
+		"%sSeverity overrides applied:%s%s",
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+	)
+
+	for _, component := range overridden {
+		serviceState, rule := componentsSet.ComponentServiceState(component)
+		_, _ = fmt.Fprintf(
+			w,
+			"* %s (%s): %s [rule: %s]%s",
+			component.Name,
+			component.Status,
+			serviceState.Label,
+			rule,
+			nagios.CheckOutputEOL,
+		)
+	}
+}
+
+// groupRollupReport lists the component groups evaluated as a single unit
+// along with the state of each group and the number of evaluated
+// subcomponents with a non-operational status. Nothing is written to the
+// provided io.Writer if component groups are not evaluated as a single unit.
+func groupRollupReport(w io.Writer, componentsSet *components.Set) {
+	groups := componentsSet.RollupGroups(false)
+	if len(groups) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(
+		w,
+		"%sComponent groups evaluated as a single unit (%s):%s%s",
+		nagios.CheckOutputEOL,
+		componentsSet.GroupRollup.Mode,
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+	)
+
+	for _, group := range groups {
+		serviceState, _ := componentsSet.ComponentServiceState(group)
+		numEvaluated, numProblem := componentsSet.GroupRollupSummary(group)
+		_, _ = fmt.Fprintf(
+			w,
+			"* %s (%s): %s [%d of %d evaluated subcomponents non-operational]%s",
+			group.Name,
+			group.Status,
+			serviceState.Label,
+			numProblem,
+			numEvaluated,
+			nagios.CheckOutputEOL,
+		)
+	}
+}
+
+// gracePeriodReport lists the evaluated components with a non-operational
+// status which has not yet persisted for the minimum problem duration along
+// with how long ago each component was last updated. Nothing is written to
+// the provided io.Writer if no components are within the grace period.
+func gracePeriodReport(w io.Writer, componentsSet *components.Set) {
+	pending := componentsSet.GracePeriodComponents(false)
+	if len(pending) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(
+		w,
+		"%sNon-operational components within minimum problem duration (%s):%s%s",
+		nagios.CheckOutputEOL,
+		componentsSet.MinProblemDuration(),
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+	)
+
+	now := time.Now()
+	for _, component := range pending {
+		_, _ = fmt.Fprintf(
+			w,
+			"* %s (%s): updated %s ago%s",
+			component.Name,
+			component.Status,
+			now.Sub(component.UpdatedAt).Round(time.Second),
+			nagios.CheckOutputEOL,
+		)
+	}
+}
+
+// ComponentsSetsOneLineCheckSummary is used to generate a one-line Nagios
+// service check results summary for a collection of component Sets (one per
+// page). If only one Set is provided, the output is the same as that
+// provided by ComponentsOneLineCheckSummary.
+func ComponentsSetsOneLineCheckSummary(
+	stateLabel string,
+	componentsSets components.Sets,
+	evalExcluded bool,
+) string {
+
+	if len(componentsSets) == 1 {
+		return ComponentsOneLineCheckSummary(stateLabel, componentsSets[0], evalExcluded)
+	}
+
+	funcTimeStart := time.Now()
+
+	defer func() {
+		logger.Printf(
+			"It took %v to execute ComponentsSetsOneLineCheckSummary func.\n",
+			time.Since(funcTimeStart),
+		)
+	}()
+
+	evaluatedComponents := componentsSets.NumComponents() - componentsSets.NumExcluded()
+	numProblemComponents := componentsSets.NumProblemComponents(evalExcluded)
+	problemStatusIdx := make(map[string]int)
+
+	// Only statuses of components in the same state as the collection are
+	// tallied.
+	serviceState := componentsSets.ServiceState(evalExcluded)
+	for _, componentsSet := range componentsSets {
+		for _, component := range componentsSet.ProblemComponents(evalExcluded) {
+			if state, _ := componentsSet.ComponentServiceState(component); state.ExitCode == serviceState.ExitCode {
+				problemStatusIdx[component.Status]++
+			}
+		}
+	}
+
+	componentStatuses := make([]string, 0, len(problemStatusIdx))
+	for status, count := range problemStatusIdx {
+		statusTally := fmt.Sprintf("%s (%d)", status, count)
+		componentStatuses = append(componentStatuses, statusTally)
+	}
+	sort.Strings(componentStatuses)
+
+	var statusTallies string
+	generalStatus := "component has a non-operational status"
+	if numProblemComponents > 1 || numProblemComponents == 0 {
+		generalStatus = "components have a non-operational status"
+	}
+	if numProblemComponents > 0 {
+		statusTallies = "[" + strings.Join(componentStatuses, ", ") + "]"
+	}
+
+	// CRITICAL: 3 evaluated components have a non-operational status on 2 of 15 pages (40 evaluated, 900 total) [major_outage (1), partial_outage (2)]
+	summaryTmpl := "%s: %d evaluated %s on %d of %d pages (%d evaluated, %d total) %s"
+	return fmt.Sprintf(
+		summaryTmpl,
+		stateLabel,
+		numProblemComponents,
+		generalStatus,
+		componentsSets.NumProblemPages(evalExcluded),
+		componentsSets.NumPages(),
+		evaluatedComponents,
+		componentsSets.NumComponents(),
+		statusTallies,
+	)
+
+}
+
+// ComponentsSetsReport generates a combined report for a collection of
+// component Sets (one per page) using the filter applied to each Set. A
+// section is emitted for each page using the output provided by
+// ComponentsReport. If only one Set is provided, the output is the same as
+// that provided by ComponentsReport.
+func ComponentsSetsReport(
+	stateLabel string,
+	filters []components.Filter,
+	componentsSets components.Sets,
+	omitOKComponents bool,
+	omitSummaryResults bool,
+	verbose bool,
+) string {
+
+	if len(componentsSets) == 1 && len(filters) == 1 {
+		return ComponentsReport(
+			stateLabel,
+			filters[0],
+			componentsSets[0],
+			omitOKComponents,
+			omitSummaryResults,
+			verbose,
+		)
+	}
+
+	funcTimeStart := time.Now()
+
+	defer func() {
+		logger.Printf(
+			"It took %v to execute ComponentsSetsReport func.\n",
+			time.Since(funcTimeStart),
+		)
+	}()
+
+	var report strings.Builder
+
+	for i, componentsSet := range componentsSets {
+		var filter components.Filter
+		if i < len(filters) {
+			filter = filters[i]
+		}
+
+		_, _ = fmt.Fprintf(
+			&report,
+			"%s--- Page %d of %d: %s (%s) [%s] ---%s%s",
+			nagios.CheckOutputEOL,
+			i+1,
+			len(componentsSets),
+			componentsSet.Page.Name,
+			componentsSet.Page.URL,
+			componentsSet.ServiceState(false).Label,
+			nagios.CheckOutputEOL,
+			nagios.CheckOutputEOL,
+		)
+
+		_, _ = fmt.Fprint(
+			&report,
+			ComponentsReport(
+				stateLabel,
+				filter,
+				componentsSet,
+				omitOKComponents,
+				omitSummaryResults,
+				verbose,
+			),
+		)
+	}
+
 	return report.String()
 }
@@ -0,0 +1,112 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package reports
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atc0005/check-statuspage/internal/statuspage"
+	"github.com/atc0005/go-nagios"
+)
+
+// AnnotateStaleFeedResults updates the given plugin results to note that the
+// last good copy of one or more feeds was evaluated in place of feeds which
+// could not be retrieved. If the results are otherwise OK, the given fallback
+// state is applied; a more severe state determined from the last good copy
+// of the feeds is retained. Nothing is changed if no stale feed errors are
+// given.
+func AnnotateStaleFeedResults(
+	plugin *nagios.Plugin,
+	fallbackState nagios.ServiceState,
+	staleErrs []*statuspage.StaleFeedError,
+) {
+	if len(staleErrs) == 0 {
+		return
+	}
+
+	if plugin.ExitStatusCode == nagios.StateOKExitCode {
+		plugin.ExitStatusCode = fallbackState.ExitCode
+		plugin.ServiceOutput = fallbackState.Label +
+			strings.TrimPrefix(plugin.ServiceOutput, nagios.StateOKLabel)
+	}
+
+	for _, staleErr := range staleErrs {
+		plugin.AddError(staleErr)
+	}
+
+	plugin.ServiceOutput = strings.TrimSpace(plugin.ServiceOutput) +
+		StaleFeedsOneLineNote(staleErrs)
+	plugin.LongServiceOutput = StaleFeedsReport(staleErrs) + plugin.LongServiceOutput
+}
+
+// StaleFeedsOneLineNote returns a brief note suitable for appending to the
+// one-line Nagios service check results summary indicating how old the last
+// good copy of the evaluated feeds is.
+func StaleFeedsOneLineNote(staleErrs []*statuspage.StaleFeedError) string {
+	switch {
+	case len(staleErrs) == 0:
+		return ""
+
+	case len(staleErrs) == 1:
+		return fmt.Sprintf(
+			" (using last good feed data from %s ago)",
+			staleErrs[0].Age.Round(time.Second),
+		)
+
+	default:
+		var oldest time.Duration
+		for _, staleErr := range staleErrs {
+			if staleErr.Age > oldest {
+				oldest = staleErr.Age
+			}
+		}
+
+		return fmt.Sprintf(
+			" (using last good data for %d feeds, oldest from %s ago)",
+			len(staleErrs),
+			oldest.Round(time.Second),
+		)
+	}
+}
+
+// StaleFeedsReport generates a note listing each feed which could not be
+// retrieved along with the age of the last good copy evaluated in its place.
+//
+// This information is provided for use with the Long Service Output field
+// commonly displayed on the detailed service check results display in the web
+// UI or in the body of many notifications.
+func StaleFeedsReport(staleErrs []*statuspage.StaleFeedError) string {
+	if len(staleErrs) == 0 {
+		return ""
+	}
+
+	var report strings.Builder
+
+	_, _ = fmt.Fprintf(
+		&report,
+		"%sFeeds evaluated using last good copy:%s%s",
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+	)
+
+	for _, staleErr := range staleErrs {
+		_, _ = fmt.Fprintf(
+			&report,
+			"* %s (retrieved %s, %s ago)%s",
+			staleErr.Source,
+			staleErr.StoredAt.Format(time.RFC3339),
+			staleErr.Age.Round(time.Second),
+			nagios.CheckOutputEOL,
+		)
+	}
+
+	return report.String()
+}
@@ -0,0 +1,145 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package reports
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atc0005/check-statuspage/internal/statuspage/summary"
+	"github.com/atc0005/go-nagios"
+)
+
+// PageStatusOneLineCheckSummary is used to generate a one-line Nagios
+// service check results summary for the overall page status indicator. This
+// is the line most prominent in notifications.
+func PageStatusOneLineCheckSummary(stateLabel string, s *summary.Summary) string {
+	funcTimeStart := time.Now()
+
+	defer func() {
+		logger.Printf(
+			"It took %v to execute PageStatusOneLineCheckSummary func.\n",
+			time.Since(funcTimeStart),
+		)
+	}()
+
+	// CRITICAL: "Instructure" page status indicator is major (Partial System Outage)
+	return fmt.Sprintf(
+		"%s: %q page status indicator is %s (%s)",
+		stateLabel,
+		s.Page.Name,
+		s.Status.Indicator,
+		s.Status.Description,
+	)
+}
+
+// PageStatusReport generates a summary of the overall page status indicator
+// and description intended to aid in reviewing check results at a glance.
+//
+// This information is provided for use with the Long Service Output field
+// commonly displayed on the detailed service check results display in the web
+// UI or in the body of many notifications.
+func PageStatusReport(
+	_ string,
+	s *summary.Summary,
+	omitSummaryResults bool,
+	verbose bool,
+) string {
+	funcTimeStart := time.Now()
+
+	defer func() {
+		logger.Printf(
+			"It took %v to execute PageStatusReport func.\n",
+			time.Since(funcTimeStart),
+		)
+	}()
+
+	var report strings.Builder
+
+	_, _ = fmt.Fprintf(
+		&report,
+		"%sPage status:%s%s",
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		&report,
+		"* Indicator: %s%s",
+		printStatus(s.Status.Indicator),
+		nagios.CheckOutputEOL,
+	)
+
+	_, _ = fmt.Fprintf(
+		&report,
+		"* Description: %s%s",
+		s.Status.Description,
+		nagios.CheckOutputEOL,
+	)
+
+	if verbose {
+		_, _ = fmt.Fprintf(
+			&report,
+			"* Page ID: %s%s",
+			s.Page.ID,
+			nagios.CheckOutputEOL,
+		)
+	}
+
+	if !omitSummaryResults {
+		_, _ = fmt.Fprintf(
+			&report,
+			"%sSummary:%s%s",
+			nagios.CheckOutputEOL,
+			nagios.CheckOutputEOL,
+			nagios.CheckOutputEOL,
+		)
+
+		_, _ = fmt.Fprintf(
+			&report,
+			"* Page: %s (%s)%s",
+			s.Page.Name,
+			s.Page.URL,
+			nagios.CheckOutputEOL,
+		)
+
+		_, _ = fmt.Fprintf(
+			&report,
+			"* Last Updated (%s): %s%s",
+			s.Page.TimeZone,
+			s.Page.UpdatedAt.Format(time.RFC3339),
+			nagios.CheckOutputEOL,
+		)
+
+		// The status.json endpoint does not provide components or incidents,
+		// so only report these details if available (e.g., summary.json).
+		if len(s.Components) > 0 {
+			set := s.ComponentsSet()
+			_, _ = fmt.Fprintf(
+				&report,
+				"* Number of components in a non-operational status: %d of %d%s",
+				set.NumProblemComponents(false),
+				set.NumComponents(),
+				nagios.CheckOutputEOL,
+			)
+		}
+
+		if len(s.Incidents) > 0 {
+			_, _ = fmt.Fprintf(
+				&report,
+				"* Number of unresolved incidents: %d%s",
+				s.NumIncidents(),
+				nagios.CheckOutputEOL,
+			)
+		}
+	}
+
+	return report.String()
+}
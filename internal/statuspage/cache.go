@@ -0,0 +1,294 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+)
+
+// Permissions used when creating the cache directory and cache files. Feed
+// content is public, but there is no need for other users to modify it.
+const (
+	cacheDirPerms  os.FileMode = 0o700
+	cacheFilePerms os.FileMode = 0o600
+)
+
+// File extensions used for cached response bodies and their metadata.
+const (
+	cacheBodyFileExt string = ".json"
+	cacheMetaFileExt string = ".meta.json"
+)
+
+// cacheEntry represents the metadata recorded for a cached response body.
+type cacheEntry struct {
+
+	// URL is the API/JSON feed URL the cached response was retrieved from.
+	URL string `json:"url"`
+
+	// ETag is the entity tag provided by the server for the cached response.
+	ETag string `json:"etag,omitempty"`
+
+	// LastModified is the Last-Modified header value provided by the server
+	// for the cached response.
+	LastModified string `json:"last_modified,omitempty"`
+
+	// StoredAt is when the cached response was stored.
+	StoredAt time.Time `json:"stored_at"`
+
+	// bodyFile is the fully-qualified filename of the cached response body.
+	bodyFile string
+}
+
+// cacheKey returns the base filename (without extension) used to store the
+// cached response for the given URL.
+func cacheKey(apiURL string) string {
+	sum := sha256.Sum256([]byte(apiURL))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// cachePaths returns the fully-qualified filenames used for the cached
+// response body and metadata for the given URL.
+func cachePaths(cacheDir string, apiURL string) (string, string) {
+	key := cacheKey(apiURL)
+
+	return filepath.Join(cacheDir, key+cacheBodyFileExt),
+		filepath.Join(cacheDir, key+cacheMetaFileExt)
+}
+
+// loadCacheEntry retrieves the cache metadata for the given URL. An error is
+// returned if the cache entry does not exist or cannot be read.
+func loadCacheEntry(cacheDir string, apiURL string) (*cacheEntry, error) {
+
+	bodyFile, metaFile := cachePaths(cacheDir, apiURL)
+
+	data, err := os.ReadFile(filepath.Clean(metaFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to decode cache metadata file %s: %w", metaFile, err)
+	}
+
+	if entry.URL != apiURL {
+		return nil, fmt.Errorf(
+			"cache metadata file %s is for URL %q, not %q",
+			metaFile,
+			entry.URL,
+			apiURL,
+		)
+	}
+
+	if _, err := os.Stat(bodyFile); err != nil {
+		return nil, err
+	}
+
+	entry.bodyFile = bodyFile
+
+	return &entry, nil
+}
+
+// storeCacheEntry records the given response body and validator headers
+// from the response for the given URL. Files are written to a temporary file
+// first and then renamed so that concurrent readers do not observe partially
+// written files.
+func storeCacheEntry(cacheDir string, apiURL string, header http.Header, body []byte) error {
+
+	if err := os.MkdirAll(cacheDir, cacheDirPerms); err != nil {
+		return fmt.Errorf("failed to create cache directory %s: %w", cacheDir, err)
+	}
+
+	bodyFile, metaFile := cachePaths(cacheDir, apiURL)
+
+	entry := cacheEntry{
+		URL:          apiURL,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		StoredAt:     time.Now().UTC(),
+	}
+
+	meta, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache metadata: %w", err)
+	}
+
+	if err := writeFileAtomic(bodyFile, body); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(metaFile, meta)
+}
+
+// refreshCacheEntry updates the stored time recorded for the given cache
+// entry to the current time. This is used when the server confirms that the
+// cached response body is still current.
+func refreshCacheEntry(cacheDir string, entry *cacheEntry) error {
+
+	_, metaFile := cachePaths(cacheDir, entry.URL)
+
+	entry.StoredAt = time.Now().UTC()
+
+	meta, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache metadata: %w", err)
+	}
+
+	return writeFileAtomic(metaFile, meta)
+}
+
+// isRetrievalFailure indicates whether the given error represents a failure
+// to retrieve a feed: a transport failure, a timeout or a server error (5xx)
+// or rate limiting (429) response. Only these failures are eligible for
+// decoding the last good response body in place of the feed.
+func isRetrievalFailure(err error) bool {
+	var prepErr *PrepError
+	if !errors.As(err, &prepErr) {
+		return false
+	}
+
+	var netErr net.Error
+
+	switch {
+	case prepErr.Task == PrepTaskSubmitRequest:
+		return true
+
+	case prepErr.StatusCode >= http.StatusInternalServerError,
+		prepErr.StatusCode == http.StatusTooManyRequests:
+		return true
+
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr):
+		return true
+
+	default:
+		return false
+	}
+}
+
+// decodeLastGood decodes the last good (cached) response body for the given
+// URL into the given destination in place of a feed which could not be
+// retrieved. A StaleFeedError wrapping the given fetch error is returned if
+// the last good response body is decoded. The given fetch error is returned
+// as-is if the last good response body is unavailable, older than the
+// maximum age specified by the given options or cannot be decoded.
+func decodeLastGood(dst interface{}, apiURL string, limit int64, allowUnknownFields bool, opts FetchOptions, fetchErr error) error {
+
+	entry, err := loadCacheEntry(opts.CacheDir, apiURL)
+	if err != nil {
+		logger.Printf("No last good response available for %q: %v", apiURL, err)
+
+		return fetchErr
+	}
+
+	age := time.Since(entry.StoredAt)
+	if age > opts.MaxStale {
+		logger.Printf(
+			"Last good response for %q is %v old; exceeds maximum age of %v",
+			apiURL,
+			age,
+			opts.MaxStale,
+		)
+
+		return fetchErr
+	}
+
+	// Discard any values decoded from a partial response before decoding
+	// the last good response body.
+	if v := reflect.ValueOf(dst); v.Kind() == reflect.Pointer && !v.IsNil() {
+		v.Elem().Set(reflect.Zero(v.Elem().Type()))
+	}
+
+	if err := DecodeFromFile(dst, entry.bodyFile, limit, allowUnknownFields); err != nil {
+		logger.Printf("Failed to decode last good response for %q: %v", apiURL, err)
+
+		return fetchErr
+	}
+
+	logger.Printf(
+		"Decoded last good response for %q stored at %v (%v old)",
+		apiURL,
+		entry.StoredAt,
+		age,
+	)
+
+	return &StaleFeedError{
+		Source:   apiURL,
+		StoredAt: entry.StoredAt,
+		Age:      age,
+		Cause:    fetchErr,
+	}
+}
+
+// writeFileAtomic writes the given data to a temporary file in the same
+// directory as the given filename and then renames it into place.
+func writeFileAtomic(filename string, data []byte) error {
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary cache file: %w", err)
+	}
+
+	tmpName := tmp.Name()
+
+	// Remove the temporary file if it was not renamed into place.
+	defer func() {
+		if err := os.Remove(tmpName); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Printf("failed to remove temporary cache file %s: %v", tmpName, err)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write temporary cache file %s: %w", tmpName, err)
+	}
+
+	if err := tmp.Chmod(cacheFilePerms); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to set permissions on temporary cache file %s: %w", tmpName, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary cache file %s: %w", tmpName, err)
+	}
+
+	if err := os.Rename(tmpName, filename); err != nil {
+		return fmt.Errorf("failed to rename temporary cache file to %s: %w", filename, err)
+	}
+
+	return nil
+}
+
+// setConditionalHeaders applies the If-None-Match and If-Modified-Since
+// headers to the given request using the validators recorded for the cache
+// entry (if any).
+func setConditionalHeaders(request *http.Request, entry *cacheEntry) {
+	if entry == nil {
+		return
+	}
+
+	if entry.ETag != "" {
+		request.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	if entry.LastModified != "" {
+		request.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestDecodeFromURLUsesCacheForNotModifiedResponse asserts that a cached
+// response body is decoded when the server indicates that the feed has not
+// been modified since it was cached.
+func TestDecodeFromURLUsesCacheForNotModifiedResponse(t *testing.T) {
+	t.Parallel()
+
+	const etag = `"v1"`
+	const lastModified = "Mon, 02 Jan 2006 15:04:05 GMT"
+
+	var requests, notModified int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		if r.Header.Get("If-None-Match") == etag &&
+			r.Header.Get("If-Modified-Since") == lastModified {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte(`{"page": {"id": "kctbh9vrtdwd", "name": "GitHub"}}`))
+	}))
+	defer server.Close()
+
+	opts := FetchOptions{CacheDir: t.TempDir()}
+
+	type feed struct {
+		Page Page `json:"page"`
+	}
+
+	for i := 1; i <= 2; i++ {
+		var dst feed
+		if err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", opts); err != nil {
+			t.Fatalf("ERROR: request %d: failed to decode feed: %v", i, err)
+		}
+
+		if dst.Page.Name != "GitHub" {
+			t.Errorf("ERROR: request %d: want page name %q, got %q", i, "GitHub", dst.Page.Name)
+		}
+	}
+
+	switch {
+	case atomic.LoadInt32(&requests) != 2:
+		t.Errorf("ERROR: want 2 requests, got %d", requests)
+	case atomic.LoadInt32(&notModified) != 1:
+		t.Errorf("ERROR: want 1 conditional request answered with 304, got %d", notModified)
+	default:
+		t.Log("OK: second request decoded from cache after 304 response")
+	}
+}
+
+// TestDecodeFromURLWithoutCacheRejectsNotModifiedResponse asserts that a
+// "Not Modified" response is treated as an error if there is no cached
+// response body to decode.
+func TestDecodeFromURLWithoutCacheRejectsNotModifiedResponse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	var dst struct{}
+	err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", FetchOptions{CacheDir: t.TempDir()})
+	if err == nil {
+		t.Fatal("ERROR: want error for 304 response without cache entry, got nil")
+	}
+
+	t.Logf("OK: got expected error: %v", err)
+}
+
+// TestDecodeFromURLFallsBackToLastGoodResponse asserts that the last good
+// (cached) response body is decoded in place of a feed which could not be
+// retrieved, but only if it is younger than the specified maximum age.
+func TestDecodeFromURLFallsBackToLastGoodResponse(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"page": {"id": "kctbh9vrtdwd", "name": "GitHub"}}`))
+	}))
+	defer server.Close()
+
+	type feed struct {
+		Page Page `json:"page"`
+	}
+
+	opts := FetchOptions{CacheDir: t.TempDir(), MaxStale: time.Hour}
+
+	var dst feed
+	if err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", opts); err != nil {
+		t.Fatalf("ERROR: failed to decode feed: %v", err)
+	}
+
+	failing.Store(true)
+
+	dst = feed{}
+	err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", opts)
+
+	var staleErr *StaleFeedError
+	switch {
+	case !errors.As(err, &staleErr):
+		t.Fatalf("ERROR: want StaleFeedError, got %v", err)
+	case dst.Page.Name != "GitHub":
+		t.Errorf("ERROR: want page name %q from last good response, got %q", "GitHub", dst.Page.Name)
+	default:
+		t.Logf("OK: got expected error: %v", err)
+	}
+
+	// The last good response is too old to be used.
+	opts.MaxStale = time.Nanosecond
+
+	err = DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", opts)
+
+	var prepErr *PrepError
+	switch {
+	case errors.As(err, &staleErr):
+		t.Errorf("ERROR: want last good response to be rejected as too old, got %v", err)
+	case !errors.As(err, &prepErr):
+		t.Errorf("ERROR: want PrepError, got %v", err)
+	default:
+		t.Logf("OK: got expected error: %v", err)
+	}
+}
+
+// TestDecodeFromURLFallsBackOnlyForRetrievalFailures asserts that the last
+// good (cached) response body is decoded in place of a feed which could not
+// be retrieved, but not in place of a retrieved feed which is invalid.
+func TestDecodeFromURLFallsBackOnlyForRetrievalFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		wantStale     bool
+		wantPrepTask  string
+		wantPrepError bool
+	}{
+		{
+			name:       "Rate limited response",
+			statusCode: http.StatusTooManyRequests,
+			wantStale:  true,
+		},
+		{
+			name:       "Server error response",
+			statusCode: http.StatusBadGateway,
+			wantStale:  true,
+		},
+		{
+			name:          "Client error response",
+			statusCode:    http.StatusNotFound,
+			wantPrepTask:  PrepTaskProcessResponse,
+			wantPrepError: true,
+		},
+		{
+			name:          "Invalid JSON response",
+			statusCode:    http.StatusOK,
+			body:          `{"page": {"id": "kctbh9vrtdwd", "name": `,
+			wantPrepTask:  PrepTaskDecode,
+			wantPrepError: true,
+		},
+		{
+			name:          "Oversized response",
+			statusCode:    http.StatusOK,
+			body:          `{"page": {"id": "kctbh9vrtdwd", "name": "` + strings.Repeat("x", 2048) + `"}}`,
+			wantPrepTask:  PrepTaskProcessResponse,
+			wantPrepError: true,
+		},
+	}
+
+	type feed struct {
+		Page Page `json:"page"`
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var failing atomic.Bool
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if failing.Load() {
+					w.WriteHeader(test.statusCode)
+					_, _ = w.Write([]byte(test.body))
+
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"page": {"id": "kctbh9vrtdwd", "name": "GitHub"}}`))
+			}))
+			defer server.Close()
+
+			opts := FetchOptions{CacheDir: t.TempDir(), MaxStale: time.Hour}
+
+			var dst feed
+			if err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", opts); err != nil {
+				t.Fatalf("ERROR: failed to decode feed: %v", err)
+			}
+
+			failing.Store(true)
+
+			dst = feed{}
+			err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", opts)
+
+			var staleErr *StaleFeedError
+			var prepErr *PrepError
+			switch {
+			case test.wantStale && !errors.As(err, &staleErr):
+				t.Errorf("ERROR: want StaleFeedError, got %v", err)
+			case test.wantStale && dst.Page.Name != "GitHub":
+				t.Errorf("ERROR: want page name %q from last good response, got %q", "GitHub", dst.Page.Name)
+			case test.wantPrepError && errors.As(err, &staleErr):
+				t.Errorf("ERROR: want error to be returned as-is, got %v", err)
+			case test.wantPrepError && !errors.As(err, &prepErr):
+				t.Errorf("ERROR: want PrepError, got %v", err)
+			case test.wantPrepError && prepErr.Task != test.wantPrepTask:
+				t.Errorf("ERROR: want PrepError task %q, got %q", test.wantPrepTask, prepErr.Task)
+			default:
+				t.Logf("OK: got expected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestDecodeFromURLWithCacheRejectsOversizedResponse asserts that a response
+// exceeding the read limit is rejected (and not cached) when a cache
+// directory is specified instead of being truncated to the limit.
+func TestDecodeFromURLWithCacheRejectsOversizedResponse(t *testing.T) {
+	t.Parallel()
+
+	oversizedFeed := []byte(strings.Replace(testFeed, "{", "{"+strings.Repeat(" ", 4096), 1))
+
+	tests := []struct {
+		name string
+		gzip bool
+	}{
+		{
+			name: "Uncompressed response",
+		},
+		{
+			name: "Gzip compressed response",
+			gzip: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if test.gzip {
+					w.Header().Set("Content-Encoding", "gzip")
+					_, _ = w.Write(gzipData(t, oversizedFeed))
+
+					return
+				}
+
+				_, _ = w.Write(oversizedFeed)
+			}))
+			defer server.Close()
+
+			cacheDir := t.TempDir()
+
+			var dst testPage
+			err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, false, "", FetchOptions{CacheDir: cacheDir})
+			if !errors.Is(err, ErrReadLimitExceeded) {
+				t.Fatalf("ERROR: want error %v, got %v", ErrReadLimitExceeded, err)
+			}
+			t.Logf("OK: got expected error: %v", err)
+
+			entries, err := os.ReadDir(cacheDir)
+			switch {
+			case err != nil:
+				t.Fatalf("ERROR: failed to read cache directory: %v", err)
+			case len(entries) != 0:
+				t.Errorf("ERROR: want empty cache directory, got %d entries", len(entries))
+			default:
+				t.Log("OK: oversized response was not cached")
+			}
+		})
+	}
+}
+
+// TestDecodeFromURLRejectsOversizedResponse asserts that an uncompressed
+// response exceeding the read limit is rejected with ErrReadLimitExceeded
+// when no cache directory is specified.
+func TestDecodeFromURLRejectsOversizedResponse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(strings.Replace(testFeed, "{", "{"+strings.Repeat(" ", 4096), 1)))
+	}))
+	defer server.Close()
+
+	var dst testPage
+	err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, false, "", FetchOptions{})
+	if !errors.Is(err, ErrReadLimitExceeded) {
+		t.Fatalf("ERROR: want error %v, got %v", ErrReadLimitExceeded, err)
+	}
+
+	t.Logf("OK: got expected error: %v", err)
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// ErrNoCertificatesInCABundle indicates that a specified CA bundle file did
+// not contain any PEM encoded certificates.
+var ErrNoCertificatesInCABundle = errors.New(
+	"no PEM encoded certificates found in CA bundle",
+)
+
+// newHTTPClient returns a http.Client using the proxy and TLS settings
+// specified by the given options. Settings not specified by the given
+// options use the same defaults as http.DefaultTransport, including proxy
+// settings from environment variables.
+func newHTTPClient(opts FetchOptions) (*http.Client, error) {
+
+	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf(
+			"unexpected default transport type %T",
+			http.DefaultTransport,
+		)
+	}
+
+	transport := defaultTransport.Clone()
+
+	if opts.ProxyURL != "" {
+		proxyURL, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proxy URL: %w", err)
+		}
+
+		logger.Printf("Using proxy %s in place of environment settings", proxyURL.Redacted())
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// newTLSConfig returns a tls.Config using the CA bundle, client certificate,
+// minimum TLS version and certificate verification settings specified by the
+// given options.
+func newTLSConfig(opts FetchOptions) (*tls.Config, error) {
+
+	tlsConfig := &tls.Config{
+		MinVersion: opts.MinTLSVersion,
+	}
+
+	if opts.CABundle != "" {
+		pemData, err := os.ReadFile(filepath.Clean(opts.CABundle))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			logger.Printf("Failed to load system certificate pool, using CA bundle only: %v", err)
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pemData) {
+			return nil, fmt.Errorf(
+				"%w: %s",
+				ErrNoCertificatesInCABundle,
+				opts.CABundle,
+			)
+		}
+
+		logger.Printf("Trusting certificates from CA bundle %s", opts.CABundle)
+		tlsConfig.RootCAs = pool
+	}
+
+	if opts.ClientCert != "" || opts.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(
+			filepath.Clean(opts.ClientCert),
+			filepath.Clean(opts.ClientKey),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate and key: %w", err)
+		}
+
+		logger.Printf("Presenting client certificate %s", opts.ClientCert)
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if opts.InsecureSkipVerify {
+		logger.Print("WARNING: TLS certificate verification is disabled")
+
+		// #nosec G402
+		// Explicitly requested by the user (e.g., for troubleshooting).
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	return tlsConfig, nil
+}
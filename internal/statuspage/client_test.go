@@ -0,0 +1,137 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// testFeed is the JSON data served by test servers in this file.
+const testFeed = `{"page": {"id": "kctbh9vrtdwd", "name": "GitHub"}}`
+
+// TestDecodeFromURLWithCABundle asserts that a server certificate issued by
+// a CA from the specified CA bundle is trusted and that the same certificate
+// is rejected without the CA bundle.
+func TestDecodeFromURLWithCABundle(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(testFeed))
+	}))
+	defer server.Close()
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	pemData := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.Certificate().Raw,
+	})
+	if err := os.WriteFile(caBundle, pemData, 0o600); err != nil {
+		t.Fatalf("ERROR: failed to write CA bundle: %v", err)
+	}
+
+	var dst struct {
+		Page Page `json:"page"`
+	}
+
+	err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", FetchOptions{})
+	if err == nil {
+		t.Fatal("ERROR: want certificate verification error without CA bundle, got nil")
+	}
+	t.Logf("OK: got expected error without CA bundle: %v", err)
+
+	err = DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", FetchOptions{CABundle: caBundle})
+	switch {
+	case err != nil:
+		t.Fatalf("ERROR: failed to decode feed using CA bundle: %v", err)
+	case dst.Page.Name != "GitHub":
+		t.Errorf("ERROR: want page name %q, got %q", "GitHub", dst.Page.Name)
+	default:
+		t.Log("OK: server certificate trusted using CA bundle")
+	}
+}
+
+// TestDecodeFromURLWithInvalidCABundle asserts that a CA bundle without any
+// certificates is rejected when preparing the HTTP client.
+func TestDecodeFromURLWithInvalidCABundle(t *testing.T) {
+	t.Parallel()
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caBundle, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("ERROR: failed to write CA bundle: %v", err)
+	}
+
+	var dst struct{}
+	err := DecodeFromURL(context.Background(), &dst, "https://127.0.0.1/", 1024, true, "", FetchOptions{CABundle: caBundle})
+
+	switch {
+	case !errors.Is(err, &PrepError{Task: PrepTaskPrepareClient}):
+		t.Errorf("ERROR: want %q task failure, got %v", PrepTaskPrepareClient, err)
+	case !errors.Is(err, ErrNoCertificatesInCABundle):
+		t.Errorf("ERROR: want ErrNoCertificatesInCABundle, got %v", err)
+	default:
+		t.Logf("OK: got expected error: %v", err)
+	}
+}
+
+// TestDecodeFromURLWithInsecureSkipVerify asserts that an untrusted server
+// certificate is accepted if certificate verification is disabled.
+func TestDecodeFromURLWithInsecureSkipVerify(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(testFeed))
+	}))
+	defer server.Close()
+
+	var dst struct{}
+	opts := FetchOptions{InsecureSkipVerify: true}
+	if err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", opts); err != nil {
+		t.Fatalf("ERROR: failed to decode feed with verification disabled: %v", err)
+	}
+
+	t.Log("OK: untrusted server certificate accepted with verification disabled")
+}
+
+// TestDecodeFromURLWithProxy asserts that requests are submitted via the
+// specified proxy.
+func TestDecodeFromURLWithProxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied int32
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Requests sent to a proxy use the absolute form of the target URL.
+		if r.URL.Host == "status.example.invalid" {
+			atomic.AddInt32(&proxied, 1)
+		}
+
+		_, _ = w.Write([]byte(testFeed))
+	}))
+	defer proxy.Close()
+
+	var dst struct{}
+	opts := FetchOptions{ProxyURL: proxy.URL}
+	err := DecodeFromURL(context.Background(), &dst, "http://status.example.invalid/api/v2/components.json", 1024, true, "", opts)
+
+	switch {
+	case err != nil:
+		t.Fatalf("ERROR: failed to decode feed via proxy: %v", err)
+	case atomic.LoadInt32(&proxied) != 1:
+		t.Errorf("ERROR: want 1 request via proxy, got %d", proxied)
+	default:
+		t.Log("OK: request submitted via proxy")
+	}
+}
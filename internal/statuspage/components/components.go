@@ -9,14 +9,8 @@ package components
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -60,11 +54,11 @@ const (
 
 // Prep tasks for creation of a components Set.
 const (
-	PrepTaskParseURL        string = "parse URL"
-	PrepTaskPrepareRequest  string = "prepare request"
-	PrepTaskDecode          string = "decode JSON data"
-	PrepTaskSubmitRequest   string = "submit request"
-	PrepTaskProcessResponse string = "process response"
+	PrepTaskParseURL        string = statuspage.PrepTaskParseURL
+	PrepTaskPrepareRequest  string = statuspage.PrepTaskPrepareRequest
+	PrepTaskDecode          string = statuspage.PrepTaskDecode
+	PrepTaskSubmitRequest   string = statuspage.PrepTaskSubmitRequest
+	PrepTaskProcessResponse string = statuspage.PrepTaskProcessResponse
 )
 
 // Set represents the collection of components for a Statuspage-enabled site.
@@ -74,13 +68,7 @@ const (
 // wget https://status.box.com/api/v2/components.json
 // json2struct -f components.json
 type Set struct {
-	Page struct {
-		ID        string    `json:"id"`
-		Name      string    `json:"name"`
-		TimeZone  string    `json:"time_zone"`
-		UpdatedAt time.Time `json:"updated_at"`
-		URL       string    `json:"url"`
-	} `json:"page"`
+	Page statuspage.Page `json:"page"`
 
 	// FilterUsed indicates what Filter values were applied to this Set (if
 	// any).
@@ -96,6 +84,30 @@ type Set struct {
 	// EvalAllComponents indicates whether the user has opted to skip
 	// filtering entirely and evaluate all components.
 	EvalAllComponents bool `json:"-"`
+
+	// StatusMap is the user-specified mapping of component statuses to
+	// Nagios ServiceState values used when evaluating the Set. The default
+	// mapping is used if not set.
+	StatusMap StatusMap `json:"-"`
+
+	// GroupRollup controls whether component groups are evaluated as a
+	// single unit in place of their subcomponents. Subcomponents are
+	// evaluated separately if not set.
+	GroupRollup GroupRollup `json:"-"`
+
+	// severityOverrides is the collection of user-specified severity
+	// overrides indexed by the ID of the matching component or component
+	// group.
+	severityOverrides map[string]SeverityOverride
+
+	// gracePeriod is the collection of IDs for components with a
+	// non-operational status which has not yet persisted for the minimum
+	// problem duration.
+	gracePeriod map[string]struct{}
+
+	// minProblemDuration is the minimum duration a non-operational status
+	// must persist before a component is evaluated as a problem.
+	minProblemDuration time.Duration
 }
 
 // Component represents one of the components defined for a Statuspage-enabled
@@ -189,191 +201,66 @@ type ComponentGroup struct {
 // Filter is a WIP. Need to setup logic that checks component strings for
 // potential IDs, then falls back to Name match attempts. Same for Group.
 type Filter struct {
-	Group      string
-	Components []string
-}
 
-// prepareRequest is a helper function that prepares a http.Request (including
-// all desired headers) for submission to an endpoint.
-func prepareRequest(ctx context.Context, apiURL string, userAgent string) (*http.Request, error) {
-	logger.Printf("Validating URL %q before attempting to read data", apiURL)
-	parsedURL, err := url.Parse(apiURL)
-	if err != nil {
-		return nil, &PrepError{
-			Task:    PrepTaskParseURL,
-			Message: "error parsing URL",
-			Source:  apiURL,
-			Cause:   err,
-		}
-	}
-	logger.Printf("Successfully validated URL %q", apiURL)
+	// Groups is an optional collection of component groups, each optionally
+	// paired with a list of its subcomponents. If a group is not paired with
+	// any subcomponents, all subcomponents of the group are matched.
+	Groups []GroupFilter
 
-	logger.Print("Preparing HTTP request")
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
-	if err != nil {
-		return nil, &PrepError{
-			Task:    PrepTaskPrepareRequest,
-			Source:  apiURL,
-			Message: "error preparing request for URL",
-			Cause:   err,
-		}
-	}
-
-	// Explicitly note that we want JSON content.
-	request.Header.Add("Content-Type", "application/json;charset=utf-8")
-
-	// If provided, override the default Go user agent ("Go-http-client/1.1")
-	// with custom value.
-	if userAgent != "" {
-		request.Header.Set("User-Agent", userAgent)
-	}
+	// Components is an optional collection of components which are not
+	// paired with a component group.
+	Components []string
 
-	return request, nil
+	// MatchMode is the mode used to match group and component values
+	// against component names. If not specified, MatchModeExact is used. ID
+	// values are always matched case-insensitively.
+	MatchMode string
+
+	// ExcludeGroups is an optional collection of component groups excluded
+	// from evaluation along with all of their subcomponents. If specified
+	// without Groups or Components values, all other components are
+	// evaluated.
+	ExcludeGroups []string
+
+	// ExcludeComponents is an optional collection of components excluded
+	// from evaluation. If specified without Groups or Components values, all
+	// other components are evaluated.
+	ExcludeComponents []string
 }
 
-// processResponse is a helper function responsible for validating a response
-// from an endpoint after submitting a message.
-func processResponse(ctx context.Context, response *http.Response, limit int64) error {
-
-	feedSource := response.Request.URL.RequestURI()
-
-	if err := ctx.Err(); err != nil {
-		logger.Print("context has expired")
-		return &PrepError{
-			Task:    PrepTaskProcessResponse,
-			Message: "timeout reached",
-			Source:  feedSource,
-			Cause:   err,
-		}
-	}
-
-	switch {
-	case response.ContentLength == -1:
-		logger.Printf("Response indicates unknown length of content from %q", feedSource)
-	default:
-		logger.Printf(
-			"Response indicates %d bytes available to be read from %q",
-			response.ContentLength,
-			feedSource,
-		)
-	}
-
-	switch {
-
-	// Successful / expected response.
-	case response.StatusCode == http.StatusOK:
-		logger.Printf("Status code %d received as expected", response.StatusCode)
-
-		return nil
-
-	// Success status range, but not in API docs for listing components.
-	case response.StatusCode > 200 && response.StatusCode <= 299:
-		logger.Printf(
-			"Status code %d (%s) received; expected %d (%s), but received value within success range",
-			response.StatusCode,
-			http.StatusText(response.StatusCode),
-			http.StatusOK,
-			http.StatusText(http.StatusOK),
-		)
-
-		return nil
-
-	// Everything else is assumed to be an error (outside of success range).
-	default:
-
-		// Get the response body, then convert to string for use with extended
-		// error messages
-		responseData, readErr := io.ReadAll(io.LimitReader(response.Body, limit))
-		if readErr != nil {
-			logger.Print(readErr)
-
-			return &PrepError{
-				Task:    PrepTaskProcessResponse,
-				Message: "error reading response data",
-				Source:  feedSource,
-				Cause:   readErr,
-			}
-		}
-		responseString := string(responseData)
-
-		statusCodeErr := fmt.Errorf(
-			"response %v (%s) from API: %w",
-			response.Status,
-			responseString,
-			ErrResponseOutsideRange,
-		)
-
-		return &PrepError{
-			Task:    PrepTaskProcessResponse,
-			Message: "unexpected response",
-			Source:  feedSource,
-			Cause:   statusCodeErr,
-		}
-
-	}
-
+// GroupFilter pairs a component group (name or ID) with an optional list of
+// subcomponents (name or ID). If specified, all components are required to
+// be subcomponents of the group.
+//
+// GroupFilter is an alias for an unnamed struct type so that an equivalent
+// filter type defined by another package (e.g., for command-line flags) can
+// be converted to a Filter.
+type GroupFilter = struct {
+	Group      string
+	Components []string
 }
 
 // NewFromURL constructs a components Set by reading and decoding JSON data
 // from a specified URL using the specified number of bytes as the read limit.
 // If specified, unknown fields in the JSON file are ignored. An error is
 // returned if there are problems reading and decoding JSON data. If provided,
-// a custom user agent is supplied in place of the default Go user agent.
-func NewFromURL(ctx context.Context, apiURL string, limit int64, allowUnknownFields bool, userAgent string) (*Set, error) {
+// a custom user agent is supplied in place of the default Go user agent. The
+// given options control optional behavior such as response caching. If the
+// last good copy of the feed is used, the Set is returned along with a
+// StaleFeedError.
+func NewFromURL(ctx context.Context, apiURL string, limit int64, allowUnknownFields bool, userAgent string, opts statuspage.FetchOptions) (*Set, error) {
 
-	request, err := prepareRequest(ctx, apiURL, userAgent)
-	if err != nil {
-		return &Set{}, err
-	}
-
-	logger.Print("Submitting HTTP request")
-	c := &http.Client{}
-	response, err := c.Do(request)
-	if err != nil {
-		return &Set{}, &PrepError{
-			Task:    PrepTaskSubmitRequest,
-			Message: "error submitting HTTP request",
-			Source:  apiURL,
-			Cause:   err,
-		}
-	}
-
-	logger.Print("Successfully submitted HTTP request")
-
-	// Make sure that we close the response body once we're done with it
-	defer func() {
-		if err := response.Body.Close(); err != nil {
-			logger.Printf("error closing response body: %v", err)
-		}
-	}()
+	var set Set
+	err := statuspage.DecodeFromURL(ctx, &set, apiURL, limit, allowUnknownFields, userAgent, opts)
 
-	// Evaluate the response
-	if err := processResponse(ctx, response, limit); err != nil {
+	var staleErr *StaleFeedError
+	switch {
+	case errors.As(err, &staleErr):
+		return &set, err
+	case err != nil:
 		return &Set{}, err
 	}
 
-	logger.Printf(
-		"Decoding JSON data from %q using a limit of %d bytes",
-		apiURL,
-		limit,
-	)
-
-	var set Set
-	err = decode(&set, response.Body, apiURL, limit, allowUnknownFields)
-	if err != nil {
-		return &Set{}, &PrepError{
-			Task:    PrepTaskDecode,
-			Message: "failed to decode JSON data",
-			Source:  apiURL,
-			Cause:   err,
-		}
-	}
-
-	logger.Printf(
-		"No errors encountered while decoding JSON data from %q",
-		apiURL,
-	)
-
 	return &set, nil
 
 }
@@ -381,96 +268,72 @@ func NewFromURL(ctx context.Context, apiURL string, limit int64, allowUnknownFie
 // NewFromFile constructs a components Set by reading and decoding JSON data
 // from a fully-qualified path to a JSON file using the specified number of
 // bytes as the read limit. If specified, unknown fields in the JSON file are
-// ignored. An error is returned if there are problems reading the specified
-// file.
+// ignored. Gzip (.gz) and zstd (.zst) compressed files are decompressed
+// transparently; the read limit applies to the decompressed size. A
+// PrepError is returned if there are problems reading the specified file or
+// decoding JSON data.
 func NewFromFile(filename string, limit int64, allowUnknownFields bool) (*Set, error) {
 
-	logger.Printf("Opening file %s for reading", filename)
-	fh, err := os.Open(filepath.Clean(filename))
-	if err != nil {
-		return &Set{}, fmt.Errorf("failed to open file for reading: %w", err)
-	}
-	logger.Printf("Successfully opened file %s for reading", filename)
-
-	// #nosec G307
-	// Believed to be a false-positive from recent gosec release
-	// https://github.com/securego/gosec/issues/714
-	defer func() {
-		if err := fh.Close(); err != nil {
-			// Ignore "file already closed" errors
-			if !errors.Is(err, os.ErrClosed) {
-				logger.Printf("failed to close file %s: %s",
-					filename,
-					err.Error(),
-				)
-			}
-		}
-	}()
-
-	logger.Printf(
-		"Decoding JSON file %s using a limit of %d bytes",
-		filename,
-		limit,
-	)
-
 	var set Set
-	err = decode(&set, fh, filename, limit, allowUnknownFields)
+	err := statuspage.DecodeFromFile(&set, filename, limit, allowUnknownFields)
 	if err != nil {
-		return &Set{}, fmt.Errorf(
-			"failed to decode JSON data: %w",
-			err,
-		)
+		return &Set{}, err
 	}
 
-	logger.Printf(
-		"No errors encountered while decoding JSON file %s",
-		filename,
-	)
-
 	return &set, nil
 }
 
-// decode is a helper function intended to handle the core JSON decoding tasks
-// for various JSON sources (file, http body, etc.).
-func decode(dst interface{}, reader io.Reader, sourceName string, limit int64, allowUnknownFields bool) error {
-
-	logger.Printf(
-		"Setting up JSON decoder for source %s with a limit of %d bytes",
-		sourceName,
-		limit,
-	)
-	dec := json.NewDecoder(io.LimitReader(reader, limit))
-
-	switch {
-	case !allowUnknownFields:
-		logger.Print("Disallowing unknown JSON feed fields")
-		dec.DisallowUnknownFields()
-	default:
-		logger.Print("Allowing unknown JSON feed fields by request")
-	}
+// NewFromSources constructs a components Set for each of the specified
+// sources using the specified number of bytes as the read limit. Up to the
+// specified number of sources are retrieved concurrently. If specified,
+// unknown fields in the JSON data are ignored. If provided, a custom user
+// agent is supplied in place of the default Go user agent. The given options
+// control optional behavior such as response caching.
+//
+// Sets and errors are returned in the same order as the given sources. The
+// Set for a source which could not be retrieved is nil and the error for a
+// source which was successfully retrieved is nil. Errors are returned as
+// PrepError values. If the last good copy of a feed was used, the Set for
+// the source is returned along with a StaleFeedError.
+func NewFromSources(
+	ctx context.Context,
+	sources []statuspage.FeedSource,
+	concurrency int,
+	limit int64,
+	allowUnknownFields bool,
+	userAgent string,
+	opts statuspage.FetchOptions,
+) ([]*Set, []error) {
+
+	sets := make([]*Set, len(sources))
+
+	errs := statuspage.FetchAll(
+		ctx,
+		sources,
+		concurrency,
+		func(ctx context.Context, index int, source statuspage.FeedSource) error {
+			var set *Set
+			var err error
 
-	logger.Print("Decoding JSON input")
+			switch {
+			case source.Filename != "":
+				set, err = NewFromFile(source.Filename, limit, allowUnknownFields)
+			default:
+				set, err = NewFromURL(ctx, source.URL, limit, allowUnknownFields, userAgent, opts)
+			}
 
-	// Decode the first JSON object.
-	if err := dec.Decode(dst); err != nil {
-		return fmt.Errorf(
-			"failed to decode JSON feed from source %s: %w",
-			sourceName,
-			err,
-		)
-	}
-	logger.Print("Successfully decoded JSON input")
+			var staleErr *StaleFeedError
+			if err != nil && !errors.As(err, &staleErr) {
+				return err
+			}
 
-	// If there is more than one object, something is off.
-	if dec.More() {
-		return fmt.Errorf(
-			"source %s contains multiple JSON objects; only one JSON object is supported",
-			sourceName,
-		)
-	}
+			sets[index] = set
 
-	return nil
+			return err
+		},
+	)
 
+	return sets, errs
 }
 
 // ServiceStateToComponentStatuses converts a given Nagios ServiceState to a
@@ -555,38 +418,119 @@ func ComponentStatusToServiceState(componentStatus string) nagios.ServiceState {
 
 // String implements the Stringer interface for a components Filter.
 func (f Filter) String() string {
-	return fmt.Sprintf(
-		`{Group: "%s", Components: "%s"}`,
-		f.Group,
+	var filter strings.Builder
+
+	groups := make([]string, 0, len(f.Groups))
+	for _, group := range f.Groups {
+		switch {
+		case len(group.Components) > 0:
+			groups = append(groups, fmt.Sprintf(
+				"%s (%s)",
+				group.Group,
+				strings.Join(group.Components, ", "),
+			))
+		default:
+			groups = append(groups, group.Group)
+		}
+	}
+
+	_, _ = fmt.Fprintf(
+		&filter,
+		`{Groups: "%s", Components: "%s"`,
+		strings.Join(groups, "; "),
 		strings.Join(f.Components, ", "),
 	)
+
+	if len(f.ExcludeGroups) > 0 {
+		_, _ = fmt.Fprintf(
+			&filter,
+			`, ExcludeGroups: "%s"`,
+			strings.Join(f.ExcludeGroups, ", "),
+		)
+	}
+
+	if len(f.ExcludeComponents) > 0 {
+		_, _ = fmt.Fprintf(
+			&filter,
+			`, ExcludeComponents: "%s"`,
+			strings.Join(f.ExcludeComponents, ", "),
+		)
+	}
+
+	if f.MatchMode != "" && !strings.EqualFold(f.MatchMode, MatchModeExact) {
+		_, _ = fmt.Fprintf(&filter, `, MatchMode: "%s"`, f.MatchMode)
+	}
+
+	filter.WriteString("}")
+
+	return filter.String()
+}
+
+// HasExclusions indicates whether component group or component exclusion
+// values were specified for the filter.
+func (f Filter) HasExclusions() bool {
+	return len(f.ExcludeGroups) > 0 || len(f.ExcludeComponents) > 0
 }
 
 // Validate performs basic validation to assert that given filter settings are
 // valid.
 func (f Filter) Validate() error {
 
-	// Assert that one of group or components list were provided.
-	if f.Group == "" && len(f.Components) == 0 {
+	// Assert that one of groups, components list or exclusions were
+	// provided.
+	if len(f.Groups) == 0 && len(f.Components) == 0 && !f.HasExclusions() {
 		return ErrComponentSetFilterEmpty
 	}
 
 	// Assert that group, component flags were not provided only
 	// whitespace characters.
-	switch {
-	case f.Group != "":
-		if strings.TrimSpace(f.Group) == "" {
+	for _, group := range f.Groups {
+		if strings.TrimSpace(group.Group) == "" {
 			return ErrComponentSetFilterWhitespaceGroupField
 		}
 
-	case len(f.Components) > 0:
-		for _, component := range f.Components {
+		for _, component := range group.Components {
 			if strings.TrimSpace(component) == "" {
 				return ErrComponentSetFilterWhitespaceComponentsField
 			}
 		}
 	}
 
+	for _, component := range f.Components {
+		if strings.TrimSpace(component) == "" {
+			return ErrComponentSetFilterWhitespaceComponentsField
+		}
+	}
+
+	for _, group := range f.ExcludeGroups {
+		if strings.TrimSpace(group) == "" {
+			return ErrComponentSetFilterWhitespaceGroupField
+		}
+	}
+
+	for _, component := range f.ExcludeComponents {
+		if strings.TrimSpace(component) == "" {
+			return ErrComponentSetFilterWhitespaceComponentsField
+		}
+	}
+
+	// Assert that the match mode is supported and that group, component
+	// values are valid patterns for the match mode.
+	values := make([]string, 0, len(f.Components)+len(f.ExcludeGroups)+len(f.ExcludeComponents))
+	for _, group := range f.Groups {
+		values = append(values, group.Group)
+		values = append(values, group.Components...)
+	}
+	values = append(values, f.Components...)
+	values = append(values, f.ExcludeGroups...)
+	values = append(values, f.ExcludeComponents...)
+
+	for _, value := range values {
+		if _, err := newNameMatcher(value, f.MatchMode); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -875,6 +819,9 @@ func (cs *Set) AreOnlyIDsInListExcluded(componentIDs []string) bool {
 // specific non-operational status which maps to an CRITICAL state. component
 // Groups are not included since groups mirror the status of subcomponents.
 //
+// Component groups evaluated as a single unit per the GroupRollup for the
+// set are included in place of their subcomponents.
+//
 // A boolean value is accepted which indicates whether component values marked
 // for exclusion (during filtering) should also be considered. The caller is
 // responsible for filtering the collection prior to calling this method.
@@ -901,14 +848,11 @@ func (cs *Set) HasCriticalState(evalExcluded bool) bool {
 	for i := range cs.Components {
 
 		switch {
-		case cs.Components[i].Group:
-			continue
-
-		case cs.Components[i].Exclude && !evalExcluded:
+		case !cs.isEvaluated(&cs.Components[i], evalExcluded):
 			continue
 
 		default:
-			serviceState := ComponentStatusToServiceState(cs.Components[i].Status)
+			serviceState, _ := cs.ComponentServiceState(&cs.Components[i])
 			if serviceState.ExitCode == nagios.StateCRITICALExitCode {
 				hasCriticalState = true
 			}
@@ -923,6 +867,9 @@ func (cs *Set) HasCriticalState(evalExcluded bool) bool {
 // Groups are not included in the count since groups mirror the status of
 // subcomponents.
 //
+// Component groups evaluated as a single unit per the GroupRollup for the
+// set are included in place of their subcomponents.
+//
 // A boolean value is accepted which indicates whether all component values
 // are evaluated or only those not marked for exclusion. The caller is
 // responsible for filtering the collection prior to calling this method.
@@ -949,14 +896,11 @@ func (cs *Set) NumCriticalState(evalExcluded bool) int {
 	for i := range cs.Components {
 
 		switch {
-		case cs.Components[i].Group:
-			continue
-
-		case cs.Components[i].Exclude && !evalExcluded:
+		case !cs.isEvaluated(&cs.Components[i], evalExcluded):
 			continue
 
 		default:
-			serviceState := ComponentStatusToServiceState(cs.Components[i].Status)
+			serviceState, _ := cs.ComponentServiceState(&cs.Components[i])
 			if serviceState.ExitCode == nagios.StateCRITICALExitCode {
 				numCriticalState++
 			}
@@ -971,6 +915,9 @@ func (cs *Set) NumCriticalState(evalExcluded bool) int {
 // specific non-operational status which maps to an WARNING state. component
 // Groups are not included since groups mirror the status of subcomponents.
 //
+// Component groups evaluated as a single unit per the GroupRollup for the
+// set are included in place of their subcomponents.
+//
 // A boolean value is accepted which indicates whether component values marked
 // for exclusion (during filtering) should also be considered. The caller is
 // responsible for filtering the collection prior to calling this method.
@@ -997,14 +944,11 @@ func (cs *Set) HasWarningState(evalExcluded bool) bool {
 	for i := range cs.Components {
 
 		switch {
-		case cs.Components[i].Group:
-			continue
-
-		case cs.Components[i].Exclude && !evalExcluded:
+		case !cs.isEvaluated(&cs.Components[i], evalExcluded):
 			continue
 
 		default:
-			serviceState := ComponentStatusToServiceState(cs.Components[i].Status)
+			serviceState, _ := cs.ComponentServiceState(&cs.Components[i])
 			if serviceState.ExitCode == nagios.StateWARNINGExitCode {
 				hasWarningState = true
 			}
@@ -1019,6 +963,9 @@ func (cs *Set) HasWarningState(evalExcluded bool) bool {
 // Groups are not included in the count since groups mirror the status of
 // subcomponents.
 //
+// Component groups evaluated as a single unit per the GroupRollup for the
+// set are included in place of their subcomponents.
+//
 // A boolean value is accepted which indicates whether all component values
 // are evaluated or only those not marked for exclusion. The caller is
 // responsible for filtering the collection prior to calling this method.
@@ -1045,14 +992,11 @@ func (cs *Set) NumWarningState(evalExcluded bool) int {
 	for i := range cs.Components {
 
 		switch {
-		case cs.Components[i].Group:
-			continue
-
-		case cs.Components[i].Exclude && !evalExcluded:
+		case !cs.isEvaluated(&cs.Components[i], evalExcluded):
 			continue
 
 		default:
-			serviceState := ComponentStatusToServiceState(cs.Components[i].Status)
+			serviceState, _ := cs.ComponentServiceState(&cs.Components[i])
 			if serviceState.ExitCode == nagios.StateWARNINGExitCode {
 				numWarningState++
 			}
@@ -1067,6 +1011,9 @@ func (cs *Set) NumWarningState(evalExcluded bool) int {
 // specific non-operational status which maps to an UNKNOWN state. component
 // Groups are not included since groups mirror the status of subcomponents.
 //
+// Component groups evaluated as a single unit per the GroupRollup for the
+// set are included in place of their subcomponents.
+//
 // A boolean value is accepted which indicates whether component values marked
 // for exclusion (during filtering) should also be considered. The caller is
 // responsible for filtering the collection prior to calling this method.
@@ -1093,14 +1040,11 @@ func (cs *Set) HasUnknownState(evalExcluded bool) bool {
 	for i := range cs.Components {
 
 		switch {
-		case cs.Components[i].Group:
-			continue
-
-		case cs.Components[i].Exclude && !evalExcluded:
+		case !cs.isEvaluated(&cs.Components[i], evalExcluded):
 			continue
 
 		default:
-			serviceState := ComponentStatusToServiceState(cs.Components[i].Status)
+			serviceState, _ := cs.ComponentServiceState(&cs.Components[i])
 			if serviceState.ExitCode == nagios.StateUNKNOWNExitCode {
 				hasUnknownState = true
 			}
@@ -1115,6 +1059,9 @@ func (cs *Set) HasUnknownState(evalExcluded bool) bool {
 // Groups are not included in the count since groups mirror the status of
 // subcomponents.
 //
+// Component groups evaluated as a single unit per the GroupRollup for the
+// set are included in place of their subcomponents.
+//
 // A boolean value is accepted which indicates whether all component values
 // are evaluated or only those not marked for exclusion. The caller is
 // responsible for filtering the collection prior to calling this method.
@@ -1141,14 +1088,11 @@ func (cs *Set) NumUnknownState(evalExcluded bool) int {
 	for i := range cs.Components {
 
 		switch {
-		case cs.Components[i].Group:
-			continue
-
-		case cs.Components[i].Exclude && !evalExcluded:
+		case !cs.isEvaluated(&cs.Components[i], evalExcluded):
 			continue
 
 		default:
-			serviceState := ComponentStatusToServiceState(cs.Components[i].Status)
+			serviceState, _ := cs.ComponentServiceState(&cs.Components[i])
 			if serviceState.ExitCode == nagios.StateUNKNOWNExitCode {
 				numUnknownState++
 			}
@@ -1187,6 +1131,9 @@ func (cs *Set) IsOKState(evalExcluded bool) bool {
 // operational status which maps to an OK state.  component Groups are not
 // included in the count since groups mirror the status of subcomponents.
 //
+// Component groups evaluated as a single unit per the GroupRollup for the
+// set are included in place of their subcomponents.
+//
 // A boolean value is accepted which indicates whether component values marked
 // for exclusion (during filtering) should also be considered. The caller is
 // responsible for filtering the collection prior to calling this method.
@@ -1213,14 +1160,11 @@ func (cs *Set) NumOKState(evalExcluded bool) int {
 	for i := range cs.Components {
 
 		switch {
-		case cs.Components[i].Group:
-			continue
-
-		case cs.Components[i].Exclude && !evalExcluded:
+		case !cs.isEvaluated(&cs.Components[i], evalExcluded):
 			continue
 
 		default:
-			serviceState := ComponentStatusToServiceState(cs.Components[i].Status)
+			serviceState, _ := cs.ComponentServiceState(&cs.Components[i])
 			if serviceState.ExitCode == nagios.StateOKExitCode {
 				numOKState++
 			}
@@ -1341,40 +1285,60 @@ func (cs *Set) Filter(filter Filter) error {
 	}
 
 	logger.Printf(
-		"filter.Group set: %t, filter.Components has length of %d",
-		filter.Group == "",
+		"filter.Groups has length of %d, filter.Components has length of %d",
+		len(filter.Groups),
 		len(filter.Components),
 	)
 
-	// If specified, build a map of component groups based off of group id.
-	// We'll use this to determine if a subcomponent is a member of one of
-	// the indexed groups.
-	matchedGroupComponents := make(map[string]*Component, len(filter.Group))
-	if filter.Group != "" {
-		err := cs.matchGroupComponents(filter, matchedGroupComponents)
+	matchedComponents := make(map[string]*Component)
+
+	// Each group is evaluated separately so that paired subcomponents are
+	// only matched against the group they were specified with.
+	for _, group := range filter.Groups {
+
+		// Build a map of component groups based off of group id. We'll use
+		// this to determine if a subcomponent is a member of one of the
+		// indexed groups. Retrieving by name can match multiple groups.
+		matchedGroupComponents := make(map[string]*Component)
+		err := cs.matchGroupComponents(filter, group.Group, matchedGroupComponents)
 		if err != nil {
 			return fmt.Errorf("cs.matchGroupComponents failed: %w", err)
 		}
+
+		switch {
+		case len(group.Components) > 0:
+			err := cs.matchComponents(filter, group.Components, matchedGroupComponents, matchedComponents)
+			if err != nil {
+				return fmt.Errorf("cs.matchComponents failed: %w", err)
+			}
+
+		default:
+			err := cs.recordSubcomponents(filter, matchedGroupComponents, matchedComponents)
+			if err != nil {
+				return fmt.Errorf(
+					"cs.recordSubcomponents failed to record subcomponents: %w",
+					err,
+				)
+			}
+		}
 	}
 
-	// Early exit logic already handles empty filter, so we have at least one
-	// component specified OR we have a component group index populated with
-	// the implied intent to evaluate all subcomponents of each group index
-	// entry.
-	matchedComponents := make(map[string]*Component)
 	switch {
 	case len(filter.Components) > 0:
-		err := cs.matchComponents(filter, matchedGroupComponents, matchedComponents)
+		err := cs.matchComponents(filter, filter.Components, nil, matchedComponents)
 		if err != nil {
 			return fmt.Errorf("cs.matchComponents failed: %w", err)
 		}
-	case filter.Group != "" && len(filter.Components) == 0:
-		err := cs.recordSubcomponents(filter, matchedGroupComponents, matchedComponents)
-		if err != nil {
-			return fmt.Errorf(
-				"cs.recordSubcomponents failed to record subcomponents: %w",
-				err,
-			)
+
+	// Only exclusions were specified; record all components so that
+	// everything other than the exclusions is evaluated.
+	case len(filter.Groups) == 0:
+		cs.recordAllComponents(matchedComponents)
+	}
+
+	if filter.HasExclusions() {
+		if err := cs.removeExcludedMatches(filter, matchedComponents); err != nil {
+			return fmt.Errorf("cs.removeExcludedMatches failed: %w", err)
 		}
 	}
 
@@ -1383,6 +1347,89 @@ func (cs *Set) Filter(filter Filter) error {
 	return nil
 }
 
+// FilterPartial applies the given Filter to a set which lists only some of
+// the components for a page (e.g., the components affected by unresolved
+// incidents). Filter values which do not match any component (or component
+// group for group values) in the set are ignored instead of resulting in an
+// error since the component may exist, but not be listed. If none of the group or component
+// values match, all components in the set are marked as excluded. Otherwise,
+// the filter values which match are applied using Filter.
+func (cs *Set) FilterPartial(filter Filter) error {
+
+	// Unlikely, but possible scenario.
+	if cs == nil {
+		return fmt.Errorf(
+			"unable to apply filter to empty components set;" +
+				" did you use a provided constructor to build set?",
+		)
+	}
+
+	// Assert valid filter was provided.
+	if err := filter.Validate(); err != nil {
+		return fmt.Errorf(
+			"unable to apply Filter to components set: %w",
+			err,
+		)
+	}
+
+	listed := Filter{MatchMode: filter.MatchMode}
+
+	listedSearchVals := func(searchVals []string, groupsOnly bool) []string {
+		var listedVals []string
+		for _, searchVal := range searchVals {
+			if !cs.isListed(filter, searchVal, groupsOnly) {
+				logger.Printf("Ignoring unlisted search value %q", searchVal)
+				continue
+			}
+			listedVals = append(listedVals, searchVal)
+		}
+
+		return listedVals
+	}
+
+	for _, group := range filter.Groups {
+		if !cs.isListed(filter, group.Group, true) {
+			logger.Printf("Ignoring unlisted component group search value %q", group.Group)
+			continue
+		}
+
+		components := listedSearchVals(group.Components, false)
+
+		// Skip a group paired with only unlisted subcomponents instead of
+		// matching all of its subcomponents.
+		if len(group.Components) > 0 && len(components) == 0 {
+			logger.Printf("Ignoring component group search value %q paired with unlisted components", group.Group)
+			continue
+		}
+
+		listed.Groups = append(listed.Groups, GroupFilter{Group: group.Group, Components: components})
+	}
+
+	listed.Components = listedSearchVals(filter.Components, false)
+	listed.ExcludeGroups = listedSearchVals(filter.ExcludeGroups, true)
+	listed.ExcludeComponents = listedSearchVals(filter.ExcludeComponents, false)
+
+	hasInclusions := len(filter.Groups) > 0 || len(filter.Components) > 0
+	hasListedInclusions := len(listed.Groups) > 0 || len(listed.Components) > 0
+
+	switch {
+	case hasInclusions && !hasListedInclusions:
+		logger.Print("No group or component search values are listed; excluding all components")
+		cs.excludeUnmatchedComponents(nil)
+
+		return nil
+
+	case !hasInclusions && !listed.HasExclusions():
+		logger.Print("No exclusion values are listed; evaluating all components")
+		cs.FilterApplied = true
+
+		return nil
+
+	default:
+		return cs.Filter(listed)
+	}
+}
+
 // NumComponents returns the count of ALL components in the set (including
 // those marked for exclusion). component Groups are also included in the
 // count.
@@ -1513,8 +1560,13 @@ func (cs *Set) NumSubcomponents() int {
 }
 
 // NumProblemComponents returns the count of components in the set which are
-// in a non-OK or non-operational status. component Groups are not included in
-// the count since groups mirror the status of subcomponents.
+// in a non-OK state as determined by the StatusMap for the set, any
+// applicable severity override and the minimum problem duration. component
+// Groups are not included in the count since groups mirror the status of
+// subcomponents.
+//
+// Component groups evaluated as a single unit per the GroupRollup for the
+// set are included in place of their subcomponents.
 //
 // A boolean value is accepted which indicates whether component values marked
 // for exclusion (during filtering) should also be considered. The caller is
@@ -1524,12 +1576,8 @@ func (cs *Set) NumProblemComponents(evalExcluded bool) int {
 	var n int
 
 	for i := range cs.Components {
-		if cs.Components[i].Group {
-			continue
-		}
-
-		if !cs.Components[i].IsOKState() &&
-			(!cs.Components[i].Exclude || (cs.Components[i].Exclude && evalExcluded)) {
+		if cs.isEvaluated(&cs.Components[i], evalExcluded) &&
+			!cs.ComponentIsOKState(&cs.Components[i]) {
 			n++
 		}
 	}
@@ -1538,10 +1586,15 @@ func (cs *Set) NumProblemComponents(evalExcluded bool) int {
 
 }
 
-// ProblemComponents returns any subcomponents in the set in a non-OK or
-// non-operational status as a collection of component values. The returned
-// collection of component values may be empty. component groups are not
-// included in the count since groups mirror the status of subcomponents.
+// ProblemComponents returns any subcomponents in the set in a non-OK state
+// (as determined by the StatusMap for the set, any applicable severity
+// override and the minimum problem duration) as a collection of component
+// values. The returned collection of component values may be empty.
+// component groups are not included in the count since groups mirror the
+// status of subcomponents.
+//
+// Component groups evaluated as a single unit per the GroupRollup for the
+// set are included in place of their subcomponents.
 //
 // A boolean value is accepted which indicates whether component values marked
 // for exclusion (during filtering) should also be considered. The caller is
@@ -1551,9 +1604,8 @@ func (cs *Set) ProblemComponents(evalExcluded bool) []*Component {
 	probComponents := make([]*Component, 0, cs.NumProblemComponents(evalExcluded))
 
 	for i := range cs.Components {
-		if !cs.Components[i].Group &&
-			!cs.Components[i].IsOKState() &&
-			(!cs.Components[i].Exclude || (cs.Components[i].Exclude && evalExcluded)) {
+		if cs.isEvaluated(&cs.Components[i], evalExcluded) &&
+			!cs.ComponentIsOKState(&cs.Components[i]) {
 			probComponents = append(probComponents, &cs.Components[i])
 		}
 	}
@@ -1813,6 +1865,40 @@ func (cs *Set) GetComponentsByName(searchKey string) ([]*Component, error) {
 	return nil, ErrComponentNotFound
 }
 
+// GetComponentsByNameMatch uses the specified search key and name match mode
+// (e.g., MatchModeGlob) to return a collection of matching components from
+// the set. ErrComponentNotFound is returned if a match was not found. An
+// error is also returned if the match mode is not supported or the search key
+// is not a valid pattern for the match mode. See GetComponentsByName for
+// notes regarding duplicate component names.
+func (cs *Set) GetComponentsByNameMatch(searchKey string, matchMode string) ([]*Component, error) {
+
+	matcher, err := newNameMatcher(searchKey, matchMode)
+	if err != nil {
+		return nil, err
+	}
+
+	var components []*Component
+	for i := range cs.Components {
+		if matcher(cs.Components[i].Name) {
+			components = append(components, &cs.Components[i])
+		}
+	}
+
+	if len(components) > 0 {
+		logger.Printf(
+			"Matched %d component(s) for search value %q using match mode %q",
+			len(components),
+			searchKey,
+			matchModeLabel(matchMode),
+		)
+
+		return components, nil
+	}
+
+	return nil, ErrComponentNotFound
+}
+
 // GetComponentByID uses the (case-insensitive) specified component ID as the
 // search key, returning the matching component from the set or an error if a
 // match was not found.
@@ -9,7 +9,8 @@ package components
 
 import (
 	"errors"
-	"fmt"
+
+	"github.com/atc0005/check-statuspage/internal/statuspage"
 )
 
 // ErrComponentSetFilterEmpty indicates that a given components set filter is
@@ -31,6 +32,19 @@ var ErrComponentSetFilterWhitespaceComponentsField = errors.New(
 	"given component set filter contains whitespace only components list value",
 )
 
+// ErrComponentSetFilterInvalidMatchMode indicates that a given components set
+// filter specifies an unsupported name match mode.
+var ErrComponentSetFilterInvalidMatchMode = errors.New(
+	"given component set filter specifies unsupported match mode",
+)
+
+// ErrComponentSetFilterInvalidPattern indicates that a given components set
+// filter contains a group or components list value which is not a valid
+// pattern for the specified name match mode.
+var ErrComponentSetFilterInvalidPattern = errors.New(
+	"given component set filter contains invalid pattern",
+)
+
 // ErrComponentSetFilterInvalid indicates that a given components set filter
 // is in an unknown or invalid state. This error condition is unlikely to
 // occur.
@@ -38,6 +52,13 @@ var ErrComponentSetFilterInvalid = errors.New(
 	"given component set filter is invalid",
 )
 
+// ErrPageNotUpdated indicates that a status page has not been updated within
+// the expected duration. A status page which stopped updating (e.g., during
+// an outage) is treated as a problem.
+var ErrPageNotUpdated = errors.New(
+	"status page not updated within expected duration",
+)
+
 // ErrComponentSetValidationFailed indicates that validating decode JSON data
 // has failed.
 var ErrComponentSetValidationFailed = errors.New(
@@ -114,64 +135,22 @@ var ErrComponentWithProblemStatusNotExcluded = errors.New(
 	"component with non-operational status not excluded from evaluation",
 )
 
+// ErrProblemComponentsThresholdExceeded indicates that the number or
+// percentage of evaluated components with a non-operational status exceeds a
+// user-specified threshold. This is a user-facing error, intended for display
+// in detailed output.
+var ErrProblemComponentsThresholdExceeded = errors.New(
+	"evaluated components with non-operational status exceed threshold",
+)
+
 // ErrResponseOutsideRange indicates that a response was received which falls
 // outside of an acceptable range.
-var ErrResponseOutsideRange = errors.New(
-	"response is outside acceptable range",
-)
+var ErrResponseOutsideRange = statuspage.ErrResponseOutsideRange
 
 // PrepError represents a class of errors encountered while performing tasks
 // related to preparing a components Set.
-type PrepError struct {
-
-	// Step indicates the specific prep task which failed.
-	//
-	// NOTE: Constants should be used to make comparisons more reliable.
-	Task string
-
-	// Message provides additional (brief) context describing why the error
-	// occurred.
-	//
-	// e.g., "error parsing URL" or "error preparing request for URL"
-	Message string
-
-	// Source associated with the prep task.
-	//
-	// e.g., "/tmp/components.json",
-	// "https://status.example.com/api/v2/components.json"
-	Source string
-
-	// Cause is the underlying error which occurred while performing a task as
-	// part of preparing a components set. This error is "bundled" for later
-	// evaluation.
-	Cause error
-}
-
-// Error provides a human readable explanation for a components Set
-// preparation task failure.
-func (s *PrepError) Error() string {
-	return fmt.Sprintf(
-		"task: %q: %s: source: %s cause: %v",
-		s.Task,
-		s.Message,
-		s.Source,
-		s.Cause,
-	)
-}
-
-// Is supports error wrapping by indicating whether a given error matches the
-// specific failed task associated with this error.
-func (s *PrepError) Is(target error) bool {
-	t, ok := target.(*PrepError)
-	if !ok {
-		return false
-	}
-
-	return t.Task == s.Task
-}
-
-// Unwrap supports error wrapping by returning the enclosed error associated
-// with the specific failed task  was encountered as part of preparing a components Set.
-func (s *PrepError) Unwrap() error {
-	return s.Cause
-}
+type PrepError = statuspage.PrepError
+
+// StaleFeedError indicates that the last good copy of a feed was used to
+// prepare a components Set because the feed could not be retrieved.
+type StaleFeedError = statuspage.StaleFeedError
@@ -0,0 +1,55 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package components
+
+import (
+	"time"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// OldestPageAge returns how long before the given time the least recently
+// updated page in the collection was last updated. Pages which do not
+// indicate when they were last updated are ignored.
+func (css Sets) OldestPageAge(now time.Time) time.Duration {
+	var oldest time.Duration
+	for _, cs := range css {
+		if age := cs.Page.Age(now); age > oldest {
+			oldest = age
+		}
+	}
+
+	return oldest
+}
+
+// FreshnessServiceState returns the Nagios ServiceState for a page (or
+// collection of pages) last updated the given duration ago. CRITICAL is
+// returned if the age exceeds the critical duration and WARNING if the age
+// exceeds the warning duration. A zero warning or critical duration disables
+// the applicable check.
+func FreshnessServiceState(age time.Duration, warning time.Duration, critical time.Duration) nagios.ServiceState {
+	switch {
+	case critical > 0 && age > critical:
+		return nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		}
+
+	case warning > 0 && age > warning:
+		return nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		}
+
+	default:
+		return nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package components
+
+import (
+	"time"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// ApplyMinProblemDuration records the components in the set with a status
+// which does not map to an OK state and which has persisted for less than
+// the given duration as of the given time. The duration of a status is
+// measured from the time the component was last updated. Until the status
+// has persisted for the given duration, the component is evaluated as if it
+// had an operational status. Components which do not indicate when they were
+// last updated are not affected. Previously recorded components are cleared;
+// a zero duration disables the grace period.
+func (cs *Set) ApplyMinProblemDuration(minDuration time.Duration, now time.Time) {
+
+	cs.gracePeriod = nil
+	cs.minProblemDuration = minDuration
+
+	if minDuration <= 0 {
+		return
+	}
+
+	for i := range cs.Components {
+		component := &cs.Components[i]
+
+		if component.UpdatedAt.IsZero() {
+			continue
+		}
+
+		// A status which maps to an OK state is not a problem and does not
+		// need a grace period.
+		serviceState, _ := cs.statusServiceState(component, component.Status)
+		if serviceState.ExitCode == nagios.StateOKExitCode {
+			continue
+		}
+
+		if now.Sub(component.UpdatedAt) >= minDuration {
+			continue
+		}
+
+		if cs.gracePeriod == nil {
+			cs.gracePeriod = make(map[string]struct{})
+		}
+
+		logger.Printf(
+			"Component status not persisted for minimum problem duration %v: %s",
+			minDuration,
+			component,
+		)
+		cs.gracePeriod[component.ID] = struct{}{}
+	}
+}
+
+// ApplyMinProblemDuration records the components with a non-operational
+// status which has persisted for less than the given duration as of the
+// given time for each Set in the collection.
+func (css Sets) ApplyMinProblemDuration(minDuration time.Duration, now time.Time) {
+	for _, cs := range css {
+		cs.ApplyMinProblemDuration(minDuration, now)
+	}
+}
+
+// inGracePeriod indicates whether the given component has a non-operational
+// status which has not yet persisted for the minimum problem duration.
+func (cs *Set) inGracePeriod(c *Component) bool {
+	_, ok := cs.gracePeriod[c.ID]
+
+	return ok
+}
+
+// MinProblemDuration returns the minimum duration a non-operational status
+// must persist before a component is evaluated as a problem. Zero is
+// returned if a grace period was not applied.
+func (cs *Set) MinProblemDuration() time.Duration {
+	return cs.minProblemDuration
+}
+
+// GracePeriodComponents returns the components from the set with a
+// non-operational status which has not yet persisted for the minimum problem
+// duration.
+//
+// A boolean value is accepted which indicates whether component values marked
+// for exclusion (during filtering) should also be considered.
+func (cs *Set) GracePeriodComponents(evalExcluded bool) []*Component {
+
+	var components []*Component
+
+	for i := range cs.Components {
+		component := &cs.Components[i]
+
+		switch {
+		case !cs.inGracePeriod(component):
+			continue
+
+		// The status of a component group is only evaluated when the
+		// group is evaluated as a single unit using its own status.
+		case component.Group:
+			if cs.GroupRollup.Mode != GroupRollupParent || !cs.isEvaluated(component, evalExcluded) {
+				continue
+			}
+
+		case component.Exclude && !evalExcluded:
+			continue
+		}
+
+		components = append(components, component)
+	}
+
+	return components
+}
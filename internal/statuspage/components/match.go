@@ -29,13 +29,13 @@ func componentIsValidSubcomponent(
 	return false
 }
 
-// matchGroupComponents evaluates components in the set using a given Filter.
-// If a component group match is made, it is recorded in the given index of
-// component ID to Component.
-func (cs *Set) matchGroupComponents(filter Filter, matches map[string]*Component) error {
+// matchGroupComponents evaluates components in the set using the given
+// component group search value from a Filter. If a component group match is
+// made, it is recorded in the given index of component ID to Component.
+func (cs *Set) matchGroupComponents(filter Filter, groupSearchVal string, matches map[string]*Component) error {
 
 	// Attempt to retrieve by name first.
-	components, err := cs.GetComponentsByName(filter.Group)
+	components, err := cs.GetComponentsByNameMatch(groupSearchVal, filter.MatchMode)
 	switch {
 	case err == nil:
 
@@ -82,7 +82,7 @@ func (cs *Set) matchGroupComponents(filter Filter, matches map[string]*Component
 
 	default:
 		// Fallback to trying to retrieve by ID.
-		component, err := cs.GetComponentByID(filter.Group)
+		component, err := cs.GetComponentByID(groupSearchVal)
 		if err != nil {
 			return fmt.Errorf(
 				"failed to apply filter '%s' to components set: %w",
@@ -105,17 +105,20 @@ func (cs *Set) matchGroupComponents(filter Filter, matches map[string]*Component
 	return nil
 }
 
-// matchComponents evaluates and then matches components in the set using a
-// given Filter.
+// matchComponents evaluates and then matches components in the set using the
+// given component search values from a Filter. If the given index of
+// component groups is not empty, matched components are required to be
+// subcomponents of an indexed group.
 func (cs *Set) matchComponents(
 	filter Filter,
+	compSearchVals []string,
 	groupIndex map[string]*Component,
 	componentMatches map[string]*Component,
 ) error {
 
-	logger.Printf("Evaluating %d filter.Components", len(filter.Components))
+	logger.Printf("Evaluating %d component search values", len(compSearchVals))
 
-	for _, compSearchVal := range filter.Components {
+	for _, compSearchVal := range compSearchVals {
 
 		logger.Printf("Retrieving component(s) for component search value: %q", compSearchVal)
 		components, err := cs.retrieveComponentByNameOrID(filter, compSearchVal)
@@ -172,7 +175,7 @@ func (cs *Set) matchComponents(
 
 		logger.Printf("Retrieving subcomponents for %d groups", len(groupIndex))
 		// NOTE: You can have multiple groups if retrieving by name.
-		err = matchComponents(filter, groupIndex, componentMatches, components...)
+		err = matchComponents(groupIndex, componentMatches, components...)
 		if err != nil {
 			return err
 		}
@@ -189,7 +192,6 @@ func (cs *Set) matchComponents(
 // matchComponents is a helper function used to perform the bulk of the
 // evaluation and matching logic for the Set.matchComponents() method.
 func matchComponents(
-	filter Filter,
 	groupIndex map[string]*Component,
 	componentsIndex map[string]*Component,
 	components ...*Component,
@@ -199,7 +201,7 @@ func matchComponents(
 
 	// If a group was specified, assert that each component is a valid
 	// subcomponent.
-	case filter.Group != "":
+	case len(groupIndex) > 0:
 
 		logger.Print("Component group was specified")
 
@@ -303,10 +305,122 @@ func (cs *Set) recordSubcomponents(
 	return nil
 }
 
+// recordAllComponents records all components in the set in the given
+// components index.
+func (cs *Set) recordAllComponents(componentsIndex map[string]*Component) {
+	logger.Print("No components or group explicitly listed, recording all components")
+
+	for i := range cs.Components {
+		componentsIndex[cs.Components[i].ID] = &cs.Components[i]
+	}
+}
+
+// removeExcludedMatches removes components matching the exclusion values of
+// the given Filter from the given components index. All subcomponents of an
+// excluded component group are also removed. An error is returned if an
+// exclusion value does not match a component group or component.
+func (cs *Set) removeExcludedMatches(filter Filter, componentsIndex map[string]*Component) error {
+
+	for _, groupSearchVal := range filter.ExcludeGroups {
+
+		logger.Printf("Retrieving component group(s) for exclusion value: %q", groupSearchVal)
+		groups, err := cs.retrieveGroupsByNameOrID(filter, groupSearchVal)
+		if err != nil {
+			return err
+		}
+
+		for _, group := range groups {
+			componentGroup, err := cs.GetGroupByID(group.ID)
+			if err != nil {
+				return fmt.Errorf(
+					"failed to apply filter '%s' to components set: %w",
+					filter,
+					ErrComponentGroupNotFound,
+				)
+			}
+
+			logger.Printf("Removing component group and subcomponents from matches: %s", componentGroup)
+			delete(componentsIndex, group.ID)
+			for _, component := range componentGroup.Subcomponents {
+				delete(componentsIndex, component.ID)
+			}
+		}
+	}
+
+	for _, compSearchVal := range filter.ExcludeComponents {
+
+		logger.Printf("Retrieving component(s) for exclusion value: %q", compSearchVal)
+		components, err := cs.retrieveComponentByNameOrID(filter, compSearchVal)
+		if err != nil {
+			return err
+		}
+
+		var numRemoved int
+		for _, component := range components {
+			if component.Group {
+				logger.Printf("Ignoring group component: %s", component)
+				continue
+			}
+
+			logger.Printf("Removing component from matches: %s", component)
+			delete(componentsIndex, component.ID)
+			numRemoved++
+		}
+
+		if numRemoved == 0 {
+			return fmt.Errorf(
+				"all retrieved components for given exclusion value %q are component groups",
+				compSearchVal,
+			)
+		}
+	}
+
+	return nil
+}
+
+// retrieveGroupsByNameOrID retrieves the component groups matching the given
+// search key, first by name and then by ID.
+func (cs *Set) retrieveGroupsByNameOrID(filter Filter, searchKey string) ([]*Component, error) {
+
+	components, err := cs.GetComponentsByNameMatch(searchKey, filter.MatchMode)
+	if err != nil {
+		logger.Printf("Fall back to retrieving component group by ID: %q", searchKey)
+		component, err := cs.GetComponentByID(searchKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to apply filter '%s' to components set: %w",
+				filter,
+				ErrComponentGroupNotFound,
+			)
+		}
+
+		components = []*Component{component}
+	}
+
+	groups := make([]*Component, 0, len(components))
+	for _, component := range components {
+		if component.Group {
+			groups = append(groups, component)
+		}
+	}
+
+	if len(groups) == 0 {
+		return nil, fmt.Errorf(
+			"failed to apply filter '%s' to components set; "+
+				"no matching component groups found for exclusion value %q: %w",
+			filter,
+			searchKey,
+			ErrComponentIsNotComponentGroup,
+		)
+	}
+
+	return groups, nil
+}
+
 func (cs *Set) retrieveComponentByNameOrID(filter Filter, searchKey string) ([]*Component, error) {
 
 	logger.Printf("Attempt to get components by specified name first: %q", searchKey)
-	components, err := cs.GetComponentsByName(searchKey)
+	components, err := cs.GetComponentsByNameMatch(searchKey, filter.MatchMode)
 	if err != nil {
 		logger.Printf("Error occurred searching for component by name: %q", searchKey)
 		logger.Printf("Fall back to retrieving component by ID: %q", searchKey)
@@ -328,3 +442,26 @@ func (cs *Set) retrieveComponentByNameOrID(filter Filter, searchKey string) ([]*
 	return components, nil
 
 }
+
+// isListed indicates whether the given search value from a Filter matches
+// the name or ID of any component in the set. If specified, only component
+// groups are considered.
+func (cs *Set) isListed(filter Filter, searchVal string, groupsOnly bool) bool {
+	components, err := cs.GetComponentsByNameMatch(searchVal, filter.MatchMode)
+	if err != nil {
+		component, err := cs.GetComponentByID(searchVal)
+		if err != nil {
+			return false
+		}
+
+		components = []*Component{component}
+	}
+
+	for _, component := range components {
+		if component.Group || !groupsOnly {
+			return true
+		}
+	}
+
+	return false
+}
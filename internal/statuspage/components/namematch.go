@@ -0,0 +1,142 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package components
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Supported modes used to match component and component group names
+// specified by a Filter. Component and component group ID values are always
+// matched case-insensitively.
+const (
+	// MatchModeExact requires that a component name exactly matches the
+	// search value (leading and trailing whitespace are ignored). This is the
+	// default.
+	MatchModeExact string = "exact"
+
+	// MatchModeCaseInsensitive requires that a component name matches the
+	// search value, ignoring differences in case.
+	MatchModeCaseInsensitive string = "case-insensitive"
+
+	// MatchModeGlob treats the search value as a shell-style pattern which
+	// must match the entire component name. The `*` wildcard matches any
+	// sequence of characters and `?` matches a single character. All other
+	// characters are matched literally.
+	MatchModeGlob string = "glob"
+
+	// MatchModeRegex treats the search value as a regular expression (RE2
+	// syntax) which must match some part of the component name. Use anchors
+	// (`^`, `$`) to match the entire name or the `(?i)` flag to ignore case.
+	MatchModeRegex string = "regex"
+)
+
+// SupportedMatchModes returns the list of supported name match modes.
+func SupportedMatchModes() []string {
+	return []string{
+		MatchModeExact,
+		MatchModeCaseInsensitive,
+		MatchModeGlob,
+		MatchModeRegex,
+	}
+}
+
+// nameMatcher is used to evaluate component names against a search value.
+type nameMatcher func(name string) bool
+
+// newNameMatcher returns a nameMatcher for the given search value using the
+// specified match mode. An empty match mode is treated as MatchModeExact. An
+// error is returned if the match mode is not supported or if the search
+// value is not a valid pattern for the match mode.
+func newNameMatcher(searchKey string, matchMode string) (nameMatcher, error) {
+
+	searchKey = strings.TrimSpace(searchKey)
+
+	switch strings.ToLower(matchMode) {
+	case "", MatchModeExact:
+		return func(name string) bool {
+			return strings.TrimSpace(name) == searchKey
+		}, nil
+
+	case MatchModeCaseInsensitive:
+		return func(name string) bool {
+			return strings.EqualFold(strings.TrimSpace(name), searchKey)
+		}, nil
+
+	case MatchModeGlob:
+		re, err := regexp.Compile(globToRegex(searchKey))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: glob pattern %q: %v",
+				ErrComponentSetFilterInvalidPattern,
+				searchKey,
+				err,
+			)
+		}
+
+		return func(name string) bool {
+			return re.MatchString(strings.TrimSpace(name))
+		}, nil
+
+	case MatchModeRegex:
+		re, err := regexp.Compile(searchKey)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: regular expression %q: %v",
+				ErrComponentSetFilterInvalidPattern,
+				searchKey,
+				err,
+			)
+		}
+
+		return func(name string) bool {
+			return re.MatchString(strings.TrimSpace(name))
+		}, nil
+
+	default:
+		return nil, fmt.Errorf(
+			"%w: %q; supported modes: %s",
+			ErrComponentSetFilterInvalidMatchMode,
+			matchMode,
+			strings.Join(SupportedMatchModes(), ", "),
+		)
+	}
+}
+
+// globToRegex converts the given shell-style pattern to an anchored regular
+// expression.
+func globToRegex(pattern string) string {
+	var expr strings.Builder
+
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+
+	return expr.String()
+}
+
+// matchModeLabel returns the given match mode or the default match mode if
+// not specified.
+func matchModeLabel(matchMode string) string {
+	if matchMode == "" {
+		return MatchModeExact
+	}
+
+	return strings.ToLower(matchMode)
+}
@@ -0,0 +1,263 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package components
+
+import (
+	"github.com/atc0005/go-nagios"
+)
+
+// Supported modes used to evaluate a component group as a single unit in
+// place of evaluating each of its subcomponents separately.
+const (
+	// GroupRollupNone evaluates each subcomponent separately. This is the
+	// default.
+	GroupRollupNone string = "none"
+
+	// GroupRollupParent evaluates a component group using the status of the
+	// component group itself.
+	GroupRollupParent string = "parent"
+
+	// GroupRollupWorstOf evaluates a component group using the most severe
+	// state of its evaluated subcomponents.
+	GroupRollupWorstOf string = "worst-of"
+
+	// GroupRollupQuorum evaluates a component group using the most severe
+	// state shared (at that state or a more severe one) by at least the
+	// quorum percentage of its evaluated subcomponents.
+	GroupRollupQuorum string = "quorum"
+)
+
+// DefaultGroupQuorum is the default percentage of evaluated subcomponents
+// required to share a state for the GroupRollupQuorum mode.
+const DefaultGroupQuorum int = 50
+
+// SupportedGroupRollupModes returns the list of supported group rollup
+// modes.
+func SupportedGroupRollupModes() []string {
+	return []string{
+		GroupRollupNone,
+		GroupRollupParent,
+		GroupRollupWorstOf,
+		GroupRollupQuorum,
+	}
+}
+
+// GroupRollup controls whether component groups with evaluated subcomponents
+// are evaluated as a single unit. When enabled, a component group counts as
+// one component and its subcomponents are not evaluated separately. A zero
+// value GroupRollup is the same as the GroupRollupNone mode.
+type GroupRollup struct {
+
+	// Mode is one of the supported group rollup modes.
+	Mode string
+
+	// Quorum is the percentage (1-100) of evaluated subcomponents required
+	// to share a state for the GroupRollupQuorum mode. DefaultGroupQuorum is
+	// used if not set.
+	Quorum int
+}
+
+// Enabled indicates whether component groups are evaluated as a single unit.
+func (gr GroupRollup) Enabled() bool {
+	return gr.Mode != "" && gr.Mode != GroupRollupNone
+}
+
+// quorum returns the quorum percentage, falling back to DefaultGroupQuorum if
+// not set.
+func (gr GroupRollup) quorum() int {
+	if gr.Quorum <= 0 {
+		return DefaultGroupQuorum
+	}
+
+	return gr.Quorum
+}
+
+// groupSubcomponents returns the subcomponents of the given component group.
+func (cs *Set) groupSubcomponents(group *Component) []*Component {
+	var subcomponents []*Component
+
+	for i := range cs.Components {
+		if !cs.Components[i].Group && string(cs.Components[i].GroupID) == group.ID {
+			subcomponents = append(subcomponents, &cs.Components[i])
+		}
+	}
+
+	return subcomponents
+}
+
+// hasGroup indicates whether the set has a component group with the given ID.
+func (cs *Set) hasGroup(groupID string) bool {
+	for i := range cs.Components {
+		if cs.Components[i].Group && cs.Components[i].ID == groupID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rollupSubcomponents returns the subcomponents of the given component group
+// used to evaluate the group as a single unit: those not marked for
+// exclusion or all subcomponents if every subcomponent is marked for
+// exclusion.
+func (cs *Set) rollupSubcomponents(group *Component) []*Component {
+	subcomponents := cs.groupSubcomponents(group)
+
+	evaluated := make([]*Component, 0, len(subcomponents))
+	for _, subcomponent := range subcomponents {
+		if !subcomponent.Exclude {
+			evaluated = append(evaluated, subcomponent)
+		}
+	}
+
+	if len(evaluated) == 0 {
+		return subcomponents
+	}
+
+	return evaluated
+}
+
+// isEvaluated indicates whether the given component is evaluated as a
+// single unit. Component groups are not evaluated since groups mirror the
+// status of subcomponents unless the GroupRollup for the set is enabled; a
+// component group with at least one evaluated subcomponent is then evaluated
+// in place of its subcomponents.
+//
+// A boolean value is accepted which indicates whether component values marked
+// for exclusion (during filtering) should also be considered.
+func (cs *Set) isEvaluated(c *Component, evalExcluded bool) bool {
+	switch {
+	case !cs.GroupRollup.Enabled():
+		return !c.Group && (!c.Exclude || evalExcluded)
+
+	case c.Group:
+		for _, subcomponent := range cs.groupSubcomponents(c) {
+			if !subcomponent.Exclude || evalExcluded {
+				return true
+			}
+		}
+
+		return false
+
+	case c.GroupID != "" && cs.hasGroup(string(c.GroupID)):
+		return false
+
+	default:
+		return !c.Exclude || evalExcluded
+	}
+}
+
+// ComponentIsOKState indicates whether the given component is in an OK state
+// as determined by the StatusMap for the set, any applicable severity
+// override and the minimum problem duration. If the GroupRollup for the set
+// is enabled, the state of a component group is determined by the GroupRollup
+// mode.
+func (cs *Set) ComponentIsOKState(c *Component) bool {
+	serviceState, _ := cs.ComponentServiceState(c)
+
+	return serviceState.ExitCode == nagios.StateOKExitCode
+}
+
+// groupRollupServiceState returns the Nagios ServiceState for the given
+// component group evaluated as a single unit using the worst-of or quorum
+// GroupRollup mode for the set.
+func (cs *Set) groupRollupServiceState(group *Component) nagios.ServiceState {
+	subcomponents := cs.rollupSubcomponents(group)
+
+	states := make([]nagios.ServiceState, 0, len(subcomponents))
+	for _, subcomponent := range subcomponents {
+		serviceState, _ := cs.ComponentServiceState(subcomponent)
+		states = append(states, serviceState)
+	}
+
+	okState := nagios.ServiceState{
+		Label:    nagios.StateOKLabel,
+		ExitCode: nagios.StateOKExitCode,
+	}
+
+	switch cs.GroupRollup.Mode {
+	case GroupRollupQuorum:
+		levels := []nagios.ServiceState{
+			{Label: nagios.StateCRITICALLabel, ExitCode: nagios.StateCRITICALExitCode},
+			{Label: nagios.StateWARNINGLabel, ExitCode: nagios.StateWARNINGExitCode},
+			{Label: nagios.StateUNKNOWNLabel, ExitCode: nagios.StateUNKNOWNExitCode},
+		}
+
+		for _, level := range levels {
+			var n int
+			for _, state := range states {
+				if stateSeverity(state.ExitCode) >= stateSeverity(level.ExitCode) {
+					n++
+				}
+			}
+
+			if quorumReached(n, len(states), cs.GroupRollup.quorum()) {
+				return level
+			}
+		}
+
+		return okState
+
+	default:
+		worst := okState
+		for _, state := range states {
+			if stateSeverity(state.ExitCode) > stateSeverity(worst.ExitCode) {
+				worst = state
+			}
+		}
+
+		return worst
+	}
+}
+
+// GroupRollupSummary returns the number of evaluated subcomponents of the
+// given component group along with the number of those subcomponents in a
+// non-OK state.
+func (cs *Set) GroupRollupSummary(group *Component) (int, int) {
+	subcomponents := cs.rollupSubcomponents(group)
+
+	var numProblem int
+	for _, subcomponent := range subcomponents {
+		if !cs.ComponentIsOKState(subcomponent) {
+			numProblem++
+		}
+	}
+
+	return len(subcomponents), numProblem
+}
+
+// RollupGroups returns the component groups in the set evaluated as a single
+// unit. Nil is returned if the GroupRollup for the set is not enabled.
+//
+// A boolean value is accepted which indicates whether component values marked
+// for exclusion (during filtering) should also be considered.
+func (cs *Set) RollupGroups(evalExcluded bool) []*Component {
+	if !cs.GroupRollup.Enabled() {
+		return nil
+	}
+
+	var groups []*Component
+
+	for i := range cs.Components {
+		if cs.Components[i].Group && cs.isEvaluated(&cs.Components[i], evalExcluded) {
+			groups = append(groups, &cs.Components[i])
+		}
+	}
+
+	return groups
+}
+
+// quorumReached indicates whether the given count is at least the given
+// percentage of the total. False is returned if the total is zero.
+func quorumReached(count int, total int, percent int) bool {
+	if total == 0 {
+		return false
+	}
+
+	return count*100 >= percent*total
+}
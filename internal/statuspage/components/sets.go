@@ -0,0 +1,191 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package components
+
+import (
+	"github.com/atc0005/go-nagios"
+)
+
+// Sets is a collection of component Sets, one for each evaluated Statuspage
+// API/JSON feed. The methods for this type aggregate the results of the
+// equivalent Set methods across all Sets in the collection.
+type Sets []*Set
+
+// HasCriticalState indicates whether components in any Set in the collection
+// have a non-operational status which maps to a CRITICAL state.
+func (css Sets) HasCriticalState(evalExcluded bool) bool {
+	for _, cs := range css {
+		if cs.HasCriticalState(evalExcluded) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasWarningState indicates whether components in any Set in the collection
+// have a non-operational status which maps to a WARNING state.
+func (css Sets) HasWarningState(evalExcluded bool) bool {
+	for _, cs := range css {
+		if cs.HasWarningState(evalExcluded) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasUnknownState indicates whether components in any Set in the collection
+// have a non-operational status which maps to an UNKNOWN state.
+func (css Sets) HasUnknownState(evalExcluded bool) bool {
+	for _, cs := range css {
+		if cs.HasUnknownState(evalExcluded) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsOKState indicates whether all Sets in the collection are in an OK or
+// operational state.
+func (css Sets) IsOKState(evalExcluded bool) bool {
+	for _, cs := range css {
+		if !cs.IsOKState(evalExcluded) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ServiceState returns the most severe Nagios ServiceState for the Sets in
+// the collection ("worst-of"). Severity follows the same order used by the
+// Set.ServiceState method: CRITICAL, WARNING, UNKNOWN and then OK.
+func (css Sets) ServiceState(evalExcluded bool) nagios.ServiceState {
+	switch {
+	case css.HasCriticalState(evalExcluded):
+		return nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		}
+
+	case css.HasWarningState(evalExcluded):
+		return nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		}
+
+	case css.HasUnknownState(evalExcluded):
+		return nagios.ServiceState{
+			Label:    nagios.StateUNKNOWNLabel,
+			ExitCode: nagios.StateUNKNOWNExitCode,
+		}
+
+	case css.IsOKState(evalExcluded):
+		return nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		}
+
+	default:
+		logger.Printf("default case triggered; unable to determine ServiceState, assuming UNKNOWN")
+		return nagios.ServiceState{
+			Label:    nagios.StateUNKNOWNLabel,
+			ExitCode: nagios.StateUNKNOWNExitCode,
+		}
+	}
+}
+
+// NumPages returns the number of Sets (one per page) in the collection.
+func (css Sets) NumPages() int {
+	return len(css)
+}
+
+// NumProblemPages returns the number of Sets in the collection which are not
+// in an OK or operational state.
+func (css Sets) NumProblemPages(evalExcluded bool) int {
+	var n int
+	for _, cs := range css {
+		if !cs.IsOKState(evalExcluded) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// NumComponents returns the total number of components across all Sets in
+// the collection.
+func (css Sets) NumComponents() int {
+	return css.sum(func(cs *Set) int { return cs.NumComponents() })
+}
+
+// NumGroups returns the total number of component groups across all Sets in
+// the collection.
+func (css Sets) NumGroups() int {
+	return css.sum(func(cs *Set) int { return cs.NumGroups() })
+}
+
+// NumExcluded returns the total number of excluded components across all
+// Sets in the collection.
+func (css Sets) NumExcluded() int {
+	return css.sum(func(cs *Set) int { return cs.NumExcluded() })
+}
+
+// NumProblemComponents returns the total number of components in a non-OK or
+// non-operational status across all Sets in the collection.
+func (css Sets) NumProblemComponents(evalExcluded bool) int {
+	return css.sum(func(cs *Set) int { return cs.NumProblemComponents(evalExcluded) })
+}
+
+// NumCriticalState returns the total number of components in a CRITICAL
+// state across all Sets in the collection.
+func (css Sets) NumCriticalState(evalExcluded bool) int {
+	return css.sum(func(cs *Set) int { return cs.NumCriticalState(evalExcluded) })
+}
+
+// NumWarningState returns the total number of components in a WARNING state
+// across all Sets in the collection.
+func (css Sets) NumWarningState(evalExcluded bool) int {
+	return css.sum(func(cs *Set) int { return cs.NumWarningState(evalExcluded) })
+}
+
+// NumUnknownState returns the total number of components in an UNKNOWN state
+// across all Sets in the collection.
+func (css Sets) NumUnknownState(evalExcluded bool) int {
+	return css.sum(func(cs *Set) int { return cs.NumUnknownState(evalExcluded) })
+}
+
+// NumOKState returns the total number of components in an OK state across
+// all Sets in the collection.
+func (css Sets) NumOKState(evalExcluded bool) int {
+	return css.sum(func(cs *Set) int { return cs.NumOKState(evalExcluded) })
+}
+
+// ProblemComponents returns the components in a non-OK or non-operational
+// status across all Sets in the collection.
+func (css Sets) ProblemComponents(evalExcluded bool) []*Component {
+	probComponents := make([]*Component, 0, css.NumProblemComponents(evalExcluded))
+	for _, cs := range css {
+		probComponents = append(probComponents, cs.ProblemComponents(evalExcluded)...)
+	}
+
+	return probComponents
+}
+
+// sum applies the given function to each Set in the collection and returns
+// the total.
+func (css Sets) sum(fn func(cs *Set) int) int {
+	var n int
+	for _, cs := range css {
+		n += fn(cs)
+	}
+
+	return n
+}
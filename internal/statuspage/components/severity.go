@@ -0,0 +1,230 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package components
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// SeverityOverrideMaxStateKey is the key used in the text form of a
+// SeverityOverride to specify the most severe state for a component.
+const SeverityOverrideMaxStateKey string = "max"
+
+// SeverityOverride is a user-specified collection of severity rules for a
+// component or component group. Rules for a component group apply to all of
+// its subcomponents. Rules for a component take precedence over rules for its
+// component group which take precedence over the StatusMap for the Set.
+type SeverityOverride struct {
+
+	// Target is the name or ID value of a component or component group.
+	Target string
+
+	// Statuses maps component statuses to the ServiceState used in place of
+	// the StatusMap for the Set.
+	Statuses map[string]nagios.ServiceState
+
+	// MaxState is the most severe ServiceState for the component; a more
+	// severe state is lowered to this state. Not applied if the Label is
+	// empty.
+	MaxState nagios.ServiceState
+}
+
+// String implements the Stringer interface. The text form of a
+// SeverityOverride is the same as that accepted by the component-severity
+// flag (e.g., "Git Operations:partial_outage=CRITICAL,max=WARNING").
+func (so SeverityOverride) String() string {
+	rules := make([]string, 0, len(so.Statuses)+1)
+	for status, serviceState := range so.Statuses {
+		rules = append(rules, status+"="+serviceState.Label)
+	}
+	sort.Strings(rules)
+
+	if so.MaxState.Label != "" {
+		rules = append(rules, SeverityOverrideMaxStateKey+"="+so.MaxState.Label)
+	}
+
+	return so.Target + ":" + strings.Join(rules, ",")
+}
+
+// stateSeverity returns a ranking for the given Nagios state exit code used
+// to compare states. The order follows that used by the Set.ServiceState
+// method: CRITICAL, WARNING, UNKNOWN and then OK.
+func stateSeverity(exitCode int) int {
+	switch exitCode {
+	case nagios.StateCRITICALExitCode:
+		return 3
+	case nagios.StateWARNINGExitCode:
+		return 2
+	case nagios.StateUNKNOWNExitCode:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ComponentServiceState returns the Nagios ServiceState for the given
+// component along with the text form of the severity override which
+// determined the state. An empty string is returned if no severity override
+// applies to the component; the state is determined by the StatusMap for the
+// Set. A non-operational status which has not yet persisted for the minimum
+// problem duration is evaluated as an operational status.
+func (cs *Set) ComponentServiceState(c *Component) (nagios.ServiceState, string) {
+
+	// A component group evaluated as a single unit using the state of its
+	// subcomponents reflects any severity overrides applied to them.
+	if c.Group && cs.GroupRollup.Enabled() && cs.GroupRollup.Mode != GroupRollupParent {
+		return cs.groupRollupServiceState(c), ""
+	}
+
+	// A non-operational status which has not yet persisted for the minimum
+	// problem duration is evaluated as operational.
+	status := c.Status
+	if cs.inGracePeriod(c) {
+		status = ComponentStatusOperational
+	}
+
+	return cs.statusServiceState(c, status)
+}
+
+// statusServiceState returns the Nagios ServiceState for the given component
+// status along with the text form of the severity override which determined
+// the state. The StatusMap for the Set and any severity override applying to
+// the given component are used to determine the state.
+func (cs *Set) statusServiceState(c *Component, status string) (nagios.ServiceState, string) {
+
+	serviceState := cs.StatusMap.ServiceState(status)
+
+	override, ok := cs.severityOverrides[c.ID]
+	if !ok && c.GroupID != "" {
+		override, ok = cs.severityOverrides[string(c.GroupID)]
+	}
+
+	if !ok {
+		return serviceState, ""
+	}
+
+	var rules []string
+
+	if state, ok := override.Statuses[status]; ok {
+		serviceState = state
+		rules = append(rules, status+"="+state.Label)
+	}
+
+	if override.MaxState.Label != "" &&
+		stateSeverity(serviceState.ExitCode) > stateSeverity(override.MaxState.ExitCode) {
+		serviceState = override.MaxState
+		rules = append(rules, SeverityOverrideMaxStateKey+"="+override.MaxState.Label)
+	}
+
+	if len(rules) == 0 {
+		return serviceState, ""
+	}
+
+	return serviceState, override.Target + ":" + strings.Join(rules, ",")
+}
+
+// SeverityOverrideComponents returns the components from the set with a
+// state determined by a severity override. Component groups are not
+// included since groups mirror the status of subcomponents unless evaluated
+// as a single unit per the GroupRollup for the set.
+//
+// A boolean value is accepted which indicates whether component values marked
+// for exclusion (during filtering) should also be considered.
+func (cs *Set) SeverityOverrideComponents(evalExcluded bool) []*Component {
+
+	var overridden []*Component
+
+	for i := range cs.Components {
+		if !cs.isEvaluated(&cs.Components[i], evalExcluded) {
+			continue
+		}
+
+		if _, rule := cs.ComponentServiceState(&cs.Components[i]); rule != "" {
+			overridden = append(overridden, &cs.Components[i])
+		}
+	}
+
+	return overridden
+}
+
+// applySeverityOverride records the given severity override for each
+// component or component group in the set matching the override target by
+// name (using the specified match mode) or by ID. Whether a match was found is
+// returned.
+func (cs *Set) applySeverityOverride(override SeverityOverride, matchMode string) (bool, error) {
+
+	matches, err := cs.GetComponentsByNameMatch(override.Target, matchMode)
+	switch {
+	case errors.Is(err, ErrComponentNotFound):
+		component, idErr := cs.GetComponentByID(override.Target)
+		if idErr != nil {
+			return false, nil
+		}
+		matches = []*Component{component}
+
+	case err != nil:
+		return false, err
+	}
+
+	if cs.severityOverrides == nil {
+		cs.severityOverrides = make(map[string]SeverityOverride)
+	}
+
+	for _, component := range matches {
+		logger.Printf(
+			"Applying severity override %q to component %s",
+			override,
+			component,
+		)
+		cs.severityOverrides[component.ID] = override
+	}
+
+	return true, nil
+}
+
+// ApplySeverityOverrides records the given severity overrides for matching
+// components and component groups in each Set of the collection. Override
+// targets are matched by name (using the specified match mode) or by ID. If
+// multiple overrides match the same component, the last one applies. An error
+// wrapping ErrComponentNotFound is returned if an override target does not
+// match a component or component group in any Set.
+func (css Sets) ApplySeverityOverrides(overrides []SeverityOverride, matchMode string) error {
+
+	for _, override := range overrides {
+		var found bool
+
+		for _, cs := range css {
+			matched, err := cs.applySeverityOverride(override, matchMode)
+			if err != nil {
+				return fmt.Errorf(
+					"failed to apply severity override %q: %w",
+					override,
+					err,
+				)
+			}
+
+			found = found || matched
+		}
+
+		if !found {
+			return fmt.Errorf(
+				"failed to apply severity override %q; target %q not found: %w",
+				override,
+				override.Target,
+				ErrComponentNotFound,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package components
+
+import (
+	"github.com/atc0005/go-nagios"
+)
+
+// StatusMap is a user-specified mapping of component status values (e.g.,
+// "under_maintenance", "partial_outage") to Nagios ServiceState values. Only
+// statuses which differ from the default mapping provided by
+// ComponentStatusToServiceState need to be present. A nil StatusMap applies
+// the default mapping.
+type StatusMap map[string]nagios.ServiceState
+
+// knownComponentStatuses returns the official component status values in the
+// order used when listing the statuses which map to a ServiceState.
+func knownComponentStatuses() []string {
+	return []string{
+		ComponentStatusMajorOutage,
+		ComponentStatusUnderMaintenance,
+		ComponentStatusPartialOutage,
+		ComponentStatusDegradedPerformance,
+		ComponentStatusOperational,
+	}
+}
+
+// ServiceState converts a Statuspage component status to a Nagios
+// ServiceState using the mapping for the status if present, otherwise the
+// default mapping provided by ComponentStatusToServiceState.
+func (sm StatusMap) ServiceState(componentStatus string) nagios.ServiceState {
+	if serviceState, ok := sm[componentStatus]; ok {
+		return serviceState
+	}
+
+	return ComponentStatusToServiceState(componentStatus)
+}
+
+// ComponentStatuses returns the collection of component statuses which map
+// to the given Nagios ServiceState. Like ServiceStateToComponentStatuses, the
+// ComponentStatusUnknown value is included for an UNKNOWN ServiceState.
+func (sm StatusMap) ComponentStatuses(serviceState nagios.ServiceState) []string {
+	var statuses []string
+
+	for _, status := range knownComponentStatuses() {
+		if sm.ServiceState(status).ExitCode == serviceState.ExitCode {
+			statuses = append(statuses, status)
+		}
+	}
+
+	if serviceState.ExitCode == nagios.StateUNKNOWNExitCode {
+		statuses = append(statuses, ComponentStatusUnknown)
+	}
+
+	return statuses
+}
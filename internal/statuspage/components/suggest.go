@@ -0,0 +1,104 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package components
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/atc0005/check-statuspage/internal/textutils"
+)
+
+// minSuggestionContainsLength is the minimum length of a normalized search
+// value before a component name containing the search value (or contained by
+// it) is considered similar regardless of edit distance. This helps prevent
+// very short search values from matching most component names.
+const minSuggestionContainsLength int = 3
+
+// Suggestion is a component or component group with a name or ID value
+// similar to a search value which could not be matched.
+type Suggestion struct {
+	Component *Component
+
+	// Distance is the edit distance between the normalized search value and
+	// the closest of the normalized component name or ID value.
+	Distance int
+}
+
+// Suggest returns up to the specified number of components with a name or ID
+// value similar to the given search value, closest match first. If groups is
+// true only component groups are considered, otherwise only components which
+// are not component groups are considered. Values are compared after case
+// folding and whitespace normalization. A component is considered similar if
+// the edit distance is small relative to the length of the search value or if
+// one normalized name contains the other.
+func (cs *Set) Suggest(searchKey string, groups bool, limit int) []Suggestion {
+
+	key := normalizeName(searchKey)
+	if key == "" || limit < 1 {
+		return nil
+	}
+
+	keyLength := utf8.RuneCountInString(key)
+	maxDistance := max(2, keyLength/3)
+
+	var suggestions []Suggestion
+	for i := range cs.Components {
+		component := &cs.Components[i]
+		if component.Group != groups {
+			continue
+		}
+
+		name := normalizeName(component.Name)
+		distance := min(
+			textutils.EditDistance(key, name),
+			textutils.EditDistance(key, strings.ToLower(strings.TrimSpace(component.ID))),
+		)
+
+		contains := keyLength >= minSuggestionContainsLength &&
+			utf8.RuneCountInString(name) >= minSuggestionContainsLength &&
+			(strings.Contains(name, key) || strings.Contains(key, name))
+
+		if distance > maxDistance && !contains {
+			continue
+		}
+
+		logger.Printf(
+			"Component similar to search value %q (distance %d): %s",
+			searchKey,
+			distance,
+			component,
+		)
+
+		suggestions = append(suggestions, Suggestion{
+			Component: component,
+			Distance:  distance,
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Distance != suggestions[j].Distance {
+			return suggestions[i].Distance < suggestions[j].Distance
+		}
+
+		return suggestions[i].Component.Name < suggestions[j].Component.Name
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions
+}
+
+// normalizeName returns the given component name or search value with case
+// folded and whitespace normalized for comparison.
+func normalizeName(name string) string {
+	return strings.ToLower(textutils.NormalizeSpace(name))
+}
@@ -0,0 +1,150 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package components
+
+import (
+	"fmt"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// ProblemThreshold is a user-specified threshold for the number or
+// percentage of evaluated components in a non-operational status. A zero
+// value ProblemThreshold (empty Range) is not evaluated.
+type ProblemThreshold struct {
+
+	// Range is the threshold in the Nagios range format (e.g., "5", "10:",
+	// "@0:3") without a percent suffix.
+	Range string
+
+	// Percent indicates whether the Range applies to the percentage of
+	// evaluated components in a non-operational status instead of the
+	// number of those components.
+	Percent bool
+}
+
+// IsSet indicates whether the threshold was specified.
+func (pt ProblemThreshold) IsSet() bool {
+	return pt.Range != ""
+}
+
+// String implements the Stringer interface. The text form of a
+// ProblemThreshold is the same as that accepted by the warning and critical
+// flags (e.g., "10%").
+func (pt ProblemThreshold) String() string {
+	if pt.Percent {
+		return pt.Range + "%"
+	}
+
+	return pt.Range
+}
+
+// NumEvaluatedComponents returns the number of components in the set which
+// have not been marked as excluded. Component groups are not included in the
+// count since groups mirror the status of subcomponents unless evaluated as
+// a single unit per the GroupRollup for the set.
+func (cs *Set) NumEvaluatedComponents() int {
+	var n int
+
+	for i := range cs.Components {
+		if cs.isEvaluated(&cs.Components[i], false) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// NumEvaluatedComponents returns the total number of components which have
+// not been marked as excluded across all Sets in the collection.
+func (css Sets) NumEvaluatedComponents() int {
+	return css.sum(func(cs *Set) int { return cs.NumEvaluatedComponents() })
+}
+
+// PercentProblemComponents returns the percentage of evaluated components
+// across all Sets in the collection which are in a non-operational status.
+// Zero is returned if there are no evaluated components.
+func (css Sets) PercentProblemComponents() float64 {
+	numEvaluated := css.NumEvaluatedComponents()
+	if numEvaluated == 0 {
+		return 0
+	}
+
+	return float64(css.NumProblemComponents(false)) / float64(numEvaluated) * 100
+}
+
+// problemThresholdValue returns the value compared against the given
+// threshold as performance data text: the number of evaluated components in
+// a non-operational status or the percentage of evaluated components in a
+// non-operational status if the threshold is a percentage.
+func (css Sets) problemThresholdValue(threshold ProblemThreshold) string {
+	if threshold.Percent {
+		return fmt.Sprintf("%.2f", css.PercentProblemComponents())
+	}
+
+	return fmt.Sprintf("%d", css.NumProblemComponents(false))
+}
+
+// ProblemThresholdsServiceState returns the Nagios ServiceState for the
+// collection determined by the given warning and critical thresholds in
+// place of the states of individual components. A CRITICAL state is returned
+// if the critical threshold is exceeded, otherwise a WARNING state if the
+// warning threshold is exceeded, otherwise an OK state. Thresholds which
+// were not specified are not evaluated. An error wrapping
+// nagios.ErrInvalidRangeThreshold is returned if a threshold is not in the
+// Nagios range format.
+func (css Sets) ProblemThresholdsServiceState(warning ProblemThreshold, critical ProblemThreshold) (nagios.ServiceState, error) {
+
+	checks := []struct {
+		threshold    ProblemThreshold
+		serviceState nagios.ServiceState
+	}{
+		{
+			threshold: critical,
+			serviceState: nagios.ServiceState{
+				Label:    nagios.StateCRITICALLabel,
+				ExitCode: nagios.StateCRITICALExitCode,
+			},
+		},
+		{
+			threshold: warning,
+			serviceState: nagios.ServiceState{
+				Label:    nagios.StateWARNINGLabel,
+				ExitCode: nagios.StateWARNINGExitCode,
+			},
+		},
+	}
+
+	for _, check := range checks {
+		if !check.threshold.IsSet() {
+			continue
+		}
+
+		thresholdRange := nagios.ParseRangeString(check.threshold.Range)
+		if thresholdRange == nil {
+			return nagios.ServiceState{
+				Label:    nagios.StateUNKNOWNLabel,
+				ExitCode: nagios.StateUNKNOWNExitCode,
+			}, fmt.Errorf(
+				"failed to parse %s threshold %q: %w",
+				check.serviceState.Label,
+				check.threshold,
+				nagios.ErrInvalidRangeThreshold,
+			)
+		}
+
+		if thresholdRange.CheckRange(css.problemThresholdValue(check.threshold)) {
+			return check.serviceState, nil
+		}
+	}
+
+	return nagios.ServiceState{
+		Label:    nagios.StateOKLabel,
+		ExitCode: nagios.StateOKExitCode,
+	}, nil
+}
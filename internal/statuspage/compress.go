@@ -0,0 +1,205 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// File extensions for compressed JSON files. Compressed files are
+// decompressed transparently when read.
+const (
+	gzipFileExt string = ".gz"
+	zstdFileExt string = ".zst"
+)
+
+// Content-Encoding values for compressed HTTP responses.
+const (
+	contentEncodingGzip     string = "gzip"
+	contentEncodingXGzip    string = "x-gzip"
+	contentEncodingIdentity string = "identity"
+)
+
+// zstdMaxWindowSize is the maximum window size accepted when decompressing
+// zstd compressed files. This limits memory use for maliciously crafted
+// input.
+const zstdMaxWindowSize uint64 = 8 << 20
+
+// limitedReader is an io.Reader which returns ErrReadLimitExceeded if more
+// than the specified number of bytes are available from the underlying
+// reader. Unlike io.LimitReader, exceeding the limit is reported as an error
+// instead of a silent EOF.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	exceeded  bool
+}
+
+// limitChecker is implemented by readers which record whether the read limit
+// for the underlying source was exceeded. This allows the limit to be
+// reported reliably even if a consumer (e.g., a JSON decoder) replaces the
+// read error with one of its own.
+type limitChecker interface {
+	limitExceeded() bool
+}
+
+// Read reads from the underlying reader, returning ErrReadLimitExceeded once
+// the limit is reached and further input remains.
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	if lr.remaining <= 0 {
+		return 0, lr.checkExhausted()
+	}
+
+	if int64(len(p)) > lr.remaining {
+		p = p[:lr.remaining]
+	}
+
+	n, err := lr.r.Read(p)
+	lr.remaining -= int64(n)
+
+	if lr.remaining <= 0 && err == nil {
+		err = lr.checkExhausted()
+	}
+
+	return n, err
+}
+
+// checkExhausted attempts to read a single byte beyond the limit from the
+// underlying reader. ErrReadLimitExceeded is returned if successful, io.EOF
+// if the underlying reader is exhausted.
+func (lr *limitedReader) checkExhausted() error {
+	var b [1]byte
+
+	n, err := io.ReadFull(lr.r, b[:])
+	switch {
+	case n > 0:
+		lr.exceeded = true
+		return ErrReadLimitExceeded
+	case errors.Is(err, io.EOF):
+		return io.EOF
+	default:
+		return err
+	}
+}
+
+// limitExceeded indicates whether input beyond the limit was found.
+func (lr *limitedReader) limitExceeded() bool {
+	return lr.exceeded
+}
+
+// decompressReadCloser pairs a decompressing reader with the function used
+// to release its resources and close the underlying source.
+type decompressReadCloser struct {
+	*limitedReader
+	closeFunc func() error
+}
+
+// Close releases resources used by the decompressing reader and closes the
+// underlying source.
+func (drc decompressReadCloser) Close() error {
+	return drc.closeFunc()
+}
+
+// newDecompressReader returns a reader which decompresses the given source
+// using the specified format (file extension). The decompressed output is
+// limited to the specified number of bytes to guard against decompression
+// bombs; ErrReadLimitExceeded is returned by the reader if the limit is
+// exceeded. The given source is returned as-is if the format is not a
+// supported compression format. Closing the returned reader closes the given
+// source.
+func newDecompressReader(src io.ReadCloser, format string, limit int64) (io.ReadCloser, error) {
+
+	switch format {
+	case gzipFileExt:
+		logger.Print("Decompressing gzip input")
+		gzr, err := gzip.NewReader(src)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip header: %w", err)
+		}
+
+		return decompressReadCloser{
+			limitedReader: &limitedReader{r: gzr, remaining: limit},
+			closeFunc: func() error {
+				return errors.Join(gzr.Close(), src.Close())
+			},
+		}, nil
+
+	case zstdFileExt:
+		logger.Print("Decompressing zstd input")
+		zr, err := zstd.NewReader(
+			src,
+			zstd.WithDecoderConcurrency(1),
+			zstd.WithDecoderMaxWindow(zstdMaxWindowSize),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare zstd decoder: %w", err)
+		}
+
+		return decompressReadCloser{
+			limitedReader: &limitedReader{r: zr, remaining: limit},
+			closeFunc: func() error {
+				zr.Close()
+				return src.Close()
+			},
+		}, nil
+
+	default:
+		return src, nil
+	}
+}
+
+// compressionFormat returns the compression format (file extension) for the
+// given filename or an empty string if the file is not compressed.
+func compressionFormat(filename string) string {
+	switch ext := strings.ToLower(filepath.Ext(filename)); ext {
+	case gzipFileExt, zstdFileExt:
+		return ext
+	default:
+		return ""
+	}
+}
+
+// decompressResponse returns a reader for the decompressed response body
+// based on the Content-Encoding specified by the server. The decompressed
+// output (or the response body as-is if not compressed) is limited to the
+// specified number of bytes; ErrReadLimitExceeded is returned by the reader
+// if the limit is exceeded. An error wrapping
+// ErrUnsupportedContentEncoding is returned for an unsupported encoding.
+// Closing the returned reader closes the response body.
+func decompressResponse(response *http.Response, limit int64) (io.ReadCloser, error) {
+
+	encoding := strings.ToLower(strings.TrimSpace(response.Header.Get("Content-Encoding")))
+
+	switch encoding {
+	case "", contentEncodingIdentity:
+		return decompressReadCloser{
+			limitedReader: &limitedReader{r: response.Body, remaining: limit},
+			closeFunc:     response.Body.Close,
+		}, nil
+
+	case contentEncodingGzip, contentEncodingXGzip:
+		logger.Printf("Response body is %s encoded", encoding)
+
+		return newDecompressReader(response.Body, gzipFileExt, limit)
+
+	default:
+		return nil, fmt.Errorf(
+			"%w: %q",
+			ErrUnsupportedContentEncoding,
+			encoding,
+		)
+	}
+}
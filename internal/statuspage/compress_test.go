@@ -0,0 +1,171 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// testPage is the destination used when decoding testFeed.
+type testPage struct {
+	Page struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"page"`
+}
+
+// gzipData returns the given data compressed using gzip.
+func gzipData(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	if _, err := gzw.Write(data); err != nil {
+		t.Fatalf("ERROR: failed to compress data: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("ERROR: failed to compress data: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// zstdData returns the given data compressed using zstd.
+func zstdData(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	zw, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatalf("ERROR: failed to prepare zstd encoder: %v", err)
+	}
+	defer zw.Close()
+
+	return zw.EncodeAll(data, nil)
+}
+
+// TestDecodeFromFileCompressed asserts that gzip and zstd compressed files
+// are decompressed transparently and that the read limit is applied to the
+// decompressed size.
+func TestDecodeFromFileCompressed(t *testing.T) {
+	t.Parallel()
+
+	// Padding compresses well, so the compressed files are well under the
+	// read limit while the decompressed content exceeds it.
+	oversizedFeed := []byte(strings.Replace(testFeed, "{", "{"+strings.Repeat(" ", 4096), 1))
+
+	tests := []struct {
+		name          string
+		filename      string
+		data          []byte
+		errorExpected error
+	}{
+		{
+			name:     "Gzip",
+			filename: "components.json.gz",
+			data:     gzipData(t, []byte(testFeed)),
+		},
+		{
+			name:     "Zstd",
+			filename: "components.json.zst",
+			data:     zstdData(t, []byte(testFeed)),
+		},
+		{
+			name:          "Gzip exceeding read limit",
+			filename:      "oversized.json.gz",
+			data:          gzipData(t, oversizedFeed),
+			errorExpected: ErrReadLimitExceeded,
+		},
+		{
+			name:          "Zstd exceeding read limit",
+			filename:      "oversized.json.zst",
+			data:          zstdData(t, oversizedFeed),
+			errorExpected: ErrReadLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filename := filepath.Join(t.TempDir(), tt.filename)
+			if err := os.WriteFile(filename, tt.data, 0o600); err != nil {
+				t.Fatalf("ERROR: failed to write test file: %v", err)
+			}
+
+			var dst testPage
+			err := DecodeFromFile(&dst, filename, 1024, false)
+
+			switch {
+			case tt.errorExpected != nil && !errors.Is(err, tt.errorExpected):
+				t.Fatalf("ERROR: want error %v, got %v", tt.errorExpected, err)
+
+			case tt.errorExpected != nil:
+				t.Logf("OK: got expected error: %v", err)
+
+			case err != nil:
+				t.Fatalf("ERROR: failed to decode compressed file: %v", err)
+
+			case dst.Page.ID != "kctbh9vrtdwd":
+				t.Fatalf("ERROR: want page ID %q, got %q", "kctbh9vrtdwd", dst.Page.ID)
+
+			default:
+				t.Logf("OK: decoded compressed file as expected")
+			}
+		})
+	}
+}
+
+// TestDecodeFromURLGzipResponse asserts that gzip compressed responses are
+// requested and decompressed and that the read limit is applied to the
+// decompressed size.
+func TestDecodeFromURLGzipResponse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("ERROR: want Accept-Encoding header value %q, got %q", "gzip", got)
+		}
+
+		feed := []byte(testFeed)
+		if r.URL.Path == "/oversized.json" {
+			feed = []byte(strings.Replace(testFeed, "{", "{"+strings.Repeat(" ", 4096), 1))
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(gzipData(t, feed))
+	}))
+	defer server.Close()
+
+	var dst testPage
+	if err := DecodeFromURL(context.Background(), &dst, server.URL+"/components.json", 1024, false, "", FetchOptions{}); err != nil {
+		t.Fatalf("ERROR: failed to decode gzip compressed response: %v", err)
+	}
+
+	if dst.Page.ID != "kctbh9vrtdwd" {
+		t.Fatalf("ERROR: want page ID %q, got %q", "kctbh9vrtdwd", dst.Page.ID)
+	}
+	t.Log("OK: decoded gzip compressed response as expected")
+
+	var oversized testPage
+	err := DecodeFromURL(context.Background(), &oversized, server.URL+"/oversized.json", 1024, false, "", FetchOptions{})
+	if !errors.Is(err, ErrReadLimitExceeded) {
+		t.Fatalf("ERROR: want error %v, got %v", ErrReadLimitExceeded, err)
+	}
+	t.Logf("OK: got expected error: %v", err)
+}
@@ -0,0 +1,165 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrResponseOutsideRange indicates that a response was received which falls
+// outside of an acceptable range.
+var ErrResponseOutsideRange = errors.New(
+	"response is outside acceptable range",
+)
+
+// ErrPageIDMismatch indicates that the page ID provided by a Statuspage
+// API/JSON feed does not match the expected page ID. This usually indicates
+// that the specified URL refers to the wrong vendor.
+var ErrPageIDMismatch = errors.New(
+	"page ID does not match expected page ID",
+)
+
+// ErrReadLimitExceeded indicates that input (after decompression, if
+// applicable) exceeds the specified read limit. This guards against
+// oversized responses and decompression bombs.
+var ErrReadLimitExceeded = errors.New(
+	"input exceeds read limit",
+)
+
+// ErrUnsupportedContentEncoding indicates that a response was received with a
+// Content-Encoding which is not supported.
+var ErrUnsupportedContentEncoding = errors.New(
+	"unsupported response content encoding",
+)
+
+// Prep tasks for retrieving and decoding a Statuspage API/JSON feed.
+const (
+	PrepTaskParseURL        string = "parse URL"
+	PrepTaskPrepareRequest  string = "prepare request"
+	PrepTaskPrepareClient   string = "prepare client"
+	PrepTaskDecode          string = "decode JSON data"
+	PrepTaskSubmitRequest   string = "submit request"
+	PrepTaskProcessResponse string = "process response"
+	PrepTaskOpenFile        string = "open file"
+	PrepTaskDecompress      string = "decompress input"
+	PrepTaskRetrieveFeed    string = "retrieve feed"
+)
+
+// PrepError represents a class of errors encountered while performing tasks
+// related to retrieving and decoding a Statuspage API/JSON feed.
+type PrepError struct {
+
+	// Step indicates the specific prep task which failed.
+	//
+	// NOTE: Constants should be used to make comparisons more reliable.
+	Task string
+
+	// Message provides additional (brief) context describing why the error
+	// occurred.
+	//
+	// e.g., "error parsing URL" or "error preparing request for URL"
+	Message string
+
+	// Source associated with the prep task.
+	//
+	// e.g., "/tmp/components.json",
+	// "https://status.example.com/api/v2/components.json"
+	Source string
+
+	// Cause is the underlying error which occurred while performing a task as
+	// part of retrieving and decoding a feed. This error is "bundled" for
+	// later evaluation.
+	Cause error
+
+	// Attempts is the number of attempts made to submit a request for the
+	// source. This is zero for sources which are not retrieved via HTTP.
+	Attempts int
+
+	// StatusCode is the HTTP status code of an unexpected response received
+	// for the source. This is zero if no unexpected response was received.
+	StatusCode int
+}
+
+// Error provides a human readable explanation for a feed preparation task
+// failure.
+func (s *PrepError) Error() string {
+	if s.Attempts > 1 {
+		return fmt.Sprintf(
+			"task: %q: %s: source: %s attempts: %d cause: %v",
+			s.Task,
+			s.Message,
+			s.Source,
+			s.Attempts,
+			s.Cause,
+		)
+	}
+
+	return fmt.Sprintf(
+		"task: %q: %s: source: %s cause: %v",
+		s.Task,
+		s.Message,
+		s.Source,
+		s.Cause,
+	)
+}
+
+// Is supports error wrapping by indicating whether a given error matches the
+// specific failed task associated with this error.
+func (s *PrepError) Is(target error) bool {
+	t, ok := target.(*PrepError)
+	if !ok {
+		return false
+	}
+
+	return t.Task == s.Task
+}
+
+// Unwrap supports error wrapping by returning the enclosed error associated
+// with the specific failed task encountered as part of retrieving and
+// decoding a feed.
+func (s *PrepError) Unwrap() error {
+	return s.Cause
+}
+
+// StaleFeedError indicates that a Statuspage API/JSON feed could not be
+// retrieved and that the last good copy of the feed was decoded in its place.
+// The destination given when retrieving the feed is populated from the last
+// good copy.
+type StaleFeedError struct {
+
+	// Source is the API/JSON feed URL which could not be retrieved.
+	Source string
+
+	// StoredAt is when the last good copy of the feed was retrieved.
+	StoredAt time.Time
+
+	// Age is how old the last good copy of the feed was when it was decoded.
+	Age time.Duration
+
+	// Cause is the error which prevented retrieving the feed.
+	Cause error
+}
+
+// Error provides a human readable explanation for the use of the last good
+// copy of a feed.
+func (s *StaleFeedError) Error() string {
+	return fmt.Sprintf(
+		"using last good copy of feed from %s retrieved %s ago: %v",
+		s.Source,
+		s.Age.Round(time.Second),
+		s.Cause,
+	)
+}
+
+// Unwrap supports error wrapping by returning the error which prevented
+// retrieving the feed.
+func (s *StaleFeedError) Unwrap() error {
+	return s.Cause
+}
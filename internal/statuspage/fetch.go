@@ -0,0 +1,592 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// StdinFilename is the filename used to indicate that a Statuspage API/JSON
+// feed should be read from standard input.
+const StdinFilename string = "-"
+
+// stdinSourceName is the source name used when reading from standard input.
+const stdinSourceName string = "standard input"
+
+// stdin is the reader used for standard input. This is overridden by tests.
+var stdin io.Reader = os.Stdin
+
+// FetchOptions represents optional settings used when retrieving a
+// Statuspage API/JSON feed from a URL. The zero value is usable and retrieves
+// the feed without any optional behavior.
+type FetchOptions struct {
+
+	// CacheDir is an optional directory used to store the last response body
+	// retrieved for each URL along with the ETag and Last-Modified header
+	// values. If specified, conditional requests are submitted and a "Not
+	// Modified" response is decoded from the cached response body.
+	CacheDir string
+
+	// Retries is the number of additional attempts made to submit a request
+	// after a connection error, timeout or transient HTTP status code (502,
+	// 503, 504). All attempts are made within the context deadline.
+	Retries int
+
+	// RetryDelay is the base delay between attempts. The delay increases
+	// exponentially (with jitter) for each attempt.
+	RetryDelay time.Duration
+
+	// MaxStale is the maximum age of the last good (cached) response body
+	// decoded in place of a feed which could not be retrieved. If zero, the
+	// last good response body is not used. Requires CacheDir.
+	MaxStale time.Duration
+
+	// ProxyURL is an optional HTTP(S) proxy URL used in place of any proxy
+	// specified via environment variables.
+	ProxyURL string
+
+	// CABundle is an optional fully-qualified filename of a PEM encoded
+	// bundle of CA certificates trusted in addition to the system
+	// certificate pool.
+	CABundle string
+
+	// ClientCert is an optional fully-qualified filename of a PEM encoded
+	// client certificate presented to the server. Requires ClientKey.
+	ClientCert string
+
+	// ClientKey is an optional fully-qualified filename of the PEM encoded
+	// private key for ClientCert.
+	ClientKey string
+
+	// MinTLSVersion is the minimum TLS version accepted (e.g.,
+	// tls.VersionTLS12). If zero, the Go default is used.
+	MinTLSVersion uint16
+
+	// InsecureSkipVerify disables verification of the server certificate
+	// chain and host name. This should only be used for troubleshooting.
+	InsecureSkipVerify bool
+
+	// Headers is an optional collection of HTTP headers added to the request.
+	Headers http.Header
+
+	// BasicAuthUser is an optional username used for basic authentication.
+	// BasicAuthPassword is used as the password.
+	BasicAuthUser string
+
+	// BasicAuthPassword is the password used for basic authentication.
+	BasicAuthPassword string
+
+	// BearerToken is an optional token supplied via the Authorization header
+	// using the Bearer scheme.
+	BearerToken string
+}
+
+// prepareRequest is a helper function that prepares a http.Request (including
+// all desired headers) for submission to an endpoint. Headers and
+// authentication credentials specified by the given options are applied
+// after the default headers, so may override them.
+func prepareRequest(ctx context.Context, apiURL string, userAgent string, opts FetchOptions) (*http.Request, error) {
+	logger.Printf("Validating URL %q before attempting to read data", apiURL)
+	parsedURL, err := url.Parse(apiURL)
+	if err != nil {
+		return nil, &PrepError{
+			Task:    PrepTaskParseURL,
+			Message: "error parsing URL",
+			Source:  apiURL,
+			Cause:   err,
+		}
+	}
+	logger.Printf("Successfully validated URL %q", apiURL)
+
+	logger.Print("Preparing HTTP request")
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedURL.String(), nil)
+	if err != nil {
+		return nil, &PrepError{
+			Task:    PrepTaskPrepareRequest,
+			Source:  apiURL,
+			Message: "error preparing request for URL",
+			Cause:   err,
+		}
+	}
+
+	// Explicitly note that we want JSON content.
+	request.Header.Add("Content-Type", "application/json;charset=utf-8")
+
+	// If provided, override the default Go user agent ("Go-http-client/1.1")
+	// with custom value.
+	if userAgent != "" {
+		request.Header.Set("User-Agent", userAgent)
+	}
+
+	// Explicitly request (and handle) a compressed response. The transport
+	// does not transparently decompress responses when this header is set,
+	// which allows the read limit to be applied to the decompressed size.
+	request.Header.Set("Accept-Encoding", contentEncodingGzip)
+
+	for name, values := range opts.Headers {
+		logger.Printf("Applying user-specified header %q", name)
+		request.Header[http.CanonicalHeaderKey(name)] = values
+	}
+
+	switch {
+	case opts.BasicAuthUser != "":
+		logger.Printf("Applying basic authentication for user %q", opts.BasicAuthUser)
+		request.SetBasicAuth(opts.BasicAuthUser, opts.BasicAuthPassword)
+
+	case opts.BearerToken != "":
+		logger.Print("Applying bearer token authentication")
+		request.Header.Set("Authorization", "Bearer "+opts.BearerToken)
+	}
+
+	return request, nil
+}
+
+// processResponse is a helper function responsible for validating a response
+// from an endpoint after submitting a message.
+func processResponse(ctx context.Context, response *http.Response, limit int64) error {
+
+	feedSource := response.Request.URL.RequestURI()
+
+	if err := ctx.Err(); err != nil {
+		logger.Print("context has expired")
+		return &PrepError{
+			Task:    PrepTaskProcessResponse,
+			Message: "timeout reached",
+			Source:  feedSource,
+			Cause:   err,
+		}
+	}
+
+	switch {
+	case response.ContentLength == -1:
+		logger.Printf("Response indicates unknown length of content from %q", feedSource)
+	default:
+		logger.Printf(
+			"Response indicates %d bytes available to be read from %q",
+			response.ContentLength,
+			feedSource,
+		)
+	}
+
+	switch {
+
+	// Successful / expected response.
+	case response.StatusCode == http.StatusOK:
+		logger.Printf("Status code %d received as expected", response.StatusCode)
+
+		return nil
+
+	// Success status range, but not in API docs for Statuspage endpoints.
+	case response.StatusCode > 200 && response.StatusCode <= 299:
+		logger.Printf(
+			"Status code %d (%s) received; expected %d (%s), but received value within success range",
+			response.StatusCode,
+			http.StatusText(response.StatusCode),
+			http.StatusOK,
+			http.StatusText(http.StatusOK),
+		)
+
+		return nil
+
+	// Everything else is assumed to be an error (outside of success range).
+	default:
+
+		// Get the response body, then convert to string for use with extended
+		// error messages. The response body is limited by the caller; an
+		// error response exceeding the limit is truncated as the status code
+		// is the relevant detail.
+		responseData, readErr := io.ReadAll(response.Body)
+		switch {
+		case errors.Is(readErr, ErrReadLimitExceeded):
+			logger.Printf("Response body exceeds limit of %d bytes; truncating", limit)
+
+		case readErr != nil:
+			logger.Print(readErr)
+
+			return &PrepError{
+				Task:    PrepTaskProcessResponse,
+				Message: "error reading response data",
+				Source:  feedSource,
+				Cause:   readErr,
+			}
+		}
+		responseString := string(responseData)
+
+		statusCodeErr := fmt.Errorf(
+			"response %v (%s) from API: %w",
+			response.Status,
+			responseString,
+			ErrResponseOutsideRange,
+		)
+
+		return &PrepError{
+			Task:       PrepTaskProcessResponse,
+			Message:    "unexpected response",
+			Source:     feedSource,
+			Cause:      statusCodeErr,
+			StatusCode: response.StatusCode,
+		}
+
+	}
+
+}
+
+// DecodeFromURL reads and decodes JSON data from a specified URL into the
+// given destination using the specified number of bytes as the read limit.
+// If specified, unknown fields in the JSON data are ignored. If provided, a
+// custom user agent is supplied in place of the default Go user agent. A gzip
+// compressed response is requested; the read limit applies to the
+// decompressed size. If a cache directory is specified via the given
+// options, the response is cached and later requests for the same URL are
+// submitted as conditional requests. Transient failures are retried as
+// specified by the given options. A PrepError recording the number of
+// attempts made is returned if there are problems retrieving or decoding
+// JSON data.
+//
+// If a maximum age for the last good response is specified via the given
+// options and the feed cannot be retrieved, the last good (cached) response
+// body is decoded instead and a StaleFeedError is returned. Errors other than
+// retrieval failures (e.g., a response which cannot be decoded) are returned
+// as-is.
+func DecodeFromURL(ctx context.Context, dst interface{}, apiURL string, limit int64, allowUnknownFields bool, userAgent string, opts FetchOptions) error {
+
+	var attempts int
+
+	err := decodeFromURL(ctx, dst, apiURL, limit, allowUnknownFields, userAgent, opts, &attempts)
+
+	var prepErr *PrepError
+	if errors.As(err, &prepErr) {
+		prepErr.Attempts = attempts
+	}
+
+	if isRetrievalFailure(err) && opts.MaxStale > 0 && opts.CacheDir != "" {
+		return decodeLastGood(dst, apiURL, limit, allowUnknownFields, opts, err)
+	}
+
+	return err
+}
+
+// decodeFromURL is a helper function which performs the work for
+// DecodeFromURL. The number of attempts made to submit the request is
+// recorded using the given pointer.
+func decodeFromURL(ctx context.Context, dst interface{}, apiURL string, limit int64, allowUnknownFields bool, userAgent string, opts FetchOptions, attempts *int) error {
+
+	request, err := prepareRequest(ctx, apiURL, userAgent, opts)
+	if err != nil {
+		return err
+	}
+
+	var cached *cacheEntry
+	if opts.CacheDir != "" {
+		cached, err = loadCacheEntry(opts.CacheDir, apiURL)
+		switch {
+		case err != nil:
+			logger.Printf("No usable cache entry for %q: %v", apiURL, err)
+		default:
+			logger.Printf("Using cache entry for %q stored at %v", apiURL, cached.StoredAt)
+			setConditionalHeaders(request, cached)
+		}
+	}
+
+	c, err := newHTTPClient(opts)
+	if err != nil {
+		return &PrepError{
+			Task:    PrepTaskPrepareClient,
+			Message: "error preparing HTTP client",
+			Source:  apiURL,
+			Cause:   err,
+		}
+	}
+
+	response, numAttempts, err := submitRequest(ctx, c, request, opts)
+	*attempts = numAttempts
+	if err != nil {
+		return &PrepError{
+			Task:    PrepTaskSubmitRequest,
+			Message: "error submitting HTTP request",
+			Source:  apiURL,
+			Cause:   err,
+		}
+	}
+
+	logger.Print("Successfully submitted HTTP request")
+
+	// Make sure that we close the response body once we're done with it
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			logger.Printf("error closing response body: %v", err)
+		}
+	}()
+
+	// The feed has not changed since it was cached; decode the cached copy.
+	if response.StatusCode == http.StatusNotModified && cached != nil {
+		logger.Printf(
+			"Status code %d received; decoding cached response body from %s",
+			response.StatusCode,
+			cached.bodyFile,
+		)
+
+		if err := DecodeFromFile(dst, cached.bodyFile, limit, allowUnknownFields); err != nil {
+			return err
+		}
+
+		// The cached response body is current; record this so that the age
+		// of the cache entry reflects the last successful retrieval.
+		if err := refreshCacheEntry(opts.CacheDir, cached); err != nil {
+			logger.Printf("Failed to refresh cache entry for %q: %v", apiURL, err)
+		}
+
+		return nil
+	}
+
+	// Decompress the response body (if needed) before evaluating it so that
+	// error responses are readable. The decompressed body is closed in place
+	// of the original response body.
+	body, err := decompressResponse(response, limit)
+	if err != nil {
+		return &PrepError{
+			Task:    PrepTaskDecompress,
+			Message: "failed to decompress response",
+			Source:  apiURL,
+			Cause:   err,
+		}
+	}
+	response.Body = body
+
+	// Evaluate the response
+	if err := processResponse(ctx, response, limit); err != nil {
+		return err
+	}
+
+	logger.Printf(
+		"Decoding JSON data from %q using a limit of %d bytes",
+		apiURL,
+		limit,
+	)
+
+	var reader io.Reader = response.Body
+
+	// Read the response body so that it can be both cached and decoded. The
+	// response body is limited to the read limit; a response exceeding the
+	// limit is rejected instead of being decoded (or cached) as a partial
+	// response.
+	var responseData []byte
+	if opts.CacheDir != "" {
+		responseData, err = io.ReadAll(response.Body)
+		if err != nil {
+			return &PrepError{
+				Task:    PrepTaskProcessResponse,
+				Message: "error reading response data",
+				Source:  apiURL,
+				Cause:   err,
+			}
+		}
+		reader = bytes.NewReader(responseData)
+	}
+
+	err = Decode(dst, reader, apiURL, limit, allowUnknownFields)
+	if err != nil {
+		return &PrepError{
+			Task:    PrepTaskDecode,
+			Message: "failed to decode JSON data",
+			Source:  apiURL,
+			Cause:   err,
+		}
+	}
+
+	logger.Printf(
+		"No errors encountered while decoding JSON data from %q",
+		apiURL,
+	)
+
+	// Only cache responses which were successfully decoded. Failure to cache
+	// a response is not fatal.
+	if opts.CacheDir != "" {
+		if err := storeCacheEntry(opts.CacheDir, apiURL, response.Header, responseData); err != nil {
+			logger.Printf("Failed to cache response from %q: %v", apiURL, err)
+		}
+	}
+
+	return nil
+
+}
+
+// DecodeFromFile reads and decodes JSON data from a fully-qualified path to a
+// JSON file into the given destination using the specified number of bytes
+// as the read limit. If specified, unknown fields in the JSON file are
+// ignored. Gzip (.gz) and zstd (.zst) compressed files are decompressed
+// transparently; the read limit applies to the decompressed size. If the
+// filename is StdinFilename, JSON data is read from standard input instead. A
+// PrepError is returned if there are problems reading the specified file or
+// decoding JSON data.
+func DecodeFromFile(dst interface{}, filename string, limit int64, allowUnknownFields bool) error {
+
+	if filename == StdinFilename {
+		return decodeFromStdin(dst, limit, allowUnknownFields)
+	}
+
+	logger.Printf("Opening file %s for reading", filename)
+	fh, err := os.Open(filepath.Clean(filename))
+	if err != nil {
+		return &PrepError{
+			Task:    PrepTaskOpenFile,
+			Message: "failed to open file for reading",
+			Source:  filename,
+			Cause:   err,
+		}
+	}
+	logger.Printf("Successfully opened file %s for reading", filename)
+
+	// #nosec G307
+	// Believed to be a false-positive from recent gosec release
+	// https://github.com/securego/gosec/issues/714
+	defer func() {
+		if err := fh.Close(); err != nil {
+			// Ignore "file already closed" errors
+			if !errors.Is(err, os.ErrClosed) {
+				logger.Printf("failed to close file %s: %s",
+					filename,
+					err.Error(),
+				)
+			}
+		}
+	}()
+
+	// Compressed files are decompressed transparently. The read limit
+	// applies to the decompressed size.
+	reader, err := newDecompressReader(fh, compressionFormat(filename), limit)
+	if err != nil {
+		return &PrepError{
+			Task:    PrepTaskDecompress,
+			Message: "failed to decompress file",
+			Source:  filename,
+			Cause:   err,
+		}
+	}
+
+	// Closing the reader also closes the file; the deferred close of the file
+	// above ignores the resulting "file already closed" error.
+	defer func() {
+		if err := reader.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+			logger.Printf("failed to close reader for file %s: %v", filename, err)
+		}
+	}()
+
+	logger.Printf(
+		"Decoding JSON file %s using a limit of %d bytes",
+		filename,
+		limit,
+	)
+
+	err = Decode(dst, reader, filename, limit, allowUnknownFields)
+	if err != nil {
+		return &PrepError{
+			Task:    PrepTaskDecode,
+			Message: "failed to decode JSON data",
+			Source:  filename,
+			Cause:   err,
+		}
+	}
+
+	logger.Printf(
+		"No errors encountered while decoding JSON file %s",
+		filename,
+	)
+
+	return nil
+}
+
+// decodeFromStdin reads and decodes JSON data from standard input into the
+// given destination using the specified number of bytes as the read limit.
+// If specified, unknown fields in the JSON data are ignored. A PrepError is
+// returned if there are problems decoding JSON data.
+func decodeFromStdin(dst interface{}, limit int64, allowUnknownFields bool) error {
+
+	logger.Printf(
+		"Decoding JSON data from %s using a limit of %d bytes",
+		stdinSourceName,
+		limit,
+	)
+
+	if err := Decode(dst, stdin, stdinSourceName, limit, allowUnknownFields); err != nil {
+		return &PrepError{
+			Task:    PrepTaskDecode,
+			Message: "failed to decode JSON data",
+			Source:  stdinSourceName,
+			Cause:   err,
+		}
+	}
+
+	logger.Printf(
+		"No errors encountered while decoding JSON data from %s",
+		stdinSourceName,
+	)
+
+	return nil
+}
+
+// Decode is a helper function intended to handle the core JSON decoding tasks
+// for various JSON sources (file, http body, etc.).
+func Decode(dst interface{}, reader io.Reader, sourceName string, limit int64, allowUnknownFields bool) error {
+
+	logger.Printf(
+		"Setting up JSON decoder for source %s with a limit of %d bytes",
+		sourceName,
+		limit,
+	)
+	dec := json.NewDecoder(io.LimitReader(reader, limit))
+
+	switch {
+	case !allowUnknownFields:
+		logger.Print("Disallowing unknown JSON feed fields")
+		dec.DisallowUnknownFields()
+	default:
+		logger.Print("Allowing unknown JSON feed fields by request")
+	}
+
+	logger.Print("Decoding JSON input")
+
+	// Decode the first JSON object.
+	if err := dec.Decode(dst); err != nil {
+		if lc, ok := reader.(limitChecker); ok && lc.limitExceeded() {
+			return fmt.Errorf(
+				"failed to decode JSON feed from source %s: %w",
+				sourceName,
+				ErrReadLimitExceeded,
+			)
+		}
+
+		return fmt.Errorf(
+			"failed to decode JSON feed from source %s: %w",
+			sourceName,
+			err,
+		)
+	}
+	logger.Print("Successfully decoded JSON input")
+
+	// If there is more than one object, something is off.
+	if dec.More() {
+		return fmt.Errorf(
+			"source %s contains multiple JSON objects; only one JSON object is supported",
+			sourceName,
+		)
+	}
+
+	return nil
+
+}
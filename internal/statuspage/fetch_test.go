@@ -0,0 +1,102 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestDecodeFromURLAppliesHeadersAndAuth asserts that user-specified headers
+// and authentication credentials are included in the submitted request.
+func TestDecodeFromURLAppliesHeadersAndAuth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		opts          FetchOptions
+		header        string
+		expectedValue string
+	}{
+		{
+			name: "Custom header",
+			opts: FetchOptions{
+				Headers: http.Header{"X-Audience": []string{"customers"}},
+			},
+			header:        "X-Audience",
+			expectedValue: "customers",
+		},
+		{
+			name: "Basic auth",
+			opts: FetchOptions{
+				BasicAuthUser:     "monitoring",
+				BasicAuthPassword: "s3cret",
+			},
+			header:        "Authorization",
+			expectedValue: "Basic bW9uaXRvcmluZzpzM2NyZXQ=",
+		},
+		{
+			name: "Bearer token",
+			opts: FetchOptions{
+				BearerToken: "abc123",
+			},
+			header:        "Authorization",
+			expectedValue: "Bearer abc123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get(tt.header); got != tt.expectedValue {
+					t.Errorf("ERROR: want %s header value %q, got %q", tt.header, tt.expectedValue, got)
+					w.WriteHeader(http.StatusUnauthorized)
+
+					return
+				}
+
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			var dst struct{}
+			if err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", tt.opts); err != nil {
+				t.Fatalf("ERROR: failed to decode feed: %v", err)
+			}
+
+			t.Logf("OK: %s header applied as expected", tt.header)
+		})
+	}
+}
+
+// TestDecodeFromFileStdin asserts that JSON data is read from standard input
+// if the filename is StdinFilename.
+func TestDecodeFromFileStdin(t *testing.T) {
+
+	// Not run in parallel; the package level stdin reader is replaced.
+	origStdin := stdin
+	defer func() { stdin = origStdin }()
+
+	stdin = strings.NewReader(testFeed)
+
+	var dst testPage
+	if err := DecodeFromFile(&dst, StdinFilename, 1024, false); err != nil {
+		t.Fatalf("ERROR: failed to decode JSON data from standard input: %v", err)
+	}
+
+	if dst.Page.ID != "kctbh9vrtdwd" {
+		t.Fatalf("ERROR: want page ID %q, got %q", "kctbh9vrtdwd", dst.Page.ID)
+	}
+
+	t.Log("OK: decoded JSON data from standard input as expected")
+}
@@ -0,0 +1,50 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"fmt"
+	"time"
+)
+
+// Page represents the page metadata included with every Statuspage API/JSON
+// feed.
+type Page struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	TimeZone  string    `json:"time_zone"`
+	UpdatedAt time.Time `json:"updated_at"`
+	URL       string    `json:"url"`
+}
+
+// Age returns how long before the given time the page was last updated.
+// Zero is returned if the page does not indicate when it was last updated.
+func (p Page) Age(now time.Time) time.Duration {
+	if p.UpdatedAt.IsZero() {
+		return 0
+	}
+
+	return now.Sub(p.UpdatedAt)
+}
+
+// ValidateID asserts that the page ID matches the given expected page ID. An
+// empty expected page ID is ignored. An error is returned if the page IDs do
+// not match.
+func (p Page) ValidateID(expected string) error {
+	if expected == "" || p.ID == expected {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w: got %q (%s), expected %q",
+		ErrPageIDMismatch,
+		p.ID,
+		p.Name,
+		expected,
+	)
+}
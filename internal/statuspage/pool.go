@@ -0,0 +1,124 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// FeedSource identifies a Statuspage API/JSON feed by either URL or
+// filename. If both are specified, the filename is used.
+type FeedSource struct {
+	URL      string
+	Filename string
+}
+
+// String returns the filename or URL used to retrieve the feed.
+func (fs FeedSource) String() string {
+	if fs.Filename != "" {
+		return fs.Filename
+	}
+
+	return fs.URL
+}
+
+// FetchFunc retrieves and decodes the Statuspage API/JSON feed for the
+// specified source. The index of the source in the collection given to
+// FetchAll is provided so that results can be recorded in order.
+type FetchFunc func(ctx context.Context, index int, source FeedSource) error
+
+// FetchAll calls the given FetchFunc for each source using a pool of (at
+// most) the specified number of workers. All workers share the given context
+// so that the full collection of sources is retrieved within the same
+// deadline.
+//
+// An error is returned for each source in the same order as the given
+// sources; the error for a successfully retrieved source is nil. Errors are
+// returned as PrepError values, or as StaleFeedError values wrapping a
+// PrepError if the last good copy of a feed was used. Sources not yet
+// retrieved when the context expires are not attempted.
+func FetchAll(ctx context.Context, sources []FeedSource, concurrency int, fetch FetchFunc) []error {
+
+	errs := make([]error, len(sources))
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	if concurrency > len(sources) {
+		concurrency = len(sources)
+	}
+
+	logger.Printf(
+		"Retrieving %d feeds using %d workers",
+		len(sources),
+		concurrency,
+	)
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				errs[i] = fetchSource(ctx, i, sources[i], fetch)
+			}
+		}()
+	}
+
+	for i := range sources {
+		indexes <- i
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	return errs
+}
+
+// fetchSource is a helper function which calls the given FetchFunc for a
+// single source and ensures that any error is returned as a PrepError.
+func fetchSource(ctx context.Context, index int, source FeedSource, fetch FetchFunc) error {
+
+	if err := ctx.Err(); err != nil {
+		logger.Printf("context has expired; skipping feed %q", source)
+
+		return &PrepError{
+			Task:    PrepTaskRetrieveFeed,
+			Message: "timeout reached",
+			Source:  source.String(),
+			Cause:   err,
+		}
+	}
+
+	logger.Printf("Retrieving feed %q", source)
+
+	err := fetch(ctx, index, source)
+	if err == nil {
+		logger.Printf("Successfully retrieved feed %q", source)
+
+		return nil
+	}
+
+	var prepErr *PrepError
+	if errors.As(err, &prepErr) {
+		return err
+	}
+
+	return &PrepError{
+		Task:    PrepTaskRetrieveFeed,
+		Message: "error retrieving feed",
+		Source:  source.String(),
+		Cause:   err,
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestFetchAllRespectsConcurrencyLimit asserts that no more than the
+// specified number of sources are retrieved at the same time.
+func TestFetchAllRespectsConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	const concurrency = 3
+
+	sources := make([]FeedSource, 10)
+	for i := range sources {
+		sources[i] = FeedSource{URL: fmt.Sprintf("https://example.com/%d", i)}
+	}
+
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+
+	errs := FetchAll(
+		context.Background(),
+		sources,
+		concurrency,
+		func(_ context.Context, _ int, _ FeedSource) error {
+			mu.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+
+			return nil
+		},
+	)
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("ERROR: unexpected error for source %d: %v", i, err)
+		}
+	}
+
+	if maxInFlight > concurrency {
+		t.Errorf("ERROR: want at most %d concurrent fetches, got %d", concurrency, maxInFlight)
+	} else {
+		t.Logf("OK: at most %d concurrent fetches", maxInFlight)
+	}
+}
+
+// TestFetchAllCollectsErrors asserts that an error for each failed source is
+// returned as a PrepError in the same order as the given sources.
+func TestFetchAllCollectsErrors(t *testing.T) {
+	t.Parallel()
+
+	sources := []FeedSource{
+		{Filename: "ok.json"},
+		{Filename: "fail.json"},
+		{URL: "https://example.com/fail"},
+		{URL: "https://example.com/ok"},
+	}
+
+	errFetch := errors.New("fetch failed")
+
+	errs := FetchAll(
+		context.Background(),
+		sources,
+		2,
+		func(_ context.Context, _ int, source FeedSource) error {
+			switch source.String() {
+			case "fail.json":
+				return errFetch
+			case "https://example.com/fail":
+				return &PrepError{
+					Task:    PrepTaskSubmitRequest,
+					Message: "error submitting HTTP request",
+					Source:  source.String(),
+					Cause:   errFetch,
+				}
+			default:
+				return nil
+			}
+		},
+	)
+
+	wantFailed := []bool{false, true, true, false}
+	for i, err := range errs {
+		switch {
+		case wantFailed[i] && err == nil:
+			t.Errorf("ERROR: want error for source %q, got nil", sources[i])
+
+		case !wantFailed[i] && err != nil:
+			t.Errorf("ERROR: want no error for source %q, got %v", sources[i], err)
+
+		case wantFailed[i]:
+			var prepErr *PrepError
+			if !errors.As(err, &prepErr) {
+				t.Errorf("ERROR: want PrepError for source %q, got %T", sources[i], err)
+			}
+
+			if !errors.Is(err, errFetch) {
+				t.Errorf("ERROR: want wrapped cause for source %q, got %v", sources[i], err)
+			}
+		}
+	}
+}
+
+// TestFetchAllRespectsContextDeadline asserts that sources are not retrieved
+// once the shared context has expired.
+func TestFetchAllRespectsContextDeadline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sources := []FeedSource{
+		{URL: "https://example.com/1"},
+		{URL: "https://example.com/2"},
+	}
+
+	errs := FetchAll(
+		ctx,
+		sources,
+		2,
+		func(_ context.Context, _ int, _ FeedSource) error {
+			t.Error("ERROR: fetch called after context expired")
+
+			return nil
+		},
+	)
+
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("ERROR: want context error for source %q, got %v", sources[i], err)
+		}
+	}
+}
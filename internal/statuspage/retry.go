@@ -0,0 +1,199 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// maxRetryDelay is the upper limit for the delay between attempts to submit
+// a request, regardless of the number of attempts already made.
+const maxRetryDelay time.Duration = 30 * time.Second
+
+// isRetryableStatus indicates whether the given HTTP status code represents
+// a transient failure (e.g., from a vendor CDN) worth retrying.
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// isPermanentTLSError indicates whether the given error represents a
+// certificate verification or TLS failure. These failures are not transient
+// and are not retried.
+func isPermanentTLSError(err error) bool {
+	var (
+		certInvalidErr      x509.CertificateInvalidError
+		unknownAuthorityErr x509.UnknownAuthorityError
+		hostnameErr         x509.HostnameError
+		systemRootsErr      x509.SystemRootsError
+		certVerifyErr       *tls.CertificateVerificationError
+		recordHeaderErr     tls.RecordHeaderError
+		alertErr            tls.AlertError
+	)
+
+	return errors.As(err, &certInvalidErr) ||
+		errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &systemRootsErr) ||
+		errors.As(err, &certVerifyErr) ||
+		errors.As(err, &recordHeaderErr) ||
+		errors.As(err, &alertErr)
+}
+
+// isRetryableError indicates whether the given error returned when
+// submitting a request represents a transient failure worth retrying:
+// timeouts, failures to connect to or read from the remote server, reset or
+// refused connections and responses cut short. Other errors (e.g., an
+// unsupported protocol scheme, invalid proxy settings or certificate
+// verification failures) are not retried. Errors are not retried once the
+// given context has expired.
+func isRetryableError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var opErr *net.OpError
+	var netErr net.Error
+
+	switch {
+	case isPermanentTLSError(err):
+		return false
+
+	case errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+
+	case errors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "read"):
+		return true
+
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return true
+
+	default:
+		return false
+	}
+}
+
+// retryDelay returns the jittered exponential backoff delay to wait before
+// the next attempt. The delay doubles for each attempt already made, is
+// capped at maxRetryDelay and is then randomized between half and the full
+// value to keep many clients from retrying in lockstep.
+func retryDelay(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+
+	delay := base
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+
+	half := delay / 2
+
+	return half + rand.N(half+1) //nolint:gosec // jitter does not need a secure source
+}
+
+// waitForRetry waits for the given delay. False is returned if the context
+// expires first.
+func waitForRetry(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// discardResponse reads a limited amount of the given response body (to
+// allow connection reuse) and then closes it.
+func discardResponse(response *http.Response) {
+	if response == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+	if err := response.Body.Close(); err != nil {
+		logger.Printf("error closing response body: %v", err)
+	}
+}
+
+// submitRequest submits the given request, retrying connection errors,
+// timeouts and transient HTTP status codes up to the number of retries
+// specified by the given options. All attempts are made within the deadline
+// for the given context. The final response is returned along with the
+// number of attempts made; the caller is responsible for closing the
+// response body.
+func submitRequest(ctx context.Context, client *http.Client, request *http.Request, opts FetchOptions) (*http.Response, int, error) {
+
+	maxAttempts := opts.Retries + 1
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var attempt int
+	for {
+		attempt++
+
+		logger.Printf("Submitting HTTP request (attempt %d of %d)", attempt, maxAttempts)
+		response, err := client.Do(request)
+
+		var retry bool
+		switch {
+		case err != nil:
+			retry = isRetryableError(ctx, err)
+			logger.Printf("Attempt %d failed: %v (retryable: %t)", attempt, err, retry)
+
+		case isRetryableStatus(response.StatusCode):
+			retry = true
+			logger.Printf("Attempt %d received status code %d (retryable: %t)", attempt, response.StatusCode, retry)
+		}
+
+		if !retry || attempt >= maxAttempts {
+			return response, attempt, err
+		}
+
+		delay := retryDelay(opts.RetryDelay, attempt)
+
+		// Leave the final response or error for the caller to evaluate if
+		// there is not enough time remaining for another attempt.
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			logger.Printf("Insufficient time remaining for another attempt after %d attempts", attempt)
+
+			return response, attempt, err
+		}
+
+		discardResponse(response)
+
+		if !waitForRetry(ctx, delay) {
+			return nil, attempt, ctx.Err()
+		}
+	}
+}
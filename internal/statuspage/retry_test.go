@@ -0,0 +1,276 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package statuspage
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestDecodeFromURLRetriesTransientStatus asserts that a transient HTTP
+// status code is retried and that the feed is decoded once a successful
+// response is received.
+func TestDecodeFromURLRetriesTransientStatus(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"page": {"id": "kctbh9vrtdwd", "name": "GitHub"}}`))
+	}))
+	defer server.Close()
+
+	opts := FetchOptions{Retries: 2, RetryDelay: time.Millisecond}
+
+	var dst struct {
+		Page Page `json:"page"`
+	}
+	if err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", opts); err != nil {
+		t.Fatalf("ERROR: failed to decode feed: %v", err)
+	}
+
+	switch {
+	case atomic.LoadInt32(&requests) != 2:
+		t.Errorf("ERROR: want 2 requests, got %d", requests)
+	case dst.Page.Name != "GitHub":
+		t.Errorf("ERROR: want page name %q, got %q", "GitHub", dst.Page.Name)
+	default:
+		t.Log("OK: feed decoded after retrying transient status code")
+	}
+}
+
+// TestDecodeFromURLRecordsAttempts asserts that the returned PrepError
+// records the number of attempts made when all attempts fail.
+func TestDecodeFromURLRecordsAttempts(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	opts := FetchOptions{Retries: 2, RetryDelay: time.Millisecond}
+
+	var dst struct{}
+	err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", opts)
+
+	var prepErr *PrepError
+	switch {
+	case !errors.As(err, &prepErr):
+		t.Fatalf("ERROR: want PrepError, got %v", err)
+	case prepErr.Attempts != 3:
+		t.Errorf("ERROR: want 3 attempts recorded, got %d", prepErr.Attempts)
+	case atomic.LoadInt32(&requests) != 3:
+		t.Errorf("ERROR: want 3 requests, got %d", requests)
+	default:
+		t.Logf("OK: got expected error: %v", err)
+	}
+}
+
+// TestDecodeFromURLRetriesWithinDeadline asserts that no further attempts
+// are made once the remaining time before the context deadline is shorter
+// than the delay before the next attempt.
+func TestDecodeFromURLRetriesWithinDeadline(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// The minimum jittered delay (half of the base delay) exceeds the
+	// deadline.
+	opts := FetchOptions{Retries: 5, RetryDelay: 10 * time.Second}
+
+	start := time.Now()
+
+	var dst struct{}
+	err := DecodeFromURL(ctx, &dst, server.URL, 1024, true, "", opts)
+
+	var prepErr *PrepError
+	switch {
+	case !errors.As(err, &prepErr):
+		t.Fatalf("ERROR: want PrepError, got %v", err)
+	case time.Since(start) >= 2*time.Second:
+		t.Errorf("ERROR: want return before deadline, took %v", time.Since(start))
+	case prepErr.Attempts != 1 || atomic.LoadInt32(&requests) != 1:
+		t.Errorf("ERROR: want 1 attempt, got %d (%d requests)", prepErr.Attempts, requests)
+	default:
+		t.Logf("OK: got expected error: %v", err)
+	}
+}
+
+// timeoutError is a net.Error which reports a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// TestIsRetryableError asserts that only transient request failures are
+// classed as retryable.
+func TestIsRetryableError(t *testing.T) {
+	t.Parallel()
+
+	// urlErr wraps the given error in the same way as the errors returned by
+	// http.Client.Do.
+	urlErr := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://example.statuspage.io/api/v2/components.json", Err: err}
+	}
+
+	expiredCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		err      error
+		expected bool
+	}{
+		{
+			name:     "Timeout",
+			err:      urlErr(timeoutError{}),
+			expected: true,
+		},
+		{
+			name:     "Connection refused",
+			err:      urlErr(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}),
+			expected: true,
+		},
+		{
+			name:     "Connection reset",
+			err:      urlErr(&net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.ECONNRESET)}),
+			expected: true,
+		},
+		{
+			name:     "Dial failure",
+			err:      urlErr(&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "server misbehaving", Name: "example.statuspage.io"}}),
+			expected: true,
+		},
+		{
+			name:     "Read failure",
+			err:      urlErr(&net.OpError{Op: "read", Net: "tcp", Err: errors.New("use of closed network connection")}),
+			expected: true,
+		},
+		{
+			name:     "Unexpected EOF",
+			err:      urlErr(io.ErrUnexpectedEOF),
+			expected: true,
+		},
+		{
+			name:     "Context expired",
+			ctx:      expiredCtx,
+			err:      urlErr(timeoutError{}),
+			expected: false,
+		},
+		{
+			name:     "Unsupported protocol scheme",
+			err:      urlErr(errors.New(`unsupported protocol scheme "ftp"`)),
+			expected: false,
+		},
+		{
+			name:     "Invalid proxy URL",
+			err:      urlErr(fmt.Errorf("proxyconnect tcp: %w", errors.New("invalid proxy address"))),
+			expected: false,
+		},
+		{
+			name:     "Unknown certificate authority",
+			err:      urlErr(&tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}),
+			expected: false,
+		},
+		{
+			name:     "Certificate hostname mismatch",
+			err:      urlErr(x509.HostnameError{Host: "example.statuspage.io"}),
+			expected: false,
+		},
+		{
+			name:     "Invalid certificate",
+			err:      urlErr(x509.CertificateInvalidError{Reason: x509.Expired}),
+			expected: false,
+		},
+		{
+			name:     "TLS record header",
+			err:      urlErr(tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}),
+			expected: false,
+		},
+		{
+			name:     "TLS alert during dial",
+			err:      urlErr(&net.OpError{Op: "dial", Net: "tcp", Err: tls.AlertError(40)}),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := test.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			if got := isRetryableError(ctx, test.err); got != test.expected {
+				t.Errorf("ERROR: want retryable %t, got %t for error %v", test.expected, got, test.err)
+			} else {
+				t.Logf("OK: got retryable %t for error %v", got, test.err)
+			}
+		})
+	}
+}
+
+// TestDecodeFromURLDoesNotRetryCertificateError asserts that a certificate
+// verification failure is not retried.
+func TestDecodeFromURLDoesNotRetryCertificateError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"page": {"id": "kctbh9vrtdwd", "name": "GitHub"}}`))
+	}))
+	defer server.Close()
+
+	opts := FetchOptions{Retries: 2, RetryDelay: time.Millisecond}
+
+	var dst struct{}
+	err := DecodeFromURL(context.Background(), &dst, server.URL, 1024, true, "", opts)
+
+	var prepErr *PrepError
+	switch {
+	case !errors.As(err, &prepErr):
+		t.Fatalf("ERROR: want PrepError, got %v", err)
+	case prepErr.Attempts != 1:
+		t.Errorf("ERROR: want 1 attempt, got %d", prepErr.Attempts)
+	default:
+		t.Logf("OK: got expected error: %v", err)
+	}
+}
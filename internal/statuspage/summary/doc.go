@@ -0,0 +1,12 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Package summary provides support for processing the summary of a status
+// page API powered by Atlassian Statuspage. This includes the page status
+// indicator, components, unresolved incidents and upcoming or active
+// scheduled maintenances.
+package summary
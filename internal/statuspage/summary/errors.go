@@ -0,0 +1,37 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package summary
+
+import "errors"
+
+// ErrSummaryValidationFailed indicates that validating decoded JSON data has
+// failed.
+var ErrSummaryValidationFailed = errors.New(
+	"decoded summary endpoint JSON data validation failed",
+)
+
+// ErrIncidentWithProblemImpactNotExcluded indicates that an unresolved
+// incident with a non-none impact was not excluded from evaluation. This is a
+// user-facing error, intended for display in detailed output.
+var ErrIncidentWithProblemImpactNotExcluded = errors.New(
+	"unresolved incident with service impact not excluded from evaluation",
+)
+
+// ErrScheduledMaintenanceNotExcluded indicates that an active or upcoming
+// scheduled maintenance was not excluded from evaluation. This is a
+// user-facing error, intended for display in detailed output.
+var ErrScheduledMaintenanceNotExcluded = errors.New(
+	"active or upcoming scheduled maintenance not excluded from evaluation",
+)
+
+// ErrPageStatusIndicatorNotOK indicates that the overall page status
+// indicator reports a problem. This is a user-facing error, intended for
+// display in detailed output.
+var ErrPageStatusIndicatorNotOK = errors.New(
+	"page status indicator reports service impact",
+)
@@ -0,0 +1,404 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package summary
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/atc0005/check-statuspage/internal/statuspage/components"
+	"github.com/atc0005/go-nagios"
+)
+
+// ServiceStateToIncidentImpacts returns a list of incident impact values
+// which map to the specified ServiceState. This list is intended for use in
+// threshold details emitted in plugin output.
+func ServiceStateToIncidentImpacts(serviceState nagios.ServiceState) []string {
+
+	switch serviceState.ExitCode {
+	case nagios.StateOKExitCode:
+		return []string{
+			IncidentImpactNone,
+		}
+
+	case nagios.StateWARNINGExitCode:
+		return []string{
+			IncidentImpactMinor,
+			IncidentImpactMaintenance,
+		}
+
+	case nagios.StateCRITICALExitCode:
+		return []string{
+			IncidentImpactMajor,
+			IncidentImpactCritical,
+		}
+
+	default:
+		return []string{}
+	}
+}
+
+// IncidentImpactToServiceState converts a given incident impact value to the
+// appropriate Nagios ServiceState. An UNKNOWN state is returned for
+// unrecognized impact values.
+func IncidentImpactToServiceState(impact string) nagios.ServiceState {
+
+	switch impact {
+	case IncidentImpactNone:
+		return nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		}
+
+	case IncidentImpactMinor, IncidentImpactMaintenance:
+		return nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		}
+
+	case IncidentImpactMajor, IncidentImpactCritical:
+		return nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		}
+
+	default:
+		// this shouldn't be reached, so assume something really odd occurred
+		logger.Printf("unknown incident impact %q provided, indicate UNKNOWN status", impact)
+		return nagios.ServiceState{
+			Label:    nagios.StateUNKNOWNLabel,
+			ExitCode: nagios.StateUNKNOWNExitCode,
+		}
+	}
+}
+
+// ServiceState returns the Nagios ServiceState for the incident based on its
+// impact value.
+func (i Incident) ServiceState() nagios.ServiceState {
+	return IncidentImpactToServiceState(i.Impact)
+}
+
+// IsOKState indicates whether the impact of the incident maps to an OK
+// state.
+func (i Incident) IsOKState() bool {
+	return i.ServiceState().ExitCode == nagios.StateOKExitCode
+}
+
+// LatestUpdate returns the most recent update posted for the incident or nil
+// if no updates are available.
+func (i Incident) LatestUpdate() *IncidentUpdate {
+	if len(i.IncidentUpdates) == 0 {
+		return nil
+	}
+
+	return &i.IncidentUpdates[0]
+}
+
+// String implements the Stringer interface for an incident.
+func (i Incident) String() string {
+	return fmt.Sprintf(
+		"{Name: %s, ID: %s, Status: %s, Impact: %s}",
+		i.Name,
+		i.ID,
+		i.Status,
+		i.Impact,
+	)
+}
+
+// Filter uses the given components Filter to mark incidents and scheduled
+// maintenances which do not affect any of the specified components or
+// component groups as excluded from evaluation.
+//
+// The filter is first applied to the full collection of page components if
+// the summary provides it (as the summary.json endpoint does or as recorded
+// using the UseComponents method). Incidents are then matched against the
+// remaining (non-excluded) components.
+//
+// If the full collection of page components is available, an error is
+// returned for filter values which do not match any component or component
+// group so that an invalid or misspelled value is not mistaken for an
+// unaffected component.
+//
+// If the full collection of page components is not available (as with the
+// incidents/unresolved.json endpoint), the filter is instead applied to the
+// components listed for all incidents and scheduled maintenances in the
+// summary. Filter values which do not match any listed component are treated
+// as not affected by any incident or scheduled maintenance. Since component
+// groups are not listed in this case, component groups may only be matched
+// by ID value.
+func (s *Summary) Filter(filter components.Filter) error {
+
+	// Unlikely, but possible scenario.
+	if s == nil {
+		return fmt.Errorf(
+			"unable to apply filter to empty summary;" +
+				" did you use a provided constructor to build summary?",
+		)
+	}
+
+	// Assert valid filter was provided.
+	if err := filter.Validate(); err != nil {
+		return fmt.Errorf(
+			"unable to apply Filter to summary: %w",
+			err,
+		)
+	}
+
+	var componentsSet *components.Set
+	var err error
+
+	switch {
+	case len(s.Components) > 0:
+		logger.Print("Applying filter to summary components collection")
+
+		componentsSet = s.ComponentsSet()
+		err = componentsSet.Filter(filter)
+
+	default:
+		logger.Print("Summary components collection empty; applying filter to affected components")
+
+		componentsSet = s.affectedComponentsSet()
+		err = componentsSet.FilterPartial(filter)
+	}
+
+	if err != nil {
+		return fmt.Errorf(
+			"unable to apply Filter to summary components: %w",
+			err,
+		)
+	}
+
+	matchedComponentIDs := make(map[string]struct{})
+	for _, component := range componentsSet.NotExcludedComponents() {
+		matchedComponentIDs[component.ID] = struct{}{}
+	}
+
+	isMatched := func(incident *Incident) bool {
+		for _, component := range incident.Components {
+			if _, ok := matchedComponentIDs[component.ID]; ok {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for i := range s.Incidents {
+		s.Incidents[i].Exclude = !isMatched(&s.Incidents[i])
+		logger.Printf(
+			"Incident %s excluded: %t",
+			s.Incidents[i].ID,
+			s.Incidents[i].Exclude,
+		)
+	}
+
+	for i := range s.ScheduledMaintenances {
+		s.ScheduledMaintenances[i].Exclude = !isMatched(&s.ScheduledMaintenances[i].Incident)
+		logger.Printf(
+			"Scheduled maintenance %s excluded: %t",
+			s.ScheduledMaintenances[i].ID,
+			s.ScheduledMaintenances[i].Exclude,
+		)
+	}
+
+	s.FilterUsed = filter
+	s.FilterApplied = true
+
+	return nil
+}
+
+// affectedComponentsSet is a helper method used to build a components Set
+// from the components listed for all incidents and scheduled maintenances in
+// the summary. Placeholder component groups are created from the group ID
+// value of affected subcomponents (unless the component group is itself
+// listed) so that a filter may reference a component group by ID.
+func (s *Summary) affectedComponentsSet() *components.Set {
+
+	var affected []components.Component
+	for i := range s.Incidents {
+		affected = append(affected, s.Incidents[i].Components...)
+	}
+	for i := range s.ScheduledMaintenances {
+		affected = append(affected, s.ScheduledMaintenances[i].Components...)
+	}
+
+	set := components.Set{Page: s.Page}
+
+	componentIdx := make(map[string]int)
+	for _, component := range affected {
+		if _, ok := componentIdx[component.ID]; ok {
+			continue
+		}
+
+		set.Components = append(set.Components, component)
+		componentIdx[component.ID] = len(set.Components) - 1
+	}
+
+	for _, component := range affected {
+		groupID := string(component.GroupID)
+		if component.Group || groupID == "" {
+			continue
+		}
+
+		idx, ok := componentIdx[groupID]
+		if !ok {
+			set.Components = append(set.Components, components.Component{
+				ID:     groupID,
+				Name:   groupID,
+				Status: components.ComponentStatusOperational,
+				PageID: component.PageID,
+				Group:  true,
+			})
+			idx = len(set.Components) - 1
+			componentIdx[groupID] = idx
+		}
+
+		group := &set.Components[idx]
+		if !slices.Contains(group.ComponentIDs, component.ID) {
+			group.ComponentIDs = append(group.ComponentIDs, component.ID)
+		}
+	}
+
+	return &set
+}
+
+// NumIncidents returns the count of all incidents in the summary (including
+// those marked for exclusion).
+func (s *Summary) NumIncidents() int {
+	return len(s.Incidents)
+}
+
+// NumExcludedIncidents returns the count of incidents marked for exclusion.
+func (s *Summary) NumExcludedIncidents() int {
+	var num int
+	for i := range s.Incidents {
+		if s.Incidents[i].Exclude {
+			num++
+		}
+	}
+
+	return num
+}
+
+// NotExcludedIncidents returns all incidents which have not been marked for
+// exclusion.
+func (s *Summary) NotExcludedIncidents() []*Incident {
+	incidents := make([]*Incident, 0, len(s.Incidents))
+	for i := range s.Incidents {
+		if !s.Incidents[i].Exclude {
+			incidents = append(incidents, &s.Incidents[i])
+		}
+	}
+
+	return incidents
+}
+
+// ProblemIncidents returns all incidents with an impact that maps to a
+// non-OK state. If specified, incidents marked for exclusion are also
+// evaluated.
+func (s *Summary) ProblemIncidents(evalExcluded bool) []*Incident {
+	incidents := make([]*Incident, 0, len(s.Incidents))
+	for i := range s.Incidents {
+		switch {
+		case s.Incidents[i].Exclude && !evalExcluded:
+			continue
+		case !s.Incidents[i].IsOKState():
+			incidents = append(incidents, &s.Incidents[i])
+		}
+	}
+
+	return incidents
+}
+
+// NumProblemIncidents returns the count of incidents with an impact that
+// maps to a non-OK state. If specified, incidents marked for exclusion are
+// also evaluated.
+func (s *Summary) NumProblemIncidents(evalExcluded bool) int {
+	return len(s.ProblemIncidents(evalExcluded))
+}
+
+// NumCriticalIncidents returns the count of incidents with an impact that
+// maps to a CRITICAL state. If specified, incidents marked for exclusion are
+// also evaluated.
+func (s *Summary) NumCriticalIncidents(evalExcluded bool) int {
+	return s.numIncidentsWithExitCode(nagios.StateCRITICALExitCode, evalExcluded)
+}
+
+// NumWarningIncidents returns the count of incidents with an impact that maps
+// to a WARNING state. If specified, incidents marked for exclusion are also
+// evaluated.
+func (s *Summary) NumWarningIncidents(evalExcluded bool) int {
+	return s.numIncidentsWithExitCode(nagios.StateWARNINGExitCode, evalExcluded)
+}
+
+// NumUnknownIncidents returns the count of incidents with an impact that maps
+// to an UNKNOWN state. If specified, incidents marked for exclusion are also
+// evaluated.
+func (s *Summary) NumUnknownIncidents(evalExcluded bool) int {
+	return s.numIncidentsWithExitCode(nagios.StateUNKNOWNExitCode, evalExcluded)
+}
+
+// NumOKIncidents returns the count of incidents with an impact that maps to
+// an OK state. If specified, incidents marked for exclusion are also
+// evaluated.
+func (s *Summary) NumOKIncidents(evalExcluded bool) int {
+	return s.numIncidentsWithExitCode(nagios.StateOKExitCode, evalExcluded)
+}
+
+// numIncidentsWithExitCode is a helper method used to count incidents with
+// an impact that maps to the given Nagios state exit code.
+func (s *Summary) numIncidentsWithExitCode(exitCode int, evalExcluded bool) int {
+	var num int
+	for i := range s.Incidents {
+		switch {
+		case s.Incidents[i].Exclude && !evalExcluded:
+			continue
+		case s.Incidents[i].ServiceState().ExitCode == exitCode:
+			num++
+		}
+	}
+
+	return num
+}
+
+// IncidentsServiceState returns the appropriate Service Check Status label
+// and exit code for the most severe incident in the summary. An OK state is
+// returned if there are no incidents to evaluate. If specified, incidents
+// marked for exclusion are also evaluated.
+func (s *Summary) IncidentsServiceState(evalExcluded bool) nagios.ServiceState {
+
+	var serviceState nagios.ServiceState
+
+	switch {
+	case s.NumCriticalIncidents(evalExcluded) > 0:
+		serviceState = nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		}
+
+	case s.NumWarningIncidents(evalExcluded) > 0:
+		serviceState = nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		}
+
+	case s.NumUnknownIncidents(evalExcluded) > 0:
+		serviceState = nagios.ServiceState{
+			Label:    nagios.StateUNKNOWNLabel,
+			ExitCode: nagios.StateUNKNOWNExitCode,
+		}
+
+	default:
+		serviceState = nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		}
+	}
+
+	return serviceState
+}
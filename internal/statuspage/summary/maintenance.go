@@ -0,0 +1,200 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package summary
+
+import (
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// Scheduled maintenance feed filenames. The upcoming feed lists scheduled
+// maintenances which have not yet started while the active feed lists
+// scheduled maintenances which are in progress or being verified.
+const (
+	MaintenanceUpcomingFeedFilename string = "upcoming.json"
+	MaintenanceActiveFeedFilename   string = "active.json"
+)
+
+// ScheduledMaintenanceFeedURLs returns the collection of scheduled
+// maintenance feed URLs to retrieve for the given URL. If the given URL
+// refers to either of the upcoming or active scheduled maintenance feeds, the
+// URLs for both feeds are returned. Otherwise (e.g., for the summary.json
+// endpoint), the given URL is returned as-is.
+func ScheduledMaintenanceFeedURLs(apiURL string) []string {
+
+	parsedURL, err := url.Parse(apiURL)
+	if err != nil {
+		logger.Printf("failed to parse URL %q: %v", apiURL, err)
+
+		return []string{apiURL}
+	}
+
+	dir, file := path.Split(parsedURL.Path)
+	if !strings.HasSuffix(dir, "/scheduled-maintenances/") {
+		return []string{apiURL}
+	}
+
+	switch file {
+	case MaintenanceUpcomingFeedFilename, MaintenanceActiveFeedFilename:
+		upcomingURL := *parsedURL
+		upcomingURL.Path = dir + MaintenanceUpcomingFeedFilename
+
+		activeURL := *parsedURL
+		activeURL.Path = dir + MaintenanceActiveFeedFilename
+
+		return []string{upcomingURL.String(), activeURL.String()}
+
+	default:
+		return []string{apiURL}
+	}
+}
+
+// IsActive indicates whether the scheduled maintenance is currently in
+// progress (or being verified).
+func (m ScheduledMaintenance) IsActive() bool {
+	switch m.Status {
+	case MaintenanceStatusInProgress, MaintenanceStatusVerifying:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsUpcoming indicates whether the scheduled maintenance has yet to start.
+func (m ScheduledMaintenance) IsUpcoming() bool {
+	return m.Status == MaintenanceStatusScheduled
+}
+
+// StartsWithin indicates whether the scheduled maintenance has yet to start
+// and is scheduled to start before the given lookahead duration elapses.
+// Scheduled maintenances which are overdue (scheduled start time has passed,
+// but the maintenance has not yet been marked as in progress) are also
+// reported as starting within the lookahead duration.
+func (m ScheduledMaintenance) StartsWithin(now time.Time, within time.Duration) bool {
+	if !m.IsUpcoming() {
+		return false
+	}
+
+	return !m.ScheduledFor.After(now.Add(within))
+}
+
+// MergeScheduledMaintenances adds scheduled maintenances from the given
+// Summary which are not already present in this Summary. This is intended
+// for combining the separate upcoming and active scheduled maintenance feeds.
+func (s *Summary) MergeScheduledMaintenances(other *Summary) {
+	if other == nil {
+		return
+	}
+
+	known := make(map[string]struct{}, len(s.ScheduledMaintenances))
+	for _, maintenance := range s.ScheduledMaintenances {
+		known[maintenance.ID] = struct{}{}
+	}
+
+	for _, maintenance := range other.ScheduledMaintenances {
+		if _, ok := known[maintenance.ID]; ok {
+			continue
+		}
+		s.ScheduledMaintenances = append(s.ScheduledMaintenances, maintenance)
+		known[maintenance.ID] = struct{}{}
+	}
+}
+
+// NumScheduledMaintenances returns the count of all scheduled maintenances
+// in the summary (including those marked for exclusion).
+func (s *Summary) NumScheduledMaintenances() int {
+	return len(s.ScheduledMaintenances)
+}
+
+// NumExcludedScheduledMaintenances returns the count of scheduled
+// maintenances marked for exclusion.
+func (s *Summary) NumExcludedScheduledMaintenances() int {
+	var num int
+	for i := range s.ScheduledMaintenances {
+		if s.ScheduledMaintenances[i].Exclude {
+			num++
+		}
+	}
+
+	return num
+}
+
+// NotExcludedScheduledMaintenances returns all scheduled maintenances which
+// have not been marked for exclusion.
+func (s *Summary) NotExcludedScheduledMaintenances() []*ScheduledMaintenance {
+	maintenances := make([]*ScheduledMaintenance, 0, len(s.ScheduledMaintenances))
+	for i := range s.ScheduledMaintenances {
+		if !s.ScheduledMaintenances[i].Exclude {
+			maintenances = append(maintenances, &s.ScheduledMaintenances[i])
+		}
+	}
+
+	return maintenances
+}
+
+// ActiveScheduledMaintenances returns all scheduled maintenances which are
+// currently in progress. If specified, scheduled maintenances marked for
+// exclusion are also evaluated.
+func (s *Summary) ActiveScheduledMaintenances(evalExcluded bool) []*ScheduledMaintenance {
+	maintenances := make([]*ScheduledMaintenance, 0, len(s.ScheduledMaintenances))
+	for i := range s.ScheduledMaintenances {
+		switch {
+		case s.ScheduledMaintenances[i].Exclude && !evalExcluded:
+			continue
+		case s.ScheduledMaintenances[i].IsActive():
+			maintenances = append(maintenances, &s.ScheduledMaintenances[i])
+		}
+	}
+
+	return maintenances
+}
+
+// UpcomingScheduledMaintenances returns all scheduled maintenances which are
+// scheduled to start before the given lookahead duration elapses. If
+// specified, scheduled maintenances marked for exclusion are also evaluated.
+func (s *Summary) UpcomingScheduledMaintenances(now time.Time, within time.Duration, evalExcluded bool) []*ScheduledMaintenance {
+	maintenances := make([]*ScheduledMaintenance, 0, len(s.ScheduledMaintenances))
+	for i := range s.ScheduledMaintenances {
+		switch {
+		case s.ScheduledMaintenances[i].Exclude && !evalExcluded:
+			continue
+		case s.ScheduledMaintenances[i].StartsWithin(now, within):
+			maintenances = append(maintenances, &s.ScheduledMaintenances[i])
+		}
+	}
+
+	return maintenances
+}
+
+// ScheduledMaintenancesServiceState returns the appropriate Service Check
+// Status label and exit code for the scheduled maintenances in the summary.
+// A WARNING state is returned if any scheduled maintenance is in progress or
+// is scheduled to start before the given lookahead duration elapses,
+// otherwise an OK state is returned. If specified, scheduled maintenances
+// marked for exclusion are also evaluated.
+func (s *Summary) ScheduledMaintenancesServiceState(now time.Time, within time.Duration, evalExcluded bool) nagios.ServiceState {
+
+	switch {
+	case len(s.ActiveScheduledMaintenances(evalExcluded)) > 0,
+		len(s.UpcomingScheduledMaintenances(now, within, evalExcluded)) > 0:
+		return nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		}
+
+	default:
+		return nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package summary
+
+import (
+	"fmt"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// ServiceStateToStatusIndicators returns a list of page status indicator
+// values which map to the specified ServiceState. This list is intended for
+// use in threshold details emitted in plugin output.
+func ServiceStateToStatusIndicators(serviceState nagios.ServiceState) []string {
+
+	switch serviceState.ExitCode {
+	case nagios.StateOKExitCode:
+		return []string{
+			StatusIndicatorNone,
+		}
+
+	case nagios.StateWARNINGExitCode:
+		return []string{
+			StatusIndicatorMinor,
+		}
+
+	case nagios.StateCRITICALExitCode:
+		return []string{
+			StatusIndicatorMajor,
+			StatusIndicatorCritical,
+		}
+
+	default:
+		return []string{}
+	}
+}
+
+// StatusIndicatorToServiceState converts a given page status indicator value
+// to the appropriate Nagios ServiceState. An UNKNOWN state is returned for
+// unrecognized indicator values.
+func StatusIndicatorToServiceState(indicator string) nagios.ServiceState {
+
+	switch indicator {
+	case StatusIndicatorNone:
+		return nagios.ServiceState{
+			Label:    nagios.StateOKLabel,
+			ExitCode: nagios.StateOKExitCode,
+		}
+
+	case StatusIndicatorMinor:
+		return nagios.ServiceState{
+			Label:    nagios.StateWARNINGLabel,
+			ExitCode: nagios.StateWARNINGExitCode,
+		}
+
+	case StatusIndicatorMajor, StatusIndicatorCritical:
+		return nagios.ServiceState{
+			Label:    nagios.StateCRITICALLabel,
+			ExitCode: nagios.StateCRITICALExitCode,
+		}
+
+	default:
+		// this shouldn't be reached, so assume something really odd occurred
+		logger.Printf("unknown page status indicator %q provided, indicate UNKNOWN status", indicator)
+		return nagios.ServiceState{
+			Label:    nagios.StateUNKNOWNLabel,
+			ExitCode: nagios.StateUNKNOWNExitCode,
+		}
+	}
+}
+
+// StatusIndicatorLevel returns a numeric value for a given page status
+// indicator suitable for use as performance data. Higher values indicate
+// greater severity. A value of -1 is returned for unrecognized indicator
+// values.
+func StatusIndicatorLevel(indicator string) int {
+	switch indicator {
+	case StatusIndicatorNone:
+		return 0
+	case StatusIndicatorMinor:
+		return 1
+	case StatusIndicatorMajor:
+		return 2
+	case StatusIndicatorCritical:
+		return 3
+	default:
+		return -1
+	}
+}
+
+// ServiceState returns the Nagios ServiceState for the page status based on
+// its indicator value.
+func (st Status) ServiceState() nagios.ServiceState {
+	return StatusIndicatorToServiceState(st.Indicator)
+}
+
+// IsOKState indicates whether the page status indicator maps to an OK state.
+func (st Status) IsOKState() bool {
+	return st.ServiceState().ExitCode == nagios.StateOKExitCode
+}
+
+// String implements the Stringer interface for a page status.
+func (st Status) String() string {
+	return fmt.Sprintf(
+		"{Indicator: %s, Description: %s}",
+		st.Indicator,
+		st.Description,
+	)
+}
+
+// ValidateStatus runs basic validation checks on the decoded page status
+// details. Unlike Validate, which only requires page details, this method is
+// intended for use with the /api/v2/status.json endpoint (or the summary.json
+// endpoint) where the page status is expected to be present. An error is
+// returned if any validation checks fail.
+func (s *Summary) ValidateStatus() error {
+
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	if s.Status.Indicator == "" {
+		return fmt.Errorf(
+			"%w: Status.Indicator field empty",
+			ErrSummaryValidationFailed,
+		)
+	}
+
+	return nil
+}
+
+// StatusServiceState returns the appropriate Service Check Status label and
+// exit code for the overall page status indicator. This is independent of
+// the status of individual components; some vendors set the page-wide
+// indicator while leaving all components in an operational status.
+func (s *Summary) StatusServiceState() nagios.ServiceState {
+	return s.Status.ServiceState()
+}
@@ -0,0 +1,410 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package summary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/atc0005/check-statuspage/internal/statuspage"
+	"github.com/atc0005/check-statuspage/internal/statuspage/components"
+)
+
+// apiPathPrefix is the Statuspage API/JSON feed path prefix and
+// componentsFeedFilename the feed listing all components for a page.
+const (
+	apiPathPrefix          string = "/api/v2/"
+	componentsFeedFilename string = "components.json"
+)
+
+// Page status indicator enums.
+// https://developer.statuspage.io/#operation/getPagesPageIdStatus
+const (
+	StatusIndicatorNone     string = "none"
+	StatusIndicatorMinor    string = "minor"
+	StatusIndicatorMajor    string = "major"
+	StatusIndicatorCritical string = "critical"
+)
+
+// Incident impact enums. Scheduled maintenances use the additional
+// maintenance impact value.
+// https://developer.statuspage.io/#operation/getPagesPageIdIncidents
+const (
+	IncidentImpactNone        string = "none"
+	IncidentImpactMinor       string = "minor"
+	IncidentImpactMajor       string = "major"
+	IncidentImpactCritical    string = "critical"
+	IncidentImpactMaintenance string = "maintenance"
+)
+
+// Incident status enums.
+const (
+	IncidentStatusInvestigating string = "investigating"
+	IncidentStatusIdentified    string = "identified"
+	IncidentStatusMonitoring    string = "monitoring"
+	IncidentStatusResolved      string = "resolved"
+	IncidentStatusPostmortem    string = "postmortem"
+)
+
+// Scheduled maintenance status enums.
+const (
+	MaintenanceStatusScheduled  string = "scheduled"
+	MaintenanceStatusInProgress string = "in_progress"
+	MaintenanceStatusVerifying  string = "verifying"
+	MaintenanceStatusCompleted  string = "completed"
+)
+
+// Summary represents the summary of a Statuspage-enabled site as provided by
+// the /api/v2/summary.json endpoint.
+//
+// Other endpoints (e.g., /api/v2/status.json,
+// /api/v2/incidents/unresolved.json) provide a subset of these fields and
+// may also be decoded using this type.
+type Summary struct {
+	Page statuspage.Page `json:"page"`
+
+	// Status is the overall page status indicator and description.
+	Status Status `json:"status"`
+
+	// Components is the collection of components for the page.
+	Components []components.Component `json:"components"`
+
+	// Incidents is the collection of unresolved incidents for the page.
+	Incidents []Incident `json:"incidents"`
+
+	// ScheduledMaintenances is the collection of upcoming or in-progress
+	// scheduled maintenances for the page.
+	ScheduledMaintenances []ScheduledMaintenance `json:"scheduled_maintenances"`
+
+	// FilterUsed indicates what components Filter values were applied to
+	// this Summary (if any).
+	FilterUsed components.Filter `json:"-"`
+
+	// FilterApplied indicates whether the Filter() method has been called
+	// with valid Filter values.
+	FilterApplied bool `json:"-"`
+}
+
+// Status represents the rollup status of all components on a page.
+type Status struct {
+
+	// Indicator is one of none, minor, major or critical.
+	Indicator string `json:"indicator"`
+
+	// Description is the human readable text shown for the page status
+	// (e.g., "All Systems Operational", "Partially Degraded Service").
+	Description string `json:"description"`
+}
+
+// Incident represents an incident reported for a Statuspage-enabled site.
+type Incident struct {
+
+	// ID is the unique identifier for the incident.
+	ID string `json:"id"`
+
+	// Name is the human readable title of the incident.
+	Name string `json:"name"`
+
+	// Status is the current status of the incident (e.g., investigating,
+	// identified). Uses a fixed set of enum values.
+	Status string `json:"status"`
+
+	// Impact is the value of impact set by the page author. Uses a fixed
+	// set of enum values.
+	Impact string `json:"impact"`
+
+	// Shortlink is a shortened URL for the incident.
+	Shortlink string `json:"shortlink"`
+
+	// PageID is the unique identifier for the Statuspage associated with the
+	// incident.
+	PageID string `json:"page_id"`
+
+	// CreatedAt is the creation time for the incident.
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt indicates when the incident was last updated.
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// StartedAt indicates when the incident started.
+	StartedAt time.Time `json:"started_at"`
+
+	// MonitoringAt indicates when the incident entered the monitoring
+	// status. This value may be null in the JSON feed and is left as the
+	// zero value if so.
+	MonitoringAt time.Time `json:"monitoring_at"`
+
+	// ResolvedAt indicates when the incident was resolved. This value may be
+	// null in the JSON feed and is left as the zero value if so.
+	ResolvedAt time.Time `json:"resolved_at"`
+
+	// Components is the collection of components affected by the incident.
+	Components []components.Component `json:"components"`
+
+	// IncidentUpdates is the collection of updates posted for the incident,
+	// most recent first.
+	IncidentUpdates []IncidentUpdate `json:"incident_updates"`
+
+	// Exclude indicates whether this incident has been marked for exclusion
+	// from overall plugin status evaluation. Until filtering is applied, all
+	// incidents are evaluated.
+	Exclude bool `json:"-"`
+}
+
+// ScheduledMaintenance represents a scheduled maintenance for a
+// Statuspage-enabled site. Scheduled maintenances are incidents with
+// additional fields noting the maintenance window.
+type ScheduledMaintenance struct {
+	Incident
+
+	// ScheduledFor indicates when the maintenance is scheduled to start.
+	ScheduledFor time.Time `json:"scheduled_for"`
+
+	// ScheduledUntil indicates when the maintenance is scheduled to end.
+	ScheduledUntil time.Time `json:"scheduled_until"`
+}
+
+// IncidentUpdate represents an update posted for an incident or scheduled
+// maintenance.
+type IncidentUpdate struct {
+
+	// ID is the unique identifier for the incident update.
+	ID string `json:"id"`
+
+	// Status is the status of the incident as of this update.
+	Status string `json:"status"`
+
+	// Body is the update text.
+	Body string `json:"body"`
+
+	// IncidentID is the unique identifier for the associated incident.
+	IncidentID string `json:"incident_id"`
+
+	// CreatedAt is the creation time for the update.
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt indicates when the update was last modified.
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// DisplayAt indicates the time shown for the update on the page.
+	DisplayAt time.Time `json:"display_at"`
+
+	// AffectedComponents is the collection of component status changes
+	// recorded with this update. This value may be null in the JSON feed.
+	AffectedComponents []AffectedComponent `json:"affected_components"`
+
+	// DeliverNotifications indicates whether notifications were sent for
+	// this update.
+	DeliverNotifications bool `json:"deliver_notifications"`
+
+	// CustomTweet is the custom text tweeted for this update, if any. This
+	// value may be null in the JSON feed.
+	CustomTweet statuspage.NullString `json:"custom_tweet"`
+
+	// TweetID is the ID of the tweet for this update, if any. This value may
+	// be null in the JSON feed.
+	TweetID statuspage.NullString `json:"tweet_id"`
+}
+
+// AffectedComponent represents a component status change recorded as part
+// of an incident update.
+type AffectedComponent struct {
+
+	// Code is the unique identifier of the affected component.
+	Code string `json:"code"`
+
+	// Name is the name of the affected component as displayed in the
+	// update. This is often prefixed with the component group name.
+	Name string `json:"name"`
+
+	// OldStatus is the status of the component before the update.
+	OldStatus string `json:"old_status"`
+
+	// NewStatus is the status of the component after the update.
+	NewStatus string `json:"new_status"`
+}
+
+// NewFromURL constructs a Summary by reading and decoding JSON data from a
+// specified URL using the specified number of bytes as the read limit. If
+// specified, unknown fields in the JSON data are ignored. An error is
+// returned if there are problems reading and decoding JSON data. If
+// provided, a custom user agent is supplied in place of the default Go user
+// agent. The given options control optional behavior such as response
+// caching. If the last good copy of the feed is used, the Summary is
+// returned along with a StaleFeedError.
+func NewFromURL(ctx context.Context, apiURL string, limit int64, allowUnknownFields bool, userAgent string, opts statuspage.FetchOptions) (*Summary, error) {
+
+	logger.Printf("Retrieving summary from %q", apiURL)
+
+	var summary Summary
+	err := statuspage.DecodeFromURL(ctx, &summary, apiURL, limit, allowUnknownFields, userAgent, opts)
+
+	var staleErr *statuspage.StaleFeedError
+	switch {
+	case errors.As(err, &staleErr):
+		logger.Printf("Using last good summary from %q", apiURL)
+
+		return &summary, err
+
+	case err != nil:
+		return &Summary{}, err
+	}
+
+	logger.Printf("Successfully retrieved summary from %q", apiURL)
+
+	return &summary, nil
+
+}
+
+// NewFromFile constructs a Summary by reading and decoding JSON data from a
+// fully-qualified path to a JSON file using the specified number of bytes as
+// the read limit. If specified, unknown fields in the JSON file are ignored.
+// Gzip (.gz) and zstd (.zst) compressed files are decompressed transparently;
+// the read limit applies to the decompressed size. An error is returned if
+// there are problems reading the specified file.
+func NewFromFile(filename string, limit int64, allowUnknownFields bool) (*Summary, error) {
+
+	logger.Printf("Retrieving summary from file %s", filename)
+
+	var summary Summary
+	err := statuspage.DecodeFromFile(&summary, filename, limit, allowUnknownFields)
+	if err != nil {
+		return &Summary{}, err
+	}
+
+	logger.Printf("Successfully retrieved summary from file %s", filename)
+
+	return &summary, nil
+}
+
+// Validate runs very basic validation checks on the decoded JSON input for
+// fields that we either use or expect to use in the future. An error is
+// returned if any validation checks fail.
+//
+// Only the page details are required; other collections are validated if
+// present since not all endpoints provide them.
+func (s *Summary) Validate() error {
+
+	switch {
+	case s.Page.ID == "":
+		return fmt.Errorf(
+			"%w: Page.ID field empty",
+			ErrSummaryValidationFailed,
+		)
+
+	case s.Page.Name == "":
+		return fmt.Errorf(
+			"%w: Page.Name field empty",
+			ErrSummaryValidationFailed,
+		)
+
+	case s.Page.URL == "":
+		return fmt.Errorf(
+			"%w: Page.URL field empty",
+			ErrSummaryValidationFailed,
+		)
+	}
+
+	for i, incident := range s.Incidents {
+
+		// Saying incident 3 when the feed shows as 4th might be confusing,
+		// so display using 1 as starting value.
+		humanReadableIncidentNumber := i + 1
+
+		switch {
+		case incident.ID == "":
+			return fmt.Errorf(
+				"%w: Incident[%d].ID field empty",
+				ErrSummaryValidationFailed,
+				humanReadableIncidentNumber,
+			)
+
+		case incident.Impact == "":
+			return fmt.Errorf(
+				"%w: Incident[%d].Impact field empty",
+				ErrSummaryValidationFailed,
+				humanReadableIncidentNumber,
+			)
+		}
+	}
+
+	for i, maintenance := range s.ScheduledMaintenances {
+
+		humanReadableMaintenanceNumber := i + 1
+
+		switch {
+		case maintenance.ID == "":
+			return fmt.Errorf(
+				"%w: ScheduledMaintenance[%d].ID field empty",
+				ErrSummaryValidationFailed,
+				humanReadableMaintenanceNumber,
+			)
+
+		case maintenance.ScheduledFor.IsZero():
+			return fmt.Errorf(
+				"%w: ScheduledMaintenance[%d].ScheduledFor field empty",
+				ErrSummaryValidationFailed,
+				humanReadableMaintenanceNumber,
+			)
+		}
+	}
+
+	return nil
+
+}
+
+// ComponentsSet returns a components Set composed of the page details and
+// components included in the summary. This allows applying existing
+// components Set evaluation and filtering logic to summary data. The
+// returned Set uses a copy of the summary components collection.
+func (s *Summary) ComponentsSet() *components.Set {
+	set := components.Set{
+		Page:       s.Page,
+		Components: make([]components.Component, len(s.Components)),
+	}
+	copy(set.Components, s.Components)
+
+	return &set
+}
+
+// UseComponents records the components from the given Set as the collection
+// of components for the page. This allows the Filter method to resolve
+// component and component group names for summaries decoded from endpoints
+// which do not provide the collection of page components (e.g.,
+// /api/v2/incidents/unresolved.json).
+func (s *Summary) UseComponents(componentsSet *components.Set) {
+	s.Components = make([]components.Component, len(componentsSet.Components))
+	copy(s.Components, componentsSet.Components)
+}
+
+// ComponentsFeedURL returns the URL of the components.json feed for the page
+// associated with the given Statuspage API/JSON feed URL. An empty string is
+// returned if the given URL does not refer to a Statuspage API/JSON feed.
+func ComponentsFeedURL(apiURL string) string {
+
+	parsedURL, err := url.Parse(apiURL)
+	if err != nil {
+		logger.Printf("failed to parse URL %q: %v", apiURL, err)
+
+		return ""
+	}
+
+	idx := strings.Index(parsedURL.Path, apiPathPrefix)
+	if idx < 0 {
+		return ""
+	}
+
+	parsedURL.Path = parsedURL.Path[:idx] + apiPathPrefix + componentsFeedFilename
+	parsedURL.RawQuery = ""
+
+	return parsedURL.String()
+}
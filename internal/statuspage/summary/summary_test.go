@@ -0,0 +1,447 @@
+// Copyright 2021 Adam Chalkley
+//
+// https://github.com/atc0005/check-statuspage
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package summary
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/atc0005/go-nagios"
+)
+
+// Testdata files shared across tests. The testdata directory is three levels
+// up from this package.
+const (
+	instructureSummaryFile     = "../../../testdata/summary/instructure-summary.json"
+	instructureIncidentsFile   = "../../../testdata/incidents/instructure-incidents-unresolved.json"
+	instructureUpcomingFile    = "../../../testdata/scheduled-maintenances/instructure-scheduled-maintenances-upcoming.json"
+	instructureActiveFile      = "../../../testdata/scheduled-maintenances/instructure-scheduled-maintenances-active.json"
+	instructureComponentsCount = 24
+	testReadLimit              = 1048576 // 1 MB
+)
+
+var (
+	okState = nagios.ServiceState{
+		Label:    nagios.StateOKLabel,
+		ExitCode: nagios.StateOKExitCode,
+	}
+
+	warningState = nagios.ServiceState{
+		Label:    nagios.StateWARNINGLabel,
+		ExitCode: nagios.StateWARNINGExitCode,
+	}
+
+	criticalState = nagios.ServiceState{
+		Label:    nagios.StateCRITICALLabel,
+		ExitCode: nagios.StateCRITICALExitCode,
+	}
+
+	unknownState = nagios.ServiceState{
+		Label:    nagios.StateUNKNOWNLabel,
+		ExitCode: nagios.StateUNKNOWNExitCode,
+	}
+)
+
+// loadSummary is a helper function used to decode and validate the given
+// testdata file.
+func loadSummary(t *testing.T, filename string) *Summary {
+	t.Helper()
+
+	s, err := NewFromFile(filename, testReadLimit, false)
+	if err != nil {
+		t.Fatalf("ERROR: failed to decode %s: %v", filename, err)
+	}
+
+	if err := s.Validate(); err != nil {
+		t.Fatalf("ERROR: failed to validate %s: %v", filename, err)
+	}
+
+	return s
+}
+
+// TestNewFromFile asserts that each summary endpoint testdata file is
+// decoded and that a missing file results in an error.
+func TestNewFromFile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                  string
+		filename              string
+		errorExpected         bool
+		expectedComponents    int
+		expectedIncidents     int
+		expectedMaintenances  int
+		expectedStatusPresent bool
+	}{
+		{
+			name:                  "Summary",
+			filename:              instructureSummaryFile,
+			expectedComponents:    instructureComponentsCount,
+			expectedIncidents:     1,
+			expectedStatusPresent: true,
+		},
+		{
+			name:              "Unresolved incidents",
+			filename:          instructureIncidentsFile,
+			expectedIncidents: 1,
+		},
+		{
+			name:                 "Upcoming scheduled maintenances",
+			filename:             instructureUpcomingFile,
+			expectedMaintenances: 2,
+		},
+		{
+			name:                 "Active scheduled maintenances",
+			filename:             instructureActiveFile,
+			expectedMaintenances: 1,
+		},
+		{
+			name:          "Nonexistent file",
+			filename:      "../../../testdata/summary/this-file-does-not-exist.json",
+			errorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := NewFromFile(test.filename, testReadLimit, false)
+
+			switch {
+			case err != nil && test.errorExpected:
+				t.Logf("OK: error occurred as expected: %v", err)
+
+				return
+
+			case err != nil:
+				t.Fatalf("ERROR: failed to decode %s: %v", test.filename, err)
+
+			case test.errorExpected:
+				t.Fatalf("ERROR: error expected for %s, but none occurred", test.filename)
+			}
+
+			switch {
+			case len(s.Components) != test.expectedComponents:
+				t.Errorf("ERROR: want %d components, got %d", test.expectedComponents, len(s.Components))
+
+			case s.NumIncidents() != test.expectedIncidents:
+				t.Errorf("ERROR: want %d incidents, got %d", test.expectedIncidents, s.NumIncidents())
+
+			case s.NumScheduledMaintenances() != test.expectedMaintenances:
+				t.Errorf(
+					"ERROR: want %d scheduled maintenances, got %d",
+					test.expectedMaintenances,
+					s.NumScheduledMaintenances(),
+				)
+
+			case (s.Status.Indicator != "") != test.expectedStatusPresent:
+				t.Errorf("ERROR: unexpected status indicator %q", s.Status.Indicator)
+
+			default:
+				t.Logf("OK: decoded %s", test.filename)
+			}
+		})
+	}
+}
+
+// TestNewFromFileUnknownFields asserts that unknown fields in JSON data are
+// rejected unless explicitly allowed.
+func TestNewFromFileUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "summary.json")
+	content := `{
+		"page": {"id": "nlxv32btr6v7", "name": "Instructure", "url": "https://status.instructure.com"},
+		"unexpected_field": true
+	}`
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatalf("ERROR: failed to write testdata file: %v", err)
+	}
+
+	if _, err := NewFromFile(filename, testReadLimit, false); err == nil {
+		t.Error("ERROR: want error for unknown field, got none")
+	} else {
+		t.Logf("OK: got expected error for unknown field: %v", err)
+	}
+
+	s, err := NewFromFile(filename, testReadLimit, true)
+	switch {
+	case err != nil:
+		t.Errorf("ERROR: want unknown field to be ignored, got %v", err)
+	case s.Page.ID != "nlxv32btr6v7":
+		t.Errorf("ERROR: want page ID %q, got %q", "nlxv32btr6v7", s.Page.ID)
+	default:
+		t.Log("OK: unknown field ignored as requested")
+	}
+}
+
+// TestValidate asserts that validation fails for summaries missing required
+// page, incident or scheduled maintenance fields.
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		filename      string
+		modify        func(s *Summary)
+		errorExpected bool
+	}{
+		{
+			name:     "Summary",
+			filename: instructureSummaryFile,
+		},
+		{
+			name:     "Upcoming scheduled maintenances",
+			filename: instructureUpcomingFile,
+		},
+		{
+			name:          "Missing page ID",
+			filename:      instructureSummaryFile,
+			modify:        func(s *Summary) { s.Page.ID = "" },
+			errorExpected: true,
+		},
+		{
+			name:          "Missing page URL",
+			filename:      instructureSummaryFile,
+			modify:        func(s *Summary) { s.Page.URL = "" },
+			errorExpected: true,
+		},
+		{
+			name:          "Missing incident impact",
+			filename:      instructureIncidentsFile,
+			modify:        func(s *Summary) { s.Incidents[0].Impact = "" },
+			errorExpected: true,
+		},
+		{
+			name:          "Missing scheduled maintenance start",
+			filename:      instructureActiveFile,
+			modify:        func(s *Summary) { s.ScheduledMaintenances[0].ScheduledFor = time.Time{} },
+			errorExpected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := NewFromFile(test.filename, testReadLimit, false)
+			if err != nil {
+				t.Fatalf("ERROR: failed to decode %s: %v", test.filename, err)
+			}
+
+			if test.modify != nil {
+				test.modify(s)
+			}
+
+			err = s.Validate()
+			switch {
+			case err != nil && !test.errorExpected:
+				t.Errorf("ERROR: unexpected validation error: %v", err)
+
+			case err == nil && test.errorExpected:
+				t.Error("ERROR: validation error expected, but none occurred")
+
+			case err != nil && !errors.Is(err, ErrSummaryValidationFailed):
+				t.Errorf("ERROR: want error wrapping %v, got %v", ErrSummaryValidationFailed, err)
+
+			default:
+				t.Logf("OK: validation result as expected: %v", err)
+			}
+		})
+	}
+}
+
+// TestComponentsSet asserts that the components Set for a summary reflects
+// the page details and a copy of the summary components.
+func TestComponentsSet(t *testing.T) {
+	t.Parallel()
+
+	s := loadSummary(t, instructureSummaryFile)
+
+	set := s.ComponentsSet()
+
+	switch {
+	case set.Page.ID != s.Page.ID:
+		t.Errorf("ERROR: want page ID %q, got %q", s.Page.ID, set.Page.ID)
+
+	case set.NumComponents() != instructureComponentsCount:
+		t.Errorf("ERROR: want %d components, got %d", instructureComponentsCount, set.NumComponents())
+
+	case set.Validate() != nil:
+		t.Errorf("ERROR: failed to validate components set: %v", set.Validate())
+	}
+
+	set.Components[0].Exclude = true
+	if s.Components[0].Exclude {
+		t.Error("ERROR: changes to components set modified summary components")
+	} else {
+		t.Log("OK: components set uses a copy of summary components")
+	}
+}
+
+// TestIncidentImpactToServiceState asserts the ServiceState for each
+// incident impact value and the impact values listed for each ServiceState.
+func TestIncidentImpactToServiceState(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		impact        string
+		expectedState nagios.ServiceState
+	}{
+		{impact: IncidentImpactNone, expectedState: okState},
+		{impact: IncidentImpactMinor, expectedState: warningState},
+		{impact: IncidentImpactMaintenance, expectedState: warningState},
+		{impact: IncidentImpactMajor, expectedState: criticalState},
+		{impact: IncidentImpactCritical, expectedState: criticalState},
+		{impact: "unexpected", expectedState: unknownState},
+	}
+
+	for _, test := range tests {
+		t.Run(test.impact, func(t *testing.T) {
+			got := IncidentImpactToServiceState(test.impact)
+			if got != test.expectedState {
+				t.Fatalf("ERROR: want %#v, got %#v", test.expectedState, got)
+			}
+
+			if test.expectedState == unknownState {
+				return
+			}
+
+			var listed bool
+			for _, impact := range ServiceStateToIncidentImpacts(got) {
+				listed = listed || impact == test.impact
+			}
+
+			if !listed {
+				t.Errorf("ERROR: impact %q not listed for state %s", test.impact, got.Label)
+			} else {
+				t.Logf("OK: impact %q maps to state %s", test.impact, got.Label)
+			}
+		})
+	}
+}
+
+// TestIncidentsServiceState asserts the incident counts and ServiceState for
+// the unresolved incidents testdata file with and without excluded
+// incidents.
+func TestIncidentsServiceState(t *testing.T) {
+	t.Parallel()
+
+	s := loadSummary(t, instructureIncidentsFile)
+
+	switch {
+	case s.NumWarningIncidents(false) != 1:
+		t.Errorf("ERROR: want 1 WARNING incident, got %d", s.NumWarningIncidents(false))
+
+	case s.NumProblemIncidents(false) != 1:
+		t.Errorf("ERROR: want 1 problem incident, got %d", s.NumProblemIncidents(false))
+
+	case s.IncidentsServiceState(false) != warningState:
+		t.Errorf("ERROR: want %#v, got %#v", warningState, s.IncidentsServiceState(false))
+	}
+
+	s.Incidents[0].Exclude = true
+
+	switch {
+	case s.IncidentsServiceState(false) != okState:
+		t.Errorf("ERROR: want %#v with incident excluded, got %#v", okState, s.IncidentsServiceState(false))
+
+	case s.IncidentsServiceState(true) != warningState:
+		t.Errorf("ERROR: want %#v evaluating excluded incidents, got %#v", warningState, s.IncidentsServiceState(true))
+
+	default:
+		t.Log("OK: got expected incident service states")
+	}
+}
+
+// TestScheduledMaintenancesServiceState asserts the ServiceState for active
+// and upcoming scheduled maintenances using a fixed evaluation time.
+func TestScheduledMaintenancesServiceState(t *testing.T) {
+	t.Parallel()
+
+	// Fixed evaluation time between the creation of the upcoming scheduled
+	// maintenances and the start of the earliest one.
+	now := time.Date(2021, time.December, 9, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		filename             string
+		within               time.Duration
+		expectedActive       int
+		expectedUpcoming     int
+		expectedServiceState nagios.ServiceState
+	}{
+		{
+			name:                 "Upcoming, within lookahead",
+			filename:             instructureUpcomingFile,
+			within:               24 * time.Hour,
+			expectedUpcoming:     1,
+			expectedServiceState: warningState,
+		},
+		{
+			name:                 "Upcoming, long lookahead",
+			filename:             instructureUpcomingFile,
+			within:               720 * time.Hour,
+			expectedUpcoming:     2,
+			expectedServiceState: warningState,
+		},
+		{
+			name:                 "Upcoming, beyond lookahead",
+			filename:             instructureUpcomingFile,
+			within:               6 * time.Hour,
+			expectedServiceState: okState,
+		},
+		{
+			name:                 "Active",
+			filename:             instructureActiveFile,
+			within:               time.Minute,
+			expectedActive:       1,
+			expectedServiceState: warningState,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := loadSummary(t, test.filename)
+
+			active := s.ActiveScheduledMaintenances(false)
+			upcoming := s.UpcomingScheduledMaintenances(now, test.within, false)
+			serviceState := s.ScheduledMaintenancesServiceState(now, test.within, false)
+
+			switch {
+			case len(active) != test.expectedActive:
+				t.Errorf("ERROR: want %d active, got %d", test.expectedActive, len(active))
+
+			case len(upcoming) != test.expectedUpcoming:
+				t.Errorf("ERROR: want %d upcoming, got %d", test.expectedUpcoming, len(upcoming))
+
+			case serviceState != test.expectedServiceState:
+				t.Errorf("ERROR: want %#v, got %#v", test.expectedServiceState, serviceState)
+
+			default:
+				t.Logf("OK: got expected state %s", serviceState.Label)
+			}
+		})
+	}
+}
+
+// TestMergeScheduledMaintenances asserts that merging the upcoming and
+// active feeds combines their scheduled maintenances without duplicates.
+func TestMergeScheduledMaintenances(t *testing.T) {
+	t.Parallel()
+
+	s := loadSummary(t, instructureUpcomingFile)
+	s.MergeScheduledMaintenances(loadSummary(t, instructureActiveFile))
+	s.MergeScheduledMaintenances(loadSummary(t, instructureActiveFile))
+
+	if got := s.NumScheduledMaintenances(); got != 3 {
+		t.Errorf("ERROR: want 3 scheduled maintenances, got %d", got)
+	} else {
+		t.Log("OK: scheduled maintenances merged without duplicates")
+	}
+}
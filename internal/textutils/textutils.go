@@ -27,3 +27,49 @@ func InList(needle string, haystack []string, ignoreCase bool) bool {
 	}
 	return false
 }
+
+// NormalizeSpace returns the given string with leading and trailing
+// whitespace removed and all other runs of whitespace replaced by a single
+// space.
+func NormalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// EditDistance returns the Levenshtein distance between the given strings;
+// the minimum number of single character insertions, deletions or
+// substitutions required to change one string into the other. Strings are
+// compared by rune (not byte) and are case-sensitive.
+func EditDistance(a string, b string) int {
+	ar := []rune(a)
+	br := []rune(b)
+
+	// Only the previous row of the distance matrix is needed to compute the
+	// current row.
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(
+				prev[j]+1,      // deletion
+				curr[j-1]+1,    // insertion
+				prev[j-1]+cost, // substitution
+			)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(br)]
+}